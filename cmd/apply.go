@@ -0,0 +1,277 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-cli/diff"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/runtime"
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-cli/sources"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func Apply(factory DPDKClientFactory) *cobra.Command {
+	var (
+		opts ApplyOptions
+	)
+	sourcesOptions := &SourcesOptions{}
+	rendererOptions := &RendererOptions{Output: "name"}
+
+	cmd := &cobra.Command{
+		Use:         "apply <-f> [--plan]",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Short:       "Create the objects of a file that do not exist yet, or show a plan of the changes",
+		Example:     "dpservice-cli apply -f objects.yaml --plan\ncat objects.yaml | dpservice-cli apply -f -",
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunApply(cmd.Context(), factory, rendererOptions, sourcesOptions, opts)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+	rendererOptions.AddFlags(cmd.Flags())
+	sourcesOptions.AddFlags(cmd.Flags())
+
+	util.Must(cmd.MarkFlagRequired("filename"))
+
+	return cmd
+}
+
+type ApplyOptions struct {
+	Plan bool
+}
+
+func (o *ApplyOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Plan, "plan", o.Plan, "Only print which objects would be created or changed, without applying anything.")
+}
+
+type PlanAction string
+
+const (
+	PlanCreate    PlanAction = "create"
+	PlanChange    PlanAction = "change"
+	PlanUnchanged PlanAction = "unchanged"
+)
+
+// PlanStep is what applying a single object would do to the live state.
+type PlanStep struct {
+	Action  PlanAction
+	Name    string
+	Object  any
+	Changes []diff.Change
+}
+
+// Plan compares every object with its live state. Fields only set in the live state
+// (e.g. an assigned underlay route) are not considered a change.
+func Plan(ctx context.Context, dc dynamic.Client, objs []any) ([]PlanStep, error) {
+	steps := make([]PlanStep, 0, len(objs))
+	for _, obj := range objs {
+		step := PlanStep{Name: planName(obj), Object: obj}
+
+		live, err := dc.Get(ctx, obj)
+		switch {
+		case errors.Is(err, dynamic.ErrNotFound):
+			step.Action = PlanCreate
+		case err != nil:
+			return nil, fmt.Errorf("error getting %s: %w", step.Name, err)
+		default:
+			changes, err := diff.Objects(live, obj)
+			if err != nil {
+				return nil, fmt.Errorf("error comparing %s: %w", step.Name, err)
+			}
+			for _, change := range changes {
+				if strings.HasPrefix(change.Path, "spec.") && change.Type != diff.Removed {
+					step.Changes = append(step.Changes, change)
+				}
+			}
+			step.Action = PlanUnchanged
+			if len(step.Changes) > 0 {
+				step.Action = PlanChange
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// planName names obj like the name output format does.
+func planName(obj any) string {
+	if o, ok := obj.(api.Object); ok {
+		return fmt.Sprintf("%s/%s", strings.ToLower(o.GetKind()), renderer.ObjectName(o))
+	}
+	return dynamic.ObjectKeyFromObject(obj).String()
+}
+
+// PrintPlan prints steps in the style of a terraform plan. Apply never deletes objects,
+// so there is no destroy step.
+func PrintPlan(w io.Writer, steps []PlanStep) {
+	counts := make(map[PlanAction]int)
+	for _, step := range steps {
+		counts[step.Action]++
+		switch step.Action {
+		case PlanCreate:
+			fmt.Fprintf(w, "+ create %s\n", step.Name)
+		case PlanChange:
+			fmt.Fprintf(w, "~ change %s\n", step.Name)
+			for _, change := range step.Changes {
+				fmt.Fprintf(w, "    %s\n", change)
+			}
+		}
+	}
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to change, %d unchanged, - (n/a) to destroy.\n",
+		counts[PlanCreate], counts[PlanChange], counts[PlanUnchanged])
+}
+
+type ApplyOutcome string
+
+const (
+	ApplyCreated ApplyOutcome = "created"
+	ApplySkipped ApplyOutcome = "skipped"
+	ApplyFailed  ApplyOutcome = "failed"
+)
+
+// applyExistsCodes are the status codes dpservice reports when creating an object that exists.
+var applyExistsCodes = []uint32{apierrors.ALREADY_EXISTS, apierrors.ROUTE_EXISTS, apierrors.DNAT_EXISTS, apierrors.SNAT_EXISTS}
+
+// ApplyOutcomeOf classifies the result of creating an object. Objects that were created
+// concurrently and already exist count as skipped.
+func ApplyOutcomeOf(err error) ApplyOutcome {
+	switch {
+	case err == nil:
+		return ApplyCreated
+	case apierrors.IsStatusErrorCode(err, applyExistsCodes...):
+		return ApplySkipped
+	default:
+		return ApplyFailed
+	}
+}
+
+type ApplyKindSummary struct {
+	Kind    string `json:"kind"`
+	Created int    `json:"created"`
+	Skipped int    `json:"skipped"`
+	Failed  int    `json:"failed"`
+}
+
+func (s ApplyKindSummary) String() string {
+	return fmt.Sprintf("%d created, %d skipped, %d failed", s.Created, s.Skipped, s.Failed)
+}
+
+// ApplySummary tallies the outcomes of an apply per kind, kinds are kept sorted.
+type ApplySummary struct {
+	Total ApplyKindSummary   `json:"total"`
+	Kinds []ApplyKindSummary `json:"kinds"`
+}
+
+func (s *ApplySummary) Add(kind string, outcome ApplyOutcome) {
+	i := sort.Search(len(s.Kinds), func(i int) bool { return s.Kinds[i].Kind >= kind })
+	if i == len(s.Kinds) || s.Kinds[i].Kind != kind {
+		s.Kinds = slices.Insert(s.Kinds, i, ApplyKindSummary{Kind: kind})
+	}
+	for _, summary := range []*ApplyKindSummary{&s.Total, &s.Kinds[i]} {
+		switch outcome {
+		case ApplyCreated:
+			summary.Created++
+		case ApplySkipped:
+			summary.Skipped++
+		case ApplyFailed:
+			summary.Failed++
+		}
+	}
+}
+
+func (s *ApplySummary) String() string {
+	parts := []string{fmt.Sprintf("Apply: %s", s.Total)}
+	for _, kind := range s.Kinds {
+		parts = append(parts, fmt.Sprintf("%s: %s", kind.Kind, kind))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func RunApply(
+	ctx context.Context,
+	dpdkClientFactory DPDKClientFactory,
+	rendererFactory RendererFactory,
+	sourcesReaderFactory SourcesReaderFactory,
+	opts ApplyOptions,
+) error {
+	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dpdk client: %w", err)
+	}
+	defer DpdkClose(cleanup)
+
+	dc := dynamic.NewFromStructured(client)
+
+	iterator, err := sourcesReaderFactory.NewIterator()
+	if err != nil {
+		return fmt.Errorf("error creating sources iterator: %w", err)
+	}
+
+	objs, err := sources.CollectObjects(iterator, runtime.DefaultScheme)
+	if err != nil {
+		return fmt.Errorf("error collecting objects: %w", err)
+	}
+
+	steps, err := Plan(ctx, dc, objs)
+	if err != nil {
+		return err
+	}
+	if opts.Plan {
+		PrintPlan(os.Stdout, steps)
+		return nil
+	}
+
+	summary := &ApplySummary{}
+	var toCreate []any
+	var changed []string
+	for _, step := range steps {
+		switch step.Action {
+		case PlanCreate:
+			toCreate = append(toCreate, step.Object)
+		case PlanChange:
+			changed = append(changed, step.Name)
+		case PlanUnchanged:
+			summary.Add(batchKind(step.Object), ApplySkipped)
+		}
+	}
+	// dpservice cannot update objects, so nothing is applied if any object would have to change
+	if len(changed) > 0 {
+		return fmt.Errorf("objects differ from the live state and cannot be updated in place, delete them first: %s", strings.Join(changed, ", "))
+	}
+
+	b, err := newBatchRenderer(os.Stdout, rendererFactory, "create")
+	if err != nil {
+		return err
+	}
+	b.summary = summary
+	for _, obj := range toCreate {
+		res, err := dc.Create(ctx, obj)
+		summary.Add(batchKind(obj), ApplyOutcomeOf(err))
+		if err := b.add(obj, res, err); err != nil {
+			return err
+		}
+	}
+	if err := b.flush(); err != nil {
+		return err
+	}
+	if summary.Total.Failed > 0 {
+		return fmt.Errorf("%d of %d objects failed to apply", summary.Total.Failed, len(objs))
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
+	"github.com/ironcore-dev/dpservice-go/api"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeDynamicClient struct {
+	dynamic.Client
+	live map[string]any
+}
+
+func (c *fakeDynamicClient) Get(_ context.Context, obj any) (any, error) {
+	live, ok := c.live[dynamic.ObjectKeyFromObject(obj).String()]
+	if !ok {
+		return nil, dynamic.ErrNotFound
+	}
+	return live, nil
+}
+
+// failingPrefixClient fails to create prefixes.
+type failingPrefixClient struct {
+	*replaceClient
+}
+
+func (c failingPrefixClient) CreatePrefix(ctx context.Context, prefix *api.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error) {
+	return &api.Prefix{Status: api.Status{Code: apierrors.NO_VM, Message: "NO_VM"}}, apierrors.NewStatusError(apierrors.NO_VM, "NO_VM")
+}
+
+var _ = Describe("RunApply", func() {
+	const objects = "kind: Interface\nmetadata:\n  id: vm1\nspec:\n  vni: 100\n  device: net_tap5\n" +
+		"---\n" +
+		"kind: Prefix\nmetadata:\n  interface_id: vm1\nspec:\n  prefix: 10.0.0.0/24\n"
+
+	It("should read objects from stdin", func(ctx SpecContext) {
+		stdin := os.Stdin
+		DeferCleanup(func() { os.Stdin = stdin })
+		filename := filepath.Join(GinkgoT().TempDir(), "stdin")
+		Expect(os.WriteFile(filename, []byte(objects), 0o644)).To(Succeed())
+		f, err := os.Open(filename)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(f.Close)
+		os.Stdin = f
+
+		c := &replaceClient{}
+		Expect(RunApply(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, &SourcesOptions{Filename: []string{"-"}}, ApplyOptions{})).To(Succeed())
+		Expect(c.calls).To(Equal([]string{"create interface/vm1", "create prefix/10.0.0.0/24"}))
+	})
+
+	It("should continue past failed objects and fail afterwards", func(ctx SpecContext) {
+		filename := filepath.Join(GinkgoT().TempDir(), "objects.yaml")
+		Expect(os.WriteFile(filename, []byte(objects), 0o644)).To(Succeed())
+
+		c := &replaceClient{}
+		err := RunApply(ctx, fakeClientFactory{failingPrefixClient{c}}, &RendererOptions{Output: "name"}, &SourcesOptions{Filename: []string{filename}}, ApplyOptions{})
+		Expect(err).To(MatchError("1 of 2 objects failed to apply"))
+		Expect(c.calls).To(Equal([]string{"create interface/vm1"}))
+	})
+})
+
+var _ = Describe("Plan", func() {
+	route := func(prefix, nextHop string, underlay bool) *api.Route {
+		p := netip.MustParsePrefix(prefix)
+		ip := netip.MustParseAddr(nextHop)
+		r := &api.Route{
+			TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+			RouteMeta: api.RouteMeta{VNI: 100},
+			Spec:      api.RouteSpec{Prefix: &p, NextHop: &api.RouteNextHop{VNI: 100, IP: &ip}},
+		}
+		if underlay {
+			r.Status = api.Status{Message: "live"}
+		}
+		return r
+	}
+
+	It("should plan creates and changes and ignore live-only fields", func(ctx SpecContext) {
+		live := &api.Interface{
+			TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+			InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			Spec:          api.InterfaceSpec{VNI: 100, Device: "net_tap5"},
+		}
+		dc := &fakeDynamicClient{live: map[string]any{
+			"100:10.0.1.0/24-100:fc00::1": route("10.0.1.0/24", "fc00::1", true),
+			"vm1":                         live,
+		}}
+		iface := &api.Interface{
+			TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+			InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			Spec:          api.InterfaceSpec{VNI: 100, Device: "net_tap6"},
+		}
+		objs := []any{
+			route("10.0.1.0/24", "fc00::1", false),
+			iface,
+			route("10.0.3.0/24", "fc00::3", false),
+		}
+
+		steps, err := Plan(ctx, dc, objs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(steps).To(HaveLen(3))
+		Expect(steps[0].Action).To(Equal(PlanUnchanged))
+		Expect(steps[1].Action).To(Equal(PlanChange))
+		Expect(steps[1].Changes).To(HaveLen(1))
+		Expect(steps[1].Changes[0].Path).To(Equal("spec.device"))
+		Expect(steps[2].Action).To(Equal(PlanCreate))
+
+		var buf bytes.Buffer
+		PrintPlan(&buf, steps)
+		Expect(buf.String()).To(Equal("~ change interface/vm1\n" +
+			"    ~ spec.device: net_tap5 -> net_tap6\n" +
+			"+ create route/10.0.3.0/24-100:fc00::3\n" +
+			"\nPlan: 1 to create, 1 to change, 1 unchanged, - (n/a) to destroy.\n"))
+	})
+
+	It("should plan routes with the same prefix and another next hop as create", func(ctx SpecContext) {
+		dc := &fakeDynamicClient{live: map[string]any{
+			"100:10.0.1.0/24-100:fc00::1": route("10.0.1.0/24", "fc00::1", true),
+		}}
+		objs := []any{
+			route("10.0.1.0/24", "fc00::1", false),
+			route("10.0.1.0/24", "fc00::2", false),
+		}
+
+		steps, err := Plan(ctx, dc, objs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(steps).To(HaveLen(2))
+		Expect(steps[0].Action).To(Equal(PlanUnchanged))
+		Expect(steps[1].Action).To(Equal(PlanCreate))
+	})
+})
+
+var _ = Describe("ApplySummary", func() {
+	It("should tally the outcomes per kind", func() {
+		summary := &ApplySummary{}
+		summary.Add(api.RouteKind, ApplyOutcomeOf(nil))
+		summary.Add(api.RouteKind, ApplyOutcomeOf(apierrors.NewStatusError(apierrors.ROUTE_EXISTS, "ROUTE_EXISTS")))
+		summary.Add(api.PrefixKind, ApplyOutcomeOf(errors.New("connection refused")))
+		summary.Add(api.RouteKind, ApplySkipped)
+
+		Expect(summary.Kinds).To(Equal([]ApplyKindSummary{
+			{Kind: api.PrefixKind, Failed: 1},
+			{Kind: api.RouteKind, Created: 1, Skipped: 2},
+		}))
+		Expect(summary.String()).To(Equal("Apply: 1 created, 2 skipped, 1 failed; " +
+			"Prefix: 0 created, 0 skipped, 1 failed; Route: 1 created, 2 skipped, 0 failed"))
+	})
+})
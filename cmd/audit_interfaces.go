@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+)
+
+// auditConcurrency bounds the number of concurrent requests issued by an audit.
+const auditConcurrency = 8
+
+const (
+	AuditWarningNoVirtualIP = "no virtual IP"
+	AuditWarningNoRoutes    = "no routes to underlay route"
+	AuditWarningSoleVNI     = "only interface in VNI"
+)
+
+// AuditInterfaces annotates ifaces with configuration warnings. all holds every
+// interface of dpservice and is used to cross-reference VNIs and routes.
+func AuditInterfaces(ctx context.Context, client client.Client, ifaces, all []api.Interface) (*renderer.AuditedInterfaceList, error) {
+	ifacesPerVNI := make(map[uint32]int)
+	for _, iface := range all {
+		ifacesPerVNI[iface.Spec.VNI]++
+	}
+	vnis := make([]uint32, 0, len(ifacesPerVNI))
+	for vni := range ifacesPerVNI {
+		vnis = append(vnis, vni)
+	}
+	sort.Slice(vnis, func(i, j int) bool { return vnis[i] < vnis[j] })
+
+	var (
+		mu       sync.Mutex
+		nextHops = make(map[netip.Addr]struct{})
+	)
+	if err := util.ForEachBounded(len(vnis), auditConcurrency, func(i int) error {
+		routes, err := client.ListRoutes(ctx, vnis[i])
+		if err != nil {
+			return fmt.Errorf("error listing routes of vni %d: %w", vnis[i], err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, route := range routes.Items {
+			if route.Spec.NextHop != nil && route.Spec.NextHop.IP != nil {
+				nextHops[*route.Spec.NextHop.IP] = struct{}{}
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	audited := make([]renderer.AuditedInterface, len(ifaces))
+	if err := util.ForEachBounded(len(ifaces), auditConcurrency, func(i int) error {
+		iface := ifaces[i]
+		warnings := []string{}
+
+		vip, err := client.GetVirtualIP(ctx, iface.ID)
+		if err != nil && vip.Status.Code == 0 {
+			return fmt.Errorf("error getting virtual ip of interface %s: %w", iface.ID, err)
+		}
+		if err != nil {
+			warnings = append(warnings, AuditWarningNoVirtualIP)
+		}
+
+		if iface.Spec.UnderlayRoute == nil {
+			warnings = append(warnings, AuditWarningNoRoutes)
+		} else if _, ok := nextHops[*iface.Spec.UnderlayRoute]; !ok {
+			warnings = append(warnings, AuditWarningNoRoutes)
+		}
+
+		if ifacesPerVNI[iface.Spec.VNI] <= 1 {
+			warnings = append(warnings, AuditWarningSoleVNI)
+		}
+
+		audited[i] = renderer.AuditedInterface{Interface: iface, Warnings: warnings}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &renderer.AuditedInterfaceList{
+		TypeMeta: api.TypeMeta{Kind: renderer.AuditedInterfaceListKind},
+		Items:    audited,
+	}, nil
+}
+
+// CountFirewallRules annotates ifaces with the number of their firewall rules. Interfaces
+// for which dpservice reports an error are counted as having no rules.
+func CountFirewallRules(ctx context.Context, client client.Client, ifaces []api.Interface) (*renderer.InterfaceFirewallRuleCountList, error) {
+	counted := make([]renderer.InterfaceFirewallRuleCount, len(ifaces))
+	if err := util.ForEachBounded(len(ifaces), auditConcurrency, func(i int) error {
+		counted[i] = renderer.InterfaceFirewallRuleCount{Interface: ifaces[i]}
+
+		fwrules, err := client.ListFirewallRules(ctx, ifaces[i].ID)
+		if err != nil {
+			if fwrules != nil && fwrules.Status.Code != 0 {
+				return nil
+			}
+			return fmt.Errorf("error listing firewall rules of interface %s: %w", ifaces[i].ID, err)
+		}
+		counted[i].FirewallRules = len(fwrules.Items)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &renderer.InterfaceFirewallRuleCountList{
+		TypeMeta: api.TypeMeta{Kind: renderer.InterfaceFirewallRuleCountListKind},
+		Items:    counted,
+	}, nil
+}
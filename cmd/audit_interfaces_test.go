@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type auditClient struct {
+	client.Client
+	routes map[uint32][]api.Route
+	vips   map[string]bool
+	rules  map[string]int
+}
+
+func (c *auditClient) ListRoutes(ctx context.Context, vni uint32, ignoredErrors ...[]uint32) (*api.RouteList, error) {
+	return &api.RouteList{Items: c.routes[vni]}, nil
+}
+
+func (c *auditClient) GetVirtualIP(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	if c.vips[interfaceID] {
+		return &api.VirtualIP{}, nil
+	}
+	return &api.VirtualIP{Status: api.Status{Code: 201, Message: "not found"}}, errors.New("rpc error")
+}
+
+func (c *auditClient) ListFirewallRules(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.FirewallRuleList, error) {
+	n, ok := c.rules[interfaceID]
+	if !ok {
+		return &api.FirewallRuleList{Status: api.Status{Code: 205, Message: "no such interface"}}, errors.New("rpc error")
+	}
+	return &api.FirewallRuleList{Items: make([]api.FirewallRule, n)}, nil
+}
+
+var _ = Describe("AuditInterfaces", func() {
+	It("should annotate interfaces with warnings", func() {
+		underlay1 := netip.MustParseAddr("fc00::1")
+		underlay2 := netip.MustParseAddr("fc00::2")
+		prefix := netip.MustParsePrefix("10.0.0.0/24")
+
+		ifaces := []api.Interface{
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 100, UnderlayRoute: &underlay1}},
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm2"}, Spec: api.InterfaceSpec{VNI: 100, UnderlayRoute: &underlay2}},
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm3"}, Spec: api.InterfaceSpec{VNI: 200}},
+		}
+		c := &auditClient{
+			routes: map[uint32][]api.Route{
+				100: {{Spec: api.RouteSpec{Prefix: &prefix, NextHop: &api.RouteNextHop{VNI: 100, IP: &underlay1}}}},
+			},
+			vips: map[string]bool{"vm1": true},
+		}
+
+		audited, err := AuditInterfaces(context.Background(), c, ifaces, ifaces)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(audited.Items).To(HaveLen(3))
+		Expect(audited.Items[0].Warnings).To(BeEmpty())
+		Expect(audited.Items[1].Warnings).To(ConsistOf(AuditWarningNoVirtualIP, AuditWarningNoRoutes))
+		Expect(audited.Items[2].Warnings).To(ConsistOf(AuditWarningNoVirtualIP, AuditWarningNoRoutes, AuditWarningSoleVNI))
+	})
+})
+
+var _ = Describe("CountFirewallRules", func() {
+	It("should count the firewall rules of each interface", func() {
+		ifaces := []api.Interface{
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}},
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm2"}},
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm3"}},
+		}
+		c := &auditClient{rules: map[string]int{"vm1": 2, "vm2": 0}}
+
+		counted, err := CountFirewallRules(context.Background(), c, ifaces)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counted.Items).To(HaveLen(3))
+		Expect(counted.Items[0].FirewallRules).To(Equal(2))
+		Expect(counted.Items[1].FirewallRules).To(Equal(0))
+		Expect(counted.Items[2].FirewallRules).To(Equal(0))
+	})
+})
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ValidateAuthority", func() {
+	DescribeTable("valid authorities",
+		func(authority string) {
+			Expect(ValidateAuthority(authority)).To(Succeed())
+		},
+		Entry("hostname", "dpservice.example.com"),
+		Entry("hostname with port", "dpservice.example.com:443"),
+		Entry("fully qualified hostname", "dpservice.example.com."),
+		Entry("IPv4 with port", "10.0.0.1:1337"),
+		Entry("IPv6 with port", "[fc00::1]:1337"),
+	)
+
+	DescribeTable("invalid authorities",
+		func(authority string) {
+			Expect(ValidateAuthority(authority)).NotTo(Succeed())
+		},
+		Entry("empty", ""),
+		Entry("empty label", "dpservice..example.com"),
+		Entry("invalid characters", "dp_service.example.com"),
+		Entry("leading hyphen", "-dpservice.example.com"),
+		Entry("invalid port", "dpservice.example.com:http"),
+		Entry("port out of range", "dpservice.example.com:70000"),
+	)
+})
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+const BatchResultListKind = "BatchResultList"
+
+// BatchResult is the outcome of a single object of a batch operation (e.g. create -f).
+// Action is the operation in its base form, e.g. "create" or "delete".
+type BatchResult struct {
+	Kind    string `json:"kind"`
+	ID      string `json:"id"`
+	Action  string `json:"action"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BatchResultList struct {
+	Kind    string        `json:"kind"`
+	Items   []BatchResult `json:"items"`
+	Summary any           `json:"summary,omitempty"`
+}
+
+func NewBatchResult(action string, obj, res any, err error) BatchResult {
+	result := BatchResult{
+		Kind:    batchKind(obj),
+		ID:      dynamic.ObjectKeyFromObject(obj).String(),
+		Action:  action,
+		Success: err == nil,
+	}
+	if err == nil {
+		return result
+	}
+
+	if o, ok := res.(api.Object); ok && o.GetStatus().Code != 0 {
+		result.Error = fmt.Sprintf("server error: %d, %s", o.GetStatus().Code, o.GetStatus().Message)
+	} else {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// batchKind returns the kind of obj, or its type if it has none.
+func batchKind(obj any) string {
+	if o, ok := obj.(api.Object); ok && o.GetKind() != "" {
+		return o.GetKind()
+	}
+	return fmt.Sprintf("%T", obj)
+}
+
+// batchConcurrency bounds the number of concurrent requests of a concurrent batch.
+const batchConcurrency = 8
+
+// RunBatch applies op to all objs. With a structured output format the per-object
+// results are collected and rendered as a single BatchResultList, otherwise each
+// object is rendered (or its error printed) as soon as it was processed.
+func RunBatch(
+	ctx context.Context,
+	w io.Writer,
+	rendererFactory RendererFactory,
+	action string,
+	objs []any,
+	op func(ctx context.Context, obj any) (any, error),
+) error {
+	b, err := newBatchRenderer(w, rendererFactory, action)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		res, err := op(ctx, obj)
+		if err := b.add(obj, res, err); err != nil {
+			return err
+		}
+	}
+	return b.flush()
+}
+
+// RunConcurrentBatch is like RunBatch, but applies op to up to batchConcurrency objects
+// at once. The results are rendered in the order of objs once all objects were processed,
+// and it fails if op failed for any object.
+func RunConcurrentBatch(
+	ctx context.Context,
+	w io.Writer,
+	rendererFactory RendererFactory,
+	action string,
+	objs []any,
+	op func(ctx context.Context, obj any) (any, error),
+) error {
+	b, err := newBatchRenderer(w, rendererFactory, action)
+	if err != nil {
+		return err
+	}
+
+	res := make([]any, len(objs))
+	errs := make([]error, len(objs))
+	_ = util.ForEachBounded(len(objs), batchConcurrency, func(i int) error {
+		res[i], errs[i] = op(ctx, objs[i])
+		return nil
+	})
+
+	failed := 0
+	for i, obj := range objs {
+		if errs[i] != nil {
+			failed++
+		}
+		if err := b.add(obj, res[i], errs[i]); err != nil {
+			return err
+		}
+	}
+	if err := b.flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d objects failed to %s", failed, len(objs), action)
+	}
+	return nil
+}
+
+type batchRenderer struct {
+	w          io.Writer
+	renderer   renderer.Renderer
+	structured bool
+	action     string
+	results    *BatchResultList
+	// summary is printed after the results, or added to them in structured output.
+	summary fmt.Stringer
+}
+
+func newBatchRenderer(w io.Writer, rendererFactory RendererFactory, action string) (*batchRenderer, error) {
+	r, err := rendererFactory.NewRenderer(action+"d", w)
+	if err != nil {
+		return nil, fmt.Errorf("error creating renderer: %w", err)
+	}
+	return &batchRenderer{
+		w:          w,
+		renderer:   r,
+		structured: rendererFactory.IsStructured(),
+		action:     action,
+		results:    &BatchResultList{Kind: BatchResultListKind, Items: []BatchResult{}},
+	}, nil
+}
+
+func (b *batchRenderer) add(obj, res any, err error) error {
+	result := NewBatchResult(b.action, obj, res, err)
+	if b.structured {
+		b.results.Items = append(b.results.Items, result)
+		return nil
+	}
+
+	if err != nil {
+		fmt.Fprintf(b.w, "Error: failed to %s %s %s: %s\n", b.action, result.Kind, result.ID, result.Error)
+		return nil
+	}
+	if err := b.renderer.Render(obj); err != nil {
+		return fmt.Errorf("error rendering %T: %w", obj, err)
+	}
+	return nil
+}
+
+func (b *batchRenderer) flush() error {
+	if b.structured {
+		if b.summary != nil {
+			b.results.Summary = b.summary
+		}
+		if err := b.renderer.Render(b.results); err != nil {
+			return fmt.Errorf("error rendering batch results: %w", err)
+		}
+		return nil
+	}
+	if b.summary != nil {
+		fmt.Fprintln(b.w, b.summary)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunBatch", func() {
+	var objs []any
+
+	BeforeEach(func() {
+		objs = []any{
+			&api.Interface{TypeMeta: api.TypeMeta{Kind: api.InterfaceKind}, InterfaceMeta: api.InterfaceMeta{ID: "vm1"}},
+			&api.Interface{TypeMeta: api.TypeMeta{Kind: api.InterfaceKind}, InterfaceMeta: api.InterfaceMeta{ID: "vm2"}},
+			&api.LoadBalancer{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerKind}, LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"}},
+		}
+	})
+
+	op := func(ctx context.Context, obj any) (any, error) {
+		switch obj := obj.(type) {
+		case *api.Interface:
+			if obj.ID == "vm2" {
+				return &api.Interface{Status: api.Status{Code: 202, Message: "already exists"}}, errors.New("rpc error")
+			}
+			return obj, nil
+		default:
+			return obj, errors.New("connection refused")
+		}
+	}
+
+	It("should render a result document with successes and failures as json", func() {
+		buf := &bytes.Buffer{}
+		Expect(RunBatch(context.Background(), buf, &RendererOptions{Output: "json"}, "create", objs, op)).To(Succeed())
+
+		var doc map[string]any
+		Expect(json.Unmarshal(buf.Bytes(), &doc)).To(Succeed())
+		Expect(doc).To(Equal(map[string]any{
+			"kind": "BatchResultList",
+			"items": []any{
+				map[string]any{"kind": "Interface", "id": "vm1", "action": "create", "success": true},
+				map[string]any{"kind": "Interface", "id": "vm2", "action": "create", "success": false, "error": "server error: 202, already exists"},
+				map[string]any{"kind": "LoadBalancer", "id": "lb1", "action": "create", "success": false, "error": "connection refused"},
+			},
+		}))
+	})
+
+	It("should render each object and print errors inline for human output", func() {
+		buf := &bytes.Buffer{}
+		Expect(RunBatch(context.Background(), buf, &RendererOptions{Output: "name"}, "create", objs, op)).To(Succeed())
+
+		Expect(buf.String()).To(ContainSubstring("vm1"))
+		Expect(buf.String()).To(ContainSubstring("Error: failed to create Interface vm2: server error: 202, already exists"))
+		Expect(buf.String()).To(ContainSubstring("Error: failed to create LoadBalancer lb1: connection refused"))
+	})
+})
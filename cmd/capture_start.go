@@ -24,10 +24,11 @@ func CaptureStart(dpdkClientFactory DPDKClientFactory, rendererFactory RendererF
 	)
 
 	cmd := &cobra.Command{
-		Use:     "start <--sink-node-ip> <--udp-src-port> <--udp-dst-port> [--pf] [--vf]",
-		Short:   "Start capturing packets",
-		Example: "dpservice-cli capture start --sink-node-ip=fc00:2::64:0:1 --udp-src-port=30000 --udp-dst-port=30100 --pf=0(must be 0 due to hardware limitation) --vf=vm1,vm2,vm3",
-		Args:    cobra.ExactArgs(0),
+		Use:         "start <--sink-node-ip> <--udp-src-port> <--udp-dst-port> [--pf] [--vf]",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Short:       "Start capturing packets",
+		Example:     "dpservice-cli capture start --sink-node-ip=fc00:2::64:0:1 --udp-src-port=30000 --udp-dst-port=30100 --pf=0(must be 0 due to hardware limitation) --vf=vm1,vm2,vm3",
+		Args:        cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			return RunCaptureStart(
@@ -14,10 +14,11 @@ import (
 func CaptureStop(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory) *cobra.Command {
 
 	cmd := &cobra.Command{
-		Use:     "stop",
-		Short:   "Stop capturing packets for all interfaces",
-		Example: "dpservice-cli capture stop",
-		Args:    cobra.ExactArgs(0),
+		Use:         "stop",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Short:       "Stop capturing packets for all interfaces",
+		Example:     "dpservice-cli capture stop",
+		Args:        cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			return RunCaptureStop(
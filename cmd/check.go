@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func Check(factory DPDKClientFactory) *cobra.Command {
+	rendererOptions := &RendererOptions{Output: "table"}
+
+	cmd := &cobra.Command{
+		Use:  "check [command]",
+		Args: cobra.NoArgs,
+		RunE: SubcommandRequired,
+	}
+
+	rendererOptions.AddFlags(cmd.PersistentFlags())
+
+	subcommands := []*cobra.Command{
+		CheckRoutes(factory, rendererOptions),
+	}
+
+	cmd.Short = fmt.Sprintf("Checks the consistency of one of %v", CommandNames(subcommands))
+	cmd.Long = fmt.Sprintf("Checks the consistency of one of %v", CommandNames(subcommands))
+
+	cmd.AddCommand(
+		subcommands...,
+	)
+
+	return cmd
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ClientMethods returns the names of the dpservice methods the CLI may call.
+func ClientMethods() []string {
+	methods := make([]string, 0, len(dpdkproto.DPDKironcore_ServiceDesc.Methods))
+	for _, method := range dpdkproto.DPDKironcore_ServiceDesc.Methods {
+		methods = append(methods, method.MethodName)
+	}
+	return methods
+}
+
+// checkMethodsTimeout bounds the server reflection of CheckMethods.
+const checkMethodsTimeout = 5 * time.Second
+
+// MissingMethods uses gRPC server reflection to find which of methods the server does
+// not offer for the given service. It fails if the server has reflection disabled.
+func MissingMethods(ctx context.Context, conn grpc.ClientConnInterface, service string, methods []string) ([]string, error) {
+	files, err := reflectFileDescriptors(ctx, conn, service)
+	if err != nil {
+		return nil, err
+	}
+
+	offered := make(map[string]bool)
+	for _, raw := range files {
+		file := &descriptorpb.FileDescriptorProto{}
+		if err := proto.Unmarshal(raw, file); err != nil {
+			return nil, fmt.Errorf("error unmarshaling file descriptor: %w", err)
+		}
+		for _, svc := range file.GetService() {
+			if file.GetPackage()+"."+svc.GetName() != service {
+				continue
+			}
+			for _, method := range svc.GetMethod() {
+				offered[method.GetName()] = true
+			}
+		}
+	}
+
+	var missing []string
+	for _, method := range methods {
+		if !offered[method] {
+			missing = append(missing, method)
+		}
+	}
+	return missing, nil
+}
+
+// reflectFileDescriptors returns the file descriptors that define service, falling back to
+// v1alpha reflection for servers that do not offer v1.
+func reflectFileDescriptors(ctx context.Context, conn grpc.ClientConnInterface, service string) ([][]byte, error) {
+	files, err := reflectFileDescriptorsV1(ctx, conn, service)
+	if status.Code(err) != codes.Unimplemented {
+		return files, err
+	}
+	return reflectFileDescriptorsV1Alpha(ctx, conn, service)
+}
+
+func reflectFileDescriptorsV1(ctx context.Context, conn grpc.ClientConnInterface, service string) ([][]byte, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting server reflection: %w", err)
+	}
+	defer func() { _ = stream.CloseSend() }()
+
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}); err != nil {
+		return nil, fmt.Errorf("error sending server reflection request: %w", err)
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("error receiving server reflection response: %w", err)
+	}
+	if errRes := res.GetErrorResponse(); errRes != nil {
+		return nil, fmt.Errorf("error looking up service %s: %s", service, errRes.GetErrorMessage())
+	}
+	return res.GetFileDescriptorResponse().GetFileDescriptorProto(), nil
+}
+
+func reflectFileDescriptorsV1Alpha(ctx context.Context, conn grpc.ClientConnInterface, service string) ([][]byte, error) {
+	stream, err := reflectionv1alphapb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting v1alpha server reflection: %w", err)
+	}
+	defer func() { _ = stream.CloseSend() }()
+
+	if err := stream.Send(&reflectionv1alphapb.ServerReflectionRequest{
+		MessageRequest: &reflectionv1alphapb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}); err != nil {
+		return nil, fmt.Errorf("error sending v1alpha server reflection request: %w", err)
+	}
+	res, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("error receiving v1alpha server reflection response: %w", err)
+	}
+	if errRes := res.GetErrorResponse(); errRes != nil {
+		return nil, fmt.Errorf("error looking up service %s: %s", service, errRes.GetErrorMessage())
+	}
+	return res.GetFileDescriptorResponse().GetFileDescriptorProto(), nil
+}
+
+// CheckMethods warns on w about dpservice methods the CLI may call but the server does not offer.
+func CheckMethods(ctx context.Context, conn grpc.ClientConnInterface, w io.Writer) {
+	ctx, cancel := context.WithTimeout(ctx, checkMethodsTimeout)
+	defer cancel()
+
+	service := dpdkproto.DPDKironcore_ServiceDesc.ServiceName
+	missing, err := MissingMethods(ctx, conn, service, ClientMethods())
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "Warning: could not check server methods (is reflection enabled on dpservice?): %v\n", err)
+		return
+	}
+	for _, method := range missing {
+		_, _ = fmt.Fprintf(w, "Warning: dpservice does not offer method %s/%s, commands using it will fail\n", service, method)
+	}
+}
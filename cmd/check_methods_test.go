@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"net"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	reflectionv1alphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var _ = Describe("MissingMethods", func() {
+	var conn *grpc.ClientConn
+
+	dial := func(registerReflection func(*grpc.Server)) {
+		lis := bufconn.Listen(1 << 20)
+		srv := grpc.NewServer()
+		dpdkproto.RegisterDPDKironcoreServer(srv, dpdkproto.UnimplementedDPDKironcoreServer{})
+		registerReflection(srv)
+		go func() { _ = srv.Serve(lis) }()
+		DeferCleanup(srv.Stop)
+
+		var err error
+		conn, err = grpc.Dial("bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(conn.Close)
+	}
+
+	It("should report only the methods the server does not offer", func(ctx SpecContext) {
+		dial(func(srv *grpc.Server) { reflection.Register(srv) })
+		methods := append(ClientMethods(), "DoesNotExist")
+
+		missing, err := MissingMethods(ctx, conn, dpdkproto.DPDKironcore_ServiceDesc.ServiceName, methods)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missing).To(Equal([]string{"DoesNotExist"}))
+	})
+
+	It("should fall back to v1alpha reflection", func(ctx SpecContext) {
+		dial(func(srv *grpc.Server) {
+			reflectionv1alphapb.RegisterServerReflectionServer(srv, reflection.NewServer(reflection.ServerOptions{Services: srv}))
+		})
+		methods := append(ClientMethods(), "DoesNotExist")
+
+		missing, err := MissingMethods(ctx, conn, dpdkproto.DPDKironcore_ServiceDesc.ServiceName, methods)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missing).To(Equal([]string{"DoesNotExist"}))
+	})
+
+	It("should fail if reflection is disabled", func(ctx SpecContext) {
+		dial(func(*grpc.Server) {})
+
+		_, err := MissingMethods(ctx, conn, dpdkproto.DPDKironcore_ServiceDesc.ServiceName, ClientMethods())
+		Expect(err).To(HaveOccurred())
+	})
+})
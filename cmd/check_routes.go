@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func CheckRoutes(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory) *cobra.Command {
+	var (
+		opts CheckRoutesOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "routes <--vni|--all-vnis>",
+		Short: "List routes whose next hop does not resolve to a known interface",
+		Long: "List routes whose next hop does not resolve to a known interface, i.e. no interface has the next hop IP as\n" +
+			"underlay route or that interface is in another VNI than the next hop VNI.\n" +
+			"Routes to interfaces of other dpservice instances are reported as well. --all-vnis checks the VNIs of all interfaces\n" +
+			"within --vni-min and --vni-max.",
+		Example: "dpservice-cli check routes --vni=100",
+		Aliases: RouteAliases,
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+
+			return RunCheckRoutes(
+				cmd.Context(),
+				dpdkClientFactory,
+				rendererFactory,
+				opts,
+			)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	cmd.MarkFlagsMutuallyExclusive("vni", "all-vnis")
+	cmd.MarkFlagsOneRequired("vni", "all-vnis")
+	cmd.MarkFlagsMutuallyExclusive("vni", "vni-min")
+	cmd.MarkFlagsMutuallyExclusive("vni", "vni-max")
+
+	return cmd
+}
+
+type CheckRoutesOptions struct {
+	VNI     uint32
+	AllVNIs bool
+	VNIRangeOptions
+}
+
+func (o *CheckRoutesOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.Uint32Var(&o.VNI, "vni", o.VNI, "VNI to check the routes of.")
+	fs.BoolVar(&o.AllVNIs, "all-vnis", o.AllVNIs, "Check the routes of every VNI that has interfaces.")
+	o.VNIRangeOptions.AddFlags(fs)
+}
+
+// DanglingRoutes returns the routes whose next hop IP is not the underlay route of an
+// interface in the next hop VNI.
+func DanglingRoutes(routes []api.Route, ifaces []api.Interface) []renderer.DanglingRoute {
+	byUnderlayRoute := make(map[netip.Addr]api.Interface, len(ifaces))
+	for _, iface := range ifaces {
+		if iface.Spec.UnderlayRoute != nil {
+			byUnderlayRoute[*iface.Spec.UnderlayRoute] = iface
+		}
+	}
+
+	var dangling []renderer.DanglingRoute
+	for _, route := range routes {
+		if route.Spec.NextHop == nil || route.Spec.NextHop.IP == nil {
+			continue
+		}
+		nextHop := route.Spec.NextHop
+		iface, ok := byUnderlayRoute[*nextHop.IP]
+		switch {
+		case !ok:
+			dangling = append(dangling, renderer.DanglingRoute{
+				Route:   route,
+				Problem: fmt.Sprintf("no interface with underlay route %s", nextHop.IP),
+			})
+		case iface.Spec.VNI != nextHop.VNI:
+			dangling = append(dangling, renderer.DanglingRoute{
+				Route:   route,
+				Problem: fmt.Sprintf("interface %s is in vni %d, not %d", iface.ID, iface.Spec.VNI, nextHop.VNI),
+			})
+		}
+	}
+	return dangling
+}
+
+func RunCheckRoutes(
+	ctx context.Context,
+	dpdkClientFactory DPDKClientFactory,
+	rendererFactory RendererFactory,
+	opts CheckRoutesOptions,
+) error {
+	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dpdk client: %w", err)
+	}
+	defer DpdkClose(cleanup)
+
+	ifaces, err := client.ListInterfaces(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing interfaces: %w", err)
+	}
+
+	vnis := []uint32{opts.VNI}
+	if opts.AllVNIs {
+		seen := make(map[uint32]bool)
+		vnis = nil
+		for _, iface := range ifaces.Items {
+			if !seen[iface.Spec.VNI] && opts.Contains(iface.Spec.VNI) {
+				seen[iface.Spec.VNI] = true
+				vnis = append(vnis, iface.Spec.VNI)
+			}
+		}
+		sort.Slice(vnis, func(i, j int) bool { return vnis[i] < vnis[j] })
+	}
+
+	var (
+		mu     sync.Mutex
+		routes []api.Route
+	)
+	if err := util.ForEachBounded(len(vnis), auditConcurrency, func(i int) error {
+		list, err := client.ListRoutes(ctx, vnis[i])
+		if err != nil {
+			return fmt.Errorf("error listing routes of vni %d: %w", vnis[i], err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		routes = append(routes, list.Items...)
+		return nil
+	}); err != nil {
+		return err
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].VNI != routes[j].VNI {
+			return routes[i].VNI < routes[j].VNI
+		}
+		return routes[i].Spec.Prefix.String() < routes[j].Spec.Prefix.String()
+	})
+
+	return rendererFactory.RenderList("", os.Stdout, &renderer.DanglingRouteList{
+		TypeMeta: api.TypeMeta{Kind: renderer.DanglingRouteListKind},
+		Items:    DanglingRoutes(routes, ifaces.Items),
+	})
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// checkRoutesClient has interfaces and routes and records the VNIs whose routes were listed.
+type checkRoutesClient struct {
+	client.Client
+	ifaces []api.Interface
+	routes map[uint32][]api.Route
+	listed chan uint32
+}
+
+func (c *checkRoutesClient) ListInterfaces(ctx context.Context, ignoredErrors ...[]uint32) (*api.InterfaceList, error) {
+	return &api.InterfaceList{Items: c.ifaces}, nil
+}
+
+func (c *checkRoutesClient) ListRoutes(ctx context.Context, vni uint32, ignoredErrors ...[]uint32) (*api.RouteList, error) {
+	c.listed <- vni
+	return &api.RouteList{Items: c.routes[vni]}, nil
+}
+
+var _ = Describe("CheckRoutes", func() {
+	newInterface := func(id string, vni uint32, underlayRoute string) api.Interface {
+		addr := netip.MustParseAddr(underlayRoute)
+		return api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{VNI: vni, UnderlayRoute: &addr}}
+	}
+	newRoute := func(vni uint32, prefix string, nextHopVNI uint32, nextHopIP string) api.Route {
+		p := netip.MustParsePrefix(prefix)
+		ip := netip.MustParseAddr(nextHopIP)
+		return api.Route{RouteMeta: api.RouteMeta{VNI: vni}, Spec: api.RouteSpec{Prefix: &p, NextHop: &api.RouteNextHop{VNI: nextHopVNI, IP: &ip}}}
+	}
+
+	It("should report next hops without an interface in their VNI", func() {
+		ifaces := []api.Interface{newInterface("vm1", 100, "fc00::1"), newInterface("vm2", 200, "fc00::2")}
+		routes := []api.Route{
+			newRoute(100, "10.0.1.0/24", 100, "fc00::1"),
+			newRoute(100, "10.0.2.0/24", 100, "fc00::2"),
+			newRoute(100, "10.0.3.0/24", 100, "fc00::3"),
+		}
+
+		dangling := DanglingRoutes(routes, ifaces)
+		Expect(dangling).To(HaveLen(2))
+		Expect(dangling[0].Route).To(Equal(routes[1]))
+		Expect(dangling[0].Problem).To(Equal("interface vm2 is in vni 200, not 100"))
+		Expect(dangling[1].Route).To(Equal(routes[2]))
+		Expect(dangling[1].Problem).To(Equal("no interface with underlay route fc00::3"))
+	})
+
+	It("should list the routes of every VNI with interfaces with --all-vnis", func(ctx SpecContext) {
+		c := &checkRoutesClient{
+			ifaces: []api.Interface{newInterface("vm1", 100, "fc00::1"), newInterface("vm2", 200, "fc00::2"), newInterface("vm3", 100, "fc00::3")},
+			listed: make(chan uint32, 3),
+		}
+		cmd := CheckRoutes(fakeClientFactory{c}, &RendererOptions{Output: "name"})
+		cmd.SetArgs([]string{"--all-vnis"})
+
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+		close(c.listed)
+		var listed []uint32
+		for vni := range c.listed {
+			listed = append(listed, vni)
+		}
+		Expect(listed).To(ConsistOf(uint32(100), uint32(200)))
+	})
+
+	It("should require --vni or --all-vnis", func(ctx SpecContext) {
+		cmd := CheckRoutes(unreachableClientFactory{}, &RendererOptions{Output: "name"})
+		cmd.SetArgs([]string{})
+		cmd.SilenceUsage = true
+
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("at least one of the flags in the group [vni all-vnis] is required")))
+	})
+})
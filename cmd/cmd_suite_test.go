@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCmd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cmd Suite")
+}
+
+// captureOutput redirects f, os.Stdout or os.Stderr, to a temporary file until the end of
+// the spec and returns the name of the file. f is process-global, so specs that capture
+// output must not run commands concurrently.
+func captureOutput(f **os.File) string {
+	orig := *f
+	DeferCleanup(func() { *f = orig })
+	tmp, err := os.Create(filepath.Join(GinkgoT().TempDir(), filepath.Base(orig.Name())))
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(tmp.Close)
+	*f = tmp
+	return tmp.Name()
+}
+
+// setStdin makes os.Stdin read content until the end of the spec.
+func setStdin(content string) {
+	name := filepath.Join(GinkgoT().TempDir(), "stdin")
+	Expect(os.WriteFile(name, []byte(content), 0o644)).To(Succeed())
+	f, err := os.Open(name)
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(f.Close)
+
+	orig := os.Stdin
+	DeferCleanup(func() { os.Stdin = orig })
+	os.Stdin = f
+}
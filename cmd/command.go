@@ -9,8 +9,16 @@ import (
 )
 
 func Command() *cobra.Command {
-	dpdkClientOptions := &DPDKClientOptions{}
+	return newCommand(&MetricsOptions{}, &DumpOptions{})
+}
+
+func newCommand(metricsOptions *MetricsOptions, dumpOptions *DumpOptions) *cobra.Command {
+	dpdkClientOptions := &DPDKClientOptions{Metrics: metricsOptions.Recorder()}
+	dumpOptions.factory = dpdkClientOptions
 	rendererOptions := &RendererOptions{}
+	printFlagsOptions := &PrintFlagsOptions{}
+	vniOptions := &VNIOptions{}
+	logOptions := &LogOptions{}
 
 	cmd := &cobra.Command{
 		Use:           "dpservice-cli [command]",
@@ -19,21 +27,52 @@ func Command() *cobra.Command {
 		SilenceErrors: true,
 		RunE:          SubcommandRequired,
 		Version:       util.BuildVersion,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := logOptions.Setup(); err != nil {
+				return err
+			}
+			if err := printFlagsOptions.PreRun(cmd, args); err != nil {
+				return err
+			}
+			if err := ValidateIntent(cmd, dpdkClientOptions.Intent); err != nil {
+				return err
+			}
+			return vniOptions.Validate(cmd.Flags())
+		},
 	}
 
 	rendererOptions.AddFlags(cmd.PersistentFlags())
 	dpdkClientOptions.AddFlags(cmd.PersistentFlags())
+	printFlagsOptions.AddFlags(cmd.PersistentFlags())
+	vniOptions.AddFlags(cmd.PersistentFlags())
+	metricsOptions.AddFlags(cmd.PersistentFlags())
+	dumpOptions.AddFlags(cmd.PersistentFlags())
+	logOptions.AddFlags(cmd.PersistentFlags())
 
 	cmd.AddCommand(
 		Create(dpdkClientOptions),
 		Get(dpdkClientOptions),
 		List(dpdkClientOptions),
+		Update(dpdkClientOptions),
 		Delete(dpdkClientOptions),
+		Apply(dpdkClientOptions),
+		Replace(dpdkClientOptions),
+		Check(dpdkClientOptions),
+		Normalize(),
+		Diff(),
 		Reset(dpdkClientOptions),
+		Drain(dpdkClientOptions),
+		Undrain(dpdkClientOptions),
 		Init(dpdkClientOptions, rendererOptions),
 		Capture(dpdkClientOptions),
+		Doctor(dpdkClientOptions),
+		Graph(dpdkClientOptions),
+		Watch(dpdkClientOptions),
+		Version(dpdkClientOptions, rendererOptions),
+		Debug(),
 		completionCmd,
 	)
+	RegisterInterfaceIDCompletion(cmd, dpdkClientOptions)
 
 	return cmd
 }
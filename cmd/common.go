@@ -8,10 +8,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/netip"
+	"net/url"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ironcore-dev/dpservice-cli/flag"
+	dpsvcio "github.com/ironcore-dev/dpservice-cli/io"
 	"github.com/ironcore-dev/dpservice-cli/renderer"
 	"github.com/ironcore-dev/dpservice-cli/sources"
 	"github.com/ironcore-dev/dpservice-go/api"
@@ -21,7 +28,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/resolver"
 )
 
 type DPDKClientFactory interface {
@@ -30,30 +37,157 @@ type DPDKClientFactory interface {
 
 type DPDKClientOptions struct {
 	Address        string
+	Authority      string
 	ConnectTimeout time.Duration
+	Timeout        time.Duration
+	Trace          bool
+	FollowRedirect bool
+	CheckMethods   bool
+	RetryPolicy    string
+	RetryOnCodes   []uint
+	MaxRetries     uint
+	RetryBackoff   time.Duration
+	Intent         string
+	TLSOptions
+	// Metrics records the RPCs for --metrics-file, if set.
+	Metrics *MetricsRecorder
 }
 
 func (o *DPDKClientOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.Address, "address", "localhost:1337", "dpservice address.")
+	fs.StringVar(&o.Authority, "authority", o.Authority, "gRPC :authority to send instead of the address, e.g. when connecting through a proxy.")
 	fs.DurationVar(&o.ConnectTimeout, "connect-timeout", 4*time.Second, "Timeout to connect to the dpservice.")
+	fs.DurationVar(&o.Timeout, "timeout", DefaultTimeout, "Deadline of every call to dpservice, including its retries. 0 means no timeout.")
+	fs.BoolVar(&o.Trace, "trace", o.Trace, "Print the count and latency of the issued RPCs per method to stderr.")
+	fs.BoolVar(&o.FollowRedirect, "follow-redirect", o.FollowRedirect, "Reconnect to the address announced by a clustered dpservice when it redirects a call, and retry once.")
+	fs.StringVar(&o.RetryPolicy, "retry-policy", string(RetryPolicySafe), "Which calls to retry on transient errors: safe (only get/list calls), all (also creates/deletes, which may then fail as already applied) or none.")
+	fs.UintVar(&o.MaxRetries, "max-retries", DefaultMaxRetries, "How often a call that failed transiently (e.g. dpservice unavailable while restarting) is retried, subject to --retry-policy. 0 disables retries.")
+	fs.DurationVar(&o.RetryBackoff, "retry-backoff", DefaultRetryBackoff, "Delay before the first retry, doubled for every further retry and randomized by up to half of it.")
+	fs.UintSliceVar(&o.RetryOnCodes, "retry-on-codes", o.RetryOnCodes, "dpservice status codes (e.g. 209 for LIMIT_REACHED) to retry like transient errors, subject to --retry-policy.")
+	fs.StringVar(&o.Intent, "intent", o.Intent, fmt.Sprintf("Declared intent sent with every call for audit logs: %s or %s. With %s, commands that change dpservice are rejected.", IntentRead, IntentWrite, IntentRead))
+	o.TLSOptions.AddFlags(fs)
+	fs.BoolVar(&o.CheckMethods, "check-methods", o.CheckMethods, "Warn about dpservice methods the CLI uses that the server does not offer (requires gRPC reflection on dpservice).")
 }
 
 func (o *DPDKClientOptions) NewClient(ctx context.Context) (client.Client, func() error, error) {
+	retryPolicy, err := ParseRetryPolicy(o.RetryPolicy)
+	if err != nil {
+		return nil, nil, err
+	}
+	retryCodes, err := ParseRetryCodes(o.RetryOnCodes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	creds, err := o.TransportCredentials()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, o.ConnectTimeout)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, o.Address, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds), grpc.WithBlock()}
+	if o.Enabled() {
+		// handshake errors, e.g. a certificate for another server name, are reported
+		// instead of only the connect timeout
+		dialOpts = append(dialOpts, grpc.WithReturnConnectionError())
+	}
+	if o.Authority != "" {
+		if err := ValidateAuthority(o.Authority); err != nil {
+			return nil, nil, err
+		}
+		dialOpts = append(dialOpts, grpc.WithAuthority(o.Authority))
+	}
+	// closers run in order on cleanup, the connection itself is closed last
+	var closers []func() error
+	var interceptors []grpc.UnaryClientInterceptor
+	interceptors = append(interceptors, IPVersionInterceptor)
+	if o.Intent != "" {
+		interceptors = append(interceptors, IntentInterceptor{Intent: o.Intent}.UnaryClientInterceptor)
+	}
+	// the tracer also logs every call at debug level
+	if o.Trace || slog.Default().Enabled(ctx, slog.LevelDebug) {
+		tracer := NewCallTracer()
+		interceptors = append(interceptors, tracer.UnaryClientInterceptor)
+		if o.Trace {
+			closers = append(closers, func() error {
+				if err := tracer.Print(os.Stderr); err != nil {
+					return fmt.Errorf("error printing trace: %w", err)
+				}
+				return nil
+			})
+		}
+	}
+	if retryPolicy != RetryPolicyNone {
+		retry := NewRetryInterceptor(retryPolicy)
+		retry.MaxRetries = int(o.MaxRetries)
+		retry.Backoff = o.RetryBackoff
+		retry.Codes = retryCodes
+		if o.Metrics != nil {
+			retry.OnRetry = o.Metrics.RecordRetry
+		}
+		interceptors = append(interceptors, retry.UnaryClientInterceptor)
+	}
+	if o.Metrics != nil {
+		interceptors = append(interceptors, o.Metrics.UnaryClientInterceptor)
+	}
+
+	// the timeout is the outermost interceptor, so that it also bounds retries and redirects
+	timeout := grpc.WithChainUnaryInterceptor(TimeoutInterceptor{Timeout: o.Timeout}.UnaryClientInterceptor)
+	if o.FollowRedirect {
+		// the redirected connection is dialed with the same options and interceptors but without
+		// the follower, so a redirected call passes every interceptor once and is redirected only once
+		redirectDialOpts := append(slices.Clone(dialOpts), grpc.WithChainUnaryInterceptor(interceptors...))
+		follower := NewRedirectFollower(o.ConnectTimeout, redirectDialOpts...)
+		dialOpts = append(dialOpts, timeout, grpc.WithChainUnaryInterceptor(follower.UnaryClientInterceptor))
+		closers = append(closers, follower.Close)
+	} else {
+		dialOpts = append(dialOpts, timeout)
+	}
+	dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(interceptors...))
+
+	target := DialTarget(o.Address)
+	conn, err := grpc.DialContext(ctx, target, dialOpts...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error connecting to %s: %w", o.Address, err)
+		return nil, nil, fmt.Errorf("error connecting to %s (dial target %s): %w", o.Address, target, err)
+	}
+	closers = append(closers, conn.Close)
+
+	if o.CheckMethods {
+		CheckMethods(ctx, conn, os.Stderr)
 	}
 
 	protoClient := dpdkproto.NewDPDKironcoreClient(conn)
 	c := client.NewClient(protoClient)
 
-	cleanup := conn.Close
+	cleanup := func() error {
+		var errs []error
+		for _, closer := range closers {
+			errs = append(errs, closer())
+		}
+		return errors.Join(errs...)
+	}
 	return c, cleanup, nil
 }
 
+// DialTarget returns the target that is dialed for address, resolved like gRPC does it:
+// addresses with the scheme of a registered resolver (e.g. unix:///path or dns:///host:port)
+// are used as they are, all others are passed through to the dialer as host:port.
+func DialTarget(address string) string {
+	if IsResolverTarget(address) {
+		return address
+	}
+	return "passthrough:///" + address
+}
+
+// IsResolverTarget reports whether address is a gRPC target with the scheme of a registered
+// resolver, e.g. unix:// or dns:///, instead of a host:port address.
+func IsResolverTarget(address string) bool {
+	u, err := url.Parse(address)
+	return err == nil && u.Scheme != "" && resolver.Get(u.Scheme) != nil
+}
+
 func DpdkClose(cleanup func() error) {
 	if err := cleanup(); err != nil {
 		fmt.Printf("error cleaning up client: %s", err)
@@ -85,51 +219,231 @@ func CommandNames(cmds []*cobra.Command) []string {
 }
 
 type RendererOptions struct {
-	Output string
-	Pretty bool
-	Wide   bool
+	Output     string
+	Pretty     bool
+	Wide       bool
+	Indent     string
+	ResolveDNS bool
+	Template   string
+	MissingKey string
+	// UnwrapSingle renders the item of single-item lists instead of the list in structured output.
+	UnwrapSingle bool
+	// PreferIPVersion is the IP version shown and sorted first, ipv4 or ipv6.
+	PreferIPVersion string
+	// Columns restricts table and line output to the columns with these header names.
+	Columns []string
+	// RenderTimeout fails rendering if the output does not accept data for this long.
+	RenderTimeout time.Duration
+	// NoHeaders omits the header row of table output.
+	NoHeaders bool
+	// Quiet suppresses the notice on stderr that a rendered list has no items.
+	Quiet bool
+
+	resolver *renderer.DNSResolver
 }
 
 func (o *RendererOptions) AddFlags(fs *pflag.FlagSet) {
-	fs.StringVarP(&o.Output, "output", "o", o.Output, "Output format. [json|yaml|table|name]")
+	fs.StringVarP(&o.Output, "output", "o", o.Output, "Output format. [json|yaml|table|wide|line|csv|name|go-template|go-template=TEMPLATE|jsonpath=EXPRESSION]")
 	fs.BoolVar(&o.Pretty, "pretty", o.Pretty, "Whether to render pretty output.")
 	fs.BoolVarP(&o.Wide, "wide", "w", o.Wide, "Whether to render more info in table output.")
+	fs.StringVar(&o.Indent, "indent", o.Indent, "Indentation of json output with --pretty and of yaml output: a number of spaces (0 to 16, 2 to 9 for yaml) or a string (yaml only supports spaces). Defaults to 2 spaces.")
+	fs.BoolVar(&o.ResolveDNS, "resolve-dns", o.ResolveDNS, "Annotate IP addresses in table and name output with their reverse-DNS names.")
+	fs.StringVar(&o.Template, "template", o.Template, "Go template to use for go-template output, applied to the json representation of the object.")
+	fs.StringVar(&o.MissingKey, "template-missing-key", renderer.MissingKeyZero, fmt.Sprintf("How go-template output handles missing keys: %s. zero renders missing keys empty.", strings.Join(renderer.MissingKeyModes, "|")))
+	fs.BoolVar(&o.UnwrapSingle, "unwrap-single", o.UnwrapSingle, "In json and yaml output, render the item of a list with exactly one item instead of the list.")
+	fs.StringSliceVar(&o.Columns, "columns", o.Columns, "Only show these columns, in this order, in table, line and csv output, e.g. ID,VNI,UnderlayRoute.")
+	fs.DurationVar(&o.RenderTimeout, "render-timeout", o.RenderTimeout, "Fail if the output does not accept rendered data for this long, e.g. a stalled pipe. 0 waits forever.")
+	fs.BoolVar(&o.NoHeaders, "no-headers", o.NoHeaders, "Do not print the header row in table output, e.g. to process the columns with awk.")
+	fs.BoolVar(&o.Quiet, "quiet", o.Quiet, "Do not print \"No resources found.\" to stderr for empty lists in name, table and line output.")
+	fs.StringVar(&o.PreferIPVersion, "prefer-ip-version", IPVersion4, fmt.Sprintf("IP version to show first in table columns and to sort first by address: %s or %s.", IPVersion4, IPVersion6))
 }
 
+// GetWide reports whether tables show more columns, by --wide or -o wide.
 func (o *RendererOptions) GetWide() bool {
-	return o.Wide
+	return o.Wide || o.Output == "wide"
+}
+
+func (o *RendererOptions) GetPreferIPv6() bool {
+	return o.PreferIPVersion == IPVersion6
+}
+
+// indent returns the indentation given by --indent, either a number of spaces or a
+// literal string in which "\t" stands for a tab.
+func (o *RendererOptions) indent() (string, error) {
+	if o.Indent == "" {
+		return renderer.DefaultIndent, nil
+	}
+	if o.isOutput("json") && !o.Pretty {
+		return "", fmt.Errorf("--indent requires --pretty with json output")
+	}
+
+	var indent string
+	if n, err := strconv.Atoi(o.Indent); err == nil {
+		if n < 0 || n > 16 {
+			return "", fmt.Errorf("--indent must be between 0 and 16 spaces, got %d", n)
+		}
+		indent = strings.Repeat(" ", n)
+	} else {
+		indent = strings.ReplaceAll(o.Indent, `\t`, "\t")
+	}
+
+	if o.isOutput("yaml") {
+		if strings.Trim(indent, " ") != "" {
+			return "", fmt.Errorf("yaml output only supports indentation with spaces")
+		}
+		// yaml.v3 silently indents by 2 spaces instead of any other indentation
+		if len(indent) < 2 || len(indent) > 9 {
+			return "", fmt.Errorf("yaml output only supports an indentation of 2 to 9 spaces, got %d", len(indent))
+		}
+	}
+	return indent, nil
+}
+
+// tableConverter returns the converter of table and line output.
+func (o *RendererOptions) tableConverter() renderer.TableConverter {
+	renderer.DefaultTableConverter.SetWide(o.GetWide())
+	renderer.DefaultTableConverter.SetPreferIPv6(o.GetPreferIPv6())
+	if len(o.Columns) == 0 {
+		return renderer.DefaultTableConverter
+	}
+	return renderer.NewColumnsConverter(renderer.DefaultTableConverter, o.Columns)
+}
+
+func (o *RendererOptions) isOutput(name string) bool {
+	output := o.Output
+	if alias, ok := rendererAliases[output]; ok {
+		output = alias
+	}
+	return output == name
+}
+
+// IsStructured reports whether the output format is meant to be parsed by other programs.
+func (o *RendererOptions) IsStructured() bool {
+	return o.isOutput("json") || o.isOutput("yaml")
+}
+
+// isHumanReadable reports whether the output is name, table or line output.
+func (o *RendererOptions) isHumanReadable() bool {
+	return o.Output == "" || o.isOutput("name") || o.isOutput("table") || o.isOutput("line")
+}
+
+// isTabular reports whether the output is table, line or csv output, which cannot show server errors.
+func (o *RendererOptions) isTabular() bool {
+	return o.isOutput("table") || o.isOutput("line") || o.isOutput("csv") || o.Output == ""
 }
 
 func (o *RendererOptions) NewRenderer(operation string, w io.Writer) (renderer.Renderer, error) {
 	// TODO: Factor out instantiation of registry & make it more modular.
 	registry := renderer.NewRegistry()
 
+	indent, err := o.indent()
+	if err != nil {
+		return nil, err
+	}
+	if o.PreferIPVersion != "" {
+		if _, err := ParseIPVersion(o.PreferIPVersion); err != nil {
+			return nil, fmt.Errorf("invalid --prefer-ip-version: %w", err)
+		}
+	}
+	if o.RenderTimeout > 0 {
+		w = dpsvcio.NewTimeoutWriter(w, o.RenderTimeout)
+	}
+
 	if err := registry.Register("json", func(w io.Writer) renderer.Renderer {
-		return renderer.NewJSON(w, o.Pretty)
+		return renderer.NewIndentedJSON(w, o.Pretty, indent)
 	}); err != nil {
 		return nil, err
 	}
 
 	if err := registry.Register("yaml", func(w io.Writer) renderer.Renderer {
-		return renderer.NewYAML(w)
+		return renderer.NewIndentedYAML(w, len(indent))
 	}); err != nil {
 		return nil, err
 	}
 
+	output, tmpl, _ := strings.Cut(o.Output, "=")
+	if output == "go-template" {
+		if tmpl == "" {
+			tmpl = o.Template
+		}
+		if tmpl == "" {
+			return nil, fmt.Errorf("go-template output requires a template, use --template or -o go-template=TEMPLATE")
+		}
+		goTemplate, err := renderer.NewGoTemplate(w, tmpl, o.MissingKey)
+		if err != nil {
+			return nil, err
+		}
+		// the template is parsed up front to report syntax errors, it already writes to w
+		if err := registry.Register("go-template", func(io.Writer) renderer.Renderer {
+			return goTemplate
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if output == "jsonpath" {
+		if tmpl == "" {
+			return nil, fmt.Errorf("jsonpath output requires an expression, e.g. -o jsonpath={.items[*].spec.vni}")
+		}
+		jsonPath, err := renderer.NewJSONPath(w, tmpl)
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.Register("jsonpath", func(io.Writer) renderer.Renderer {
+			return jsonPath
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// the resolver is kept across renderers, so that addresses are looked up only once per command
+	if o.ResolveDNS && o.resolver == nil {
+		o.resolver = renderer.NewDNSResolver(renderer.DefaultLookupTimeout)
+	}
+
 	if err := registry.Register("name", func(w io.Writer) renderer.Renderer {
+		if o.resolver != nil {
+			return renderer.NewResolvingName(w, operation, o.resolver)
+		}
 		return renderer.NewName(w, operation)
 	}); err != nil {
 		return nil, err
 	}
 
 	if err := registry.Register("table", func(w io.Writer) renderer.Renderer {
-		renderer.DefaultTableConverter.SetWide(o.Wide)
-		return renderer.NewTable(w, renderer.DefaultTableConverter)
+		converter := o.tableConverter()
+		table := renderer.NewTable(w, converter)
+		if o.resolver != nil {
+			table = renderer.NewResolvingTable(w, converter, o.resolver)
+		}
+		table.SetNoHeaders(o.NoHeaders)
+		return table
 	}); err != nil {
 		return nil, err
 	}
 
-	output := o.Output
+	if err := registry.Register("csv", func(w io.Writer) renderer.Renderer {
+		return renderer.NewCSV(w, o.tableConverter())
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := registry.Register("line", func(w io.Writer) renderer.Renderer {
+		converter := o.tableConverter()
+		if o.resolver != nil {
+			return renderer.NewResolvingLine(w, converter, o.resolver)
+		}
+		return renderer.NewLine(w, converter)
+	}); err != nil {
+		return nil, err
+	}
+
+	for alias, name := range rendererAliases {
+		if err := registry.RegisterAlias(alias, name); err != nil {
+			return nil, err
+		}
+	}
+
 	if output == "" {
 		output = "table"
 	}
@@ -140,7 +454,7 @@ func (o *RendererOptions) NewRenderer(operation string, w io.Writer) (renderer.R
 func (o *RendererOptions) RenderObject(operation string, w io.Writer, obj api.Object) error {
 	if obj.GetStatus().Code != 0 {
 		operation = fmt.Sprintf("server error: %d, %s", obj.GetStatus().Code, obj.GetStatus().Message)
-		if o.Output == "table" {
+		if o.isTabular() {
 			o.Output = "name"
 		}
 	}
@@ -160,16 +474,28 @@ func (o *RendererOptions) RenderObject(operation string, w io.Writer, obj api.Ob
 func (o *RendererOptions) RenderList(operation string, w io.Writer, list api.List) error {
 	if list.GetStatus().Code != 0 {
 		operation = fmt.Sprintf("server error: %d, %s", list.GetStatus().Code, list.GetStatus().Message)
-		if o.Output == "table" {
+		if o.isTabular() {
 			o.Output = "name"
 		}
 	}
+	// structured output renders an empty list, the other formats would render
+	// nothing or just the table headers
+	if len(list.GetItems()) == 0 && list.GetStatus().Code == 0 && o.isHumanReadable() {
+		if !o.Quiet {
+			fmt.Fprintln(os.Stderr, NoResourcesFound)
+		}
+		return nil
+	}
 	renderer, err := o.NewRenderer(operation, w)
 	if err != nil {
 		return fmt.Errorf("error creating renderer: %w", err)
 	}
-	if err := renderer.Render(list); err != nil {
-		return fmt.Errorf("error rendering %s: %w", list.GetItems()[0].GetKind(), err)
+	var v any = list
+	if items := list.GetItems(); o.UnwrapSingle && o.IsStructured() && len(items) == 1 && list.GetStatus().Code == 0 {
+		v = items[0]
+	}
+	if err := renderer.Render(v); err != nil {
+		return fmt.Errorf("error rendering %T: %w", list, err)
 	}
 	if list.GetStatus().Code != 0 {
 		return fmt.Errorf(strconv.Itoa(apierrors.SERVER_ERROR))
@@ -177,11 +503,25 @@ func (o *RendererOptions) RenderList(operation string, w io.Writer, list api.Lis
 	return nil
 }
 
+// rendererAliases maps commonly typed output format variants to the registered renderer names.
+var rendererAliases = map[string]string{
+	"j":   "json",
+	"y":   "yaml",
+	"yml": "yaml",
+	"t":   "table",
+	"tbl": "table",
+	"n":   "name",
+	// wide is table output with --wide
+	"wide": "table",
+}
+
 type RendererFactory interface {
 	NewRenderer(operation string, w io.Writer) (renderer.Renderer, error)
 	RenderObject(operation string, w io.Writer, obj api.Object) error
 	RenderList(operation string, w io.Writer, list api.List) error
 	GetWide() bool
+	GetPreferIPv6() bool
+	IsStructured() bool
 }
 
 type SourcesOptions struct {
@@ -189,7 +529,7 @@ type SourcesOptions struct {
 }
 
 func (o *SourcesOptions) AddFlags(fs *pflag.FlagSet) {
-	fs.StringSliceVarP(&o.Filename, "filename", "f", o.Filename, "Filename, directory, or URL to file to use to create the resource")
+	fs.StringSliceVarP(&o.Filename, "filename", "f", o.Filename, "Filename, directory, or URL to file to use to create the resource, - for stdin")
 }
 
 func (o *SourcesOptions) NewIterator() (*sources.Iterator, error) {
@@ -200,6 +540,67 @@ type SourcesReaderFactory interface {
 	NewIterator() (*sources.Iterator, error)
 }
 
+// PageOptions limit the number of items shown by list commands. dpservice does not
+// support pagination, so the limit and offset are applied after fetching all items.
+type PageOptions struct {
+	Limit  uint
+	Offset uint
+}
+
+func (o *PageOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.UintVar(&o.Limit, "limit", o.Limit, "Maximum number of items to show (0 for no limit).")
+	fs.UintVar(&o.Offset, "offset", o.Offset, "Number of items to skip before showing items.")
+}
+
+// Paginate applies the limit and offset of opts to items and reports to w that this
+// happened client-side and whether the result was truncated.
+func Paginate[T any](w io.Writer, items []T, opts PageOptions) []T {
+	if opts.Limit == 0 && opts.Offset == 0 {
+		return items
+	}
+
+	total := uint(len(items))
+	start := min(opts.Offset, total)
+	end := total
+	if opts.Limit > 0 {
+		end = min(start+opts.Limit, total)
+	}
+
+	fmt.Fprintln(w, "Warning: dpservice does not support pagination, --limit and --offset are applied client-side")
+	if start > 0 || end < total {
+		fmt.Fprintf(w, "Showing %d of %d items (truncated)\n", end-start, total)
+	}
+	return items[start:end]
+}
+
+// SourceErrors collects the errors of individual sources (e.g. interfaces) in
+// aggregating commands, so that the remaining sources can still be processed.
+type SourceErrors struct {
+	sources []string
+	errs    []error
+}
+
+func (e *SourceErrors) Add(source string, err error) {
+	e.sources = append(e.sources, source)
+	e.errs = append(e.errs, err)
+}
+
+func (e *SourceErrors) Len() int {
+	return len(e.errs)
+}
+
+// Summarize prints the collected errors to w and returns an error reflecting the partial failure.
+func (e *SourceErrors) Summarize(w io.Writer) error {
+	if e.Len() == 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "%d source(s) failed:\n", e.Len())
+	for i, source := range e.sources {
+		fmt.Fprintf(w, "  %s: %v\n", source, e.errs[i])
+	}
+	return fmt.Errorf(strconv.Itoa(apierrors.SERVER_ERROR))
+}
+
 type RouteKey struct {
 	Prefix     netip.Prefix
 	NextHopVNI uint32
@@ -207,9 +608,9 @@ type RouteKey struct {
 }
 
 func ParseRouteKey(prefixStr, nextHopVNIStr, nextHopIPStr string) (RouteKey, error) {
-	prefix, err := netip.ParsePrefix(prefixStr)
+	prefix, err := flag.ParsePrefix(prefixStr)
 	if err != nil {
-		return RouteKey{}, fmt.Errorf("error parsing prefix: %w", err)
+		return RouteKey{}, err
 	}
 
 	nextHopVNI, err := strconv.ParseUint(nextHopVNIStr, 10, 32)
@@ -249,7 +650,7 @@ func ParseRouteKeyArgs(args []string) ([]RouteKey, error) {
 func ParsePrefixArgs(args []string) ([]netip.Prefix, error) {
 	prefixes := make([]netip.Prefix, len(args))
 	for i, arg := range args {
-		prefix, err := netip.ParsePrefix(arg)
+		prefix, err := flag.ParsePrefix(arg)
 		if err != nil {
 			return nil, fmt.Errorf("[prefix %d] %w", i, err)
 		}
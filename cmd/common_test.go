@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Paginate", func() {
+	var (
+		w     *bytes.Buffer
+		items []int
+	)
+	BeforeEach(func() {
+		w = &bytes.Buffer{}
+		items = []int{0, 1, 2, 3, 4}
+	})
+
+	It("should return all items without a warning if no limit or offset is set", func() {
+		Expect(Paginate(w, items, PageOptions{})).To(Equal(items))
+		Expect(w.String()).To(BeEmpty())
+	})
+
+	It("should apply limit and offset and report truncation", func() {
+		Expect(Paginate(w, items, PageOptions{Limit: 2, Offset: 1})).To(Equal([]int{1, 2}))
+		Expect(w.String()).To(ContainSubstring("client-side"))
+		Expect(w.String()).To(ContainSubstring("Showing 2 of 5 items (truncated)"))
+	})
+
+	It("should not report truncation if all items fit", func() {
+		Expect(Paginate(w, items, PageOptions{Limit: 10})).To(Equal(items))
+		Expect(w.String()).NotTo(ContainSubstring("truncated"))
+	})
+
+	It("should return no items if the offset is past the end", func() {
+		Expect(Paginate(w, items, PageOptions{Offset: 10})).To(BeEmpty())
+	})
+})
+
+var _ = Describe("SourceErrors", func() {
+	It("should not report anything if no source failed", func() {
+		var buf bytes.Buffer
+		Expect((&SourceErrors{}).Summarize(&buf)).To(Succeed())
+		Expect(buf.String()).To(BeEmpty())
+	})
+
+	It("should summarize the failed sources in order and fail with a server error", func() {
+		sourceErrs := &SourceErrors{}
+		sourceErrs.Add("interface/vm1", errors.New("no such interface"))
+		sourceErrs.Add("interface/vm3", errors.New("connection reset"))
+		Expect(sourceErrs.Len()).To(Equal(2))
+
+		var buf bytes.Buffer
+		Expect(sourceErrs.Summarize(&buf)).To(MatchError(strconv.Itoa(apierrors.SERVER_ERROR)))
+		Expect(buf.String()).To(Equal("2 source(s) failed:\n" +
+			"  interface/vm1: no such interface\n" +
+			"  interface/vm3: connection reset\n"))
+	})
+})
+
+var _ = Describe("RendererOptions", func() {
+	one := &api.InterfaceList{
+		TypeMeta: api.TypeMeta{Kind: api.InterfaceListKind},
+		Items:    []api.Interface{{TypeMeta: api.TypeMeta{Kind: api.InterfaceKind}, InterfaceMeta: api.InterfaceMeta{ID: "vm1"}}},
+	}
+
+	It("should render the item of single-item lists with --unwrap-single", func() {
+		var w bytes.Buffer
+		opts := &RendererOptions{Output: "json", UnwrapSingle: true}
+		Expect(opts.RenderList("", &w, one)).To(Succeed())
+		Expect(w.String()).To(HavePrefix(`{"kind":"Interface","metadata":{"id":"vm1"}`))
+	})
+
+	It("should keep lists with more items wrapped", func() {
+		two := &api.InterfaceList{TypeMeta: one.TypeMeta, Items: append(one.Items, one.Items[0])}
+		var w bytes.Buffer
+		opts := &RendererOptions{Output: "json", UnwrapSingle: true}
+		Expect(opts.RenderList("", &w, two)).To(Succeed())
+		Expect(w.String()).To(HavePrefix(`{"kind":"InterfaceList"`))
+	})
+
+	DescribeTable("should reject --indent that would be ignored",
+		func(opts RendererOptions, msg string) {
+			_, err := opts.NewRenderer("", &bytes.Buffer{})
+			Expect(err).To(MatchError(msg))
+		},
+		Entry("json without --pretty", RendererOptions{Output: "json", Indent: "4"}, "--indent requires --pretty with json output"),
+		Entry("yaml below 2 spaces", RendererOptions{Output: "yaml", Indent: "1"}, "yaml output only supports an indentation of 2 to 9 spaces, got 1"),
+		Entry("yaml above 9 spaces", RendererOptions{Output: "yml", Indent: "10"}, "yaml output only supports an indentation of 2 to 9 spaces, got 10"),
+		Entry("yaml with tabs", RendererOptions{Output: "yaml", Indent: `\t`}, "yaml output only supports indentation with spaces"),
+	)
+
+	It("should indent yaml and pretty json by --indent", func() {
+		var yaml, json bytes.Buffer
+		Expect((&RendererOptions{Output: "yaml", Indent: "4"}).RenderList("", &yaml, one)).To(Succeed())
+		Expect(yaml.String()).To(ContainSubstring("\n    - kind: Interface\n"))
+		Expect((&RendererOptions{Output: "json", Pretty: true, Indent: `\t`}).RenderList("", &json, one)).To(Succeed())
+		Expect(json.String()).To(ContainSubstring("\n\t\"kind\": \"InterfaceList\""))
+	})
+
+	DescribeTable("should render server errors as name output instead of a table",
+		func(output string) {
+			failed := &api.Interface{
+				TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+				InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+				Status:        api.Status{Code: 202, Message: "already exists"},
+			}
+			var w, name bytes.Buffer
+			Expect((&RendererOptions{Output: output}).RenderObject("", &w, failed)).NotTo(Succeed())
+			Expect((&RendererOptions{Output: "name"}).RenderObject("", &name, failed)).NotTo(Succeed())
+			Expect(w.String()).To(Equal(name.String()))
+		},
+		Entry("default", ""),
+		Entry("table", "table"),
+		Entry("table alias", "tbl"),
+		Entry("wide", "wide"),
+		Entry("csv", "csv"),
+	)
+})
+
+var _ = Describe("DialTarget", func() {
+	It("should pass host:port addresses through to the dialer", func() {
+		Expect(DialTarget("localhost:1337")).To(Equal("passthrough:///localhost:1337"))
+		Expect(DialTarget("[fc00::1]:1337")).To(Equal("passthrough:///[fc00::1]:1337"))
+	})
+
+	It("should keep addresses with the scheme of a registered resolver", func() {
+		Expect(DialTarget("unix:///run/dpservice.sock")).To(Equal("unix:///run/dpservice.sock"))
+		Expect(DialTarget("dns:///dpservice:1337")).To(Equal("dns:///dpservice:1337"))
+	})
+	It("should render -o wide like table output with --wide", func() {
+		list := &api.InterfaceList{
+			TypeMeta: api.TypeMeta{Kind: api.InterfaceListKind},
+			Items: []api.Interface{{
+				TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+				InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+				Spec:          api.InterfaceSpec{PXE: &api.PXE{Server: "192.168.129.1", FileName: "ipxe/x86_64/ipxe.efi"}, Metering: &api.MeteringParams{}},
+			}},
+		}
+
+		var wide, table, narrow bytes.Buffer
+		Expect((&RendererOptions{Output: "wide"}).RenderList("", &wide, list)).To(Succeed())
+		Expect((&RendererOptions{Output: "table", Wide: true}).RenderList("", &table, list)).To(Succeed())
+		Expect((&RendererOptions{Output: "table"}).RenderList("", &narrow, list)).To(Succeed())
+		Expect(wide.String()).To(Equal(table.String()))
+		Expect(wide.String()).To(ContainSubstring("ipxe/x86_64/ipxe.efi"))
+		Expect(narrow.String()).NotTo(ContainSubstring("ipxe/x86_64/ipxe.efi"))
+	})
+})
@@ -4,9 +4,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/spf13/cobra"
 )
 
@@ -90,3 +94,50 @@ func init() {
 	// is called directly, e.g.:
 	// completionCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
+
+// completionTimeout bounds the calls to dpservice made while completing flag values.
+const completionTimeout = 2 * time.Second
+
+// RegisterInterfaceIDCompletion registers CompleteInterfaceIDs for the --interface-id flag
+// of cmd and all of its subcommands.
+func RegisterInterfaceIDCompletion(cmd *cobra.Command, dpdkClientFactory DPDKClientFactory) {
+	if cmd.Flags().Lookup("interface-id") != nil {
+		util.Must(cmd.RegisterFlagCompletionFunc("interface-id", CompleteInterfaceIDs(dpdkClientFactory)))
+	}
+	for _, subcommand := range cmd.Commands() {
+		RegisterInterfaceIDCompletion(subcommand, dpdkClientFactory)
+	}
+}
+
+// CompleteInterfaceIDs suggests the IDs of the interfaces of dpservice. If dpservice cannot be
+// listed within completionTimeout, nothing is suggested.
+func CompleteInterfaceIDs(dpdkClientFactory DPDKClientFactory) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+		defer cancel()
+
+		client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		// errors are not printed, the output of completion is read by the shell
+		defer func() { _ = cleanup() }()
+
+		ifaces, err := client.ListInterfaces(ctx)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var ids []string
+		for _, iface := range ifaces.Items {
+			if strings.HasPrefix(iface.ID, toComplete) {
+				ids = append(ids, iface.ID)
+			}
+		}
+		return ids, cobra.ShellCompDirectiveNoFileComp
+	}
+}
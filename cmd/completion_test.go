@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("CompleteInterfaceIDs", func() {
+	It("should suggest the interface IDs starting with the typed prefix", func(ctx SpecContext) {
+		cmd := &cobra.Command{}
+		cmd.SetContext(ctx)
+		c := &interfaceClient{ids: []string{"3f2a-0001", "3f2b-0002", "7c00-0003"}}
+
+		ids, directive := CompleteInterfaceIDs(fakeClientFactory{c})(cmd, nil, "3f2")
+		Expect(ids).To(Equal([]string{"3f2a-0001", "3f2b-0002"}))
+		Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+	})
+
+	It("should suggest nothing if dpservice is unreachable", func(ctx SpecContext) {
+		cmd := &cobra.Command{}
+		cmd.SetContext(ctx)
+
+		ids, directive := CompleteInterfaceIDs(unreachableClientFactory{})(cmd, nil, "")
+		Expect(ids).To(BeEmpty())
+		Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+	})
+
+	It("should register the completion for every --interface-id flag", func() {
+		root := Command()
+		for _, path := range [][]string{{"add", "prefix"}, {"delete", "nat"}, {"list", "firewallrules"}} {
+			cmd, _, err := root.Find(path)
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := cmd.GetFlagCompletionFunc("interface-id")
+			Expect(ok).To(BeTrue(), "%v", path)
+		}
+	})
+})
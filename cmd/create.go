@@ -7,31 +7,32 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"reflect"
-	"strings"
 
 	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
 	"github.com/ironcore-dev/dpservice-cli/dpdk/runtime"
 	"github.com/ironcore-dev/dpservice-cli/sources"
-	"github.com/ironcore-dev/dpservice-go/errors"
 	"github.com/spf13/cobra"
 )
 
 func Create(factory DPDKClientFactory) *cobra.Command {
 	rendererOptions := &RendererOptions{Output: "name"}
 	sourcesOptions := &SourcesOptions{}
+	dryRunOptions := &DryRunOptions{}
 
 	cmd := &cobra.Command{
-		Use:     "create [command]",
-		Aliases: []string{"add"},
-		Args:    cobra.NoArgs,
+		Use:         "create [command]",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Aliases:     []string{"add"},
+		Args:        cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
-			return RunCreate(ctx, factory, rendererOptions, sourcesOptions)
+			return RunCreate(ctx, factory, rendererOptions, sourcesOptions, *dryRunOptions)
 		},
 	}
 
 	rendererOptions.AddFlags(cmd.PersistentFlags())
+	dryRunOptions.AddFlags(cmd.PersistentFlags())
+	factory = dryRunClientFactory{factory, dryRunOptions}
 
 	sourcesOptions.AddFlags(cmd.Flags())
 
@@ -63,22 +64,10 @@ func RunCreate(
 	dpdkClientFactory DPDKClientFactory,
 	rendererFactory RendererFactory,
 	sourcesReaderFactory SourcesReaderFactory,
+	dryRun DryRunOptions,
 ) error {
-	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
-	if err != nil {
-		return fmt.Errorf("error creating dpdk client: %w", err)
-	}
-	defer func() {
-		if err := cleanup(); err != nil {
-			fmt.Printf("Error cleaning up client: %v\n", err)
-		}
-	}()
-
-	dc := dynamic.NewFromStructured(client)
-
-	renderer, err := rendererFactory.NewRenderer("created", os.Stdout)
-	if err != nil {
-		return fmt.Errorf("error creating renderer: %w", err)
+	if err := dryRun.Validate(); err != nil {
+		return err
 	}
 
 	iterator, err := sourcesReaderFactory.NewIterator()
@@ -91,24 +80,21 @@ func RunCreate(
 		return fmt.Errorf("error collecting objects: %w", err)
 	}
 
-	for _, obj := range objs {
-		res, err := dc.Create(ctx, obj)
-		if strings.Contains(err.Error(), errors.StatusErrorString) {
-			r := reflect.ValueOf(res)
-			err := reflect.Indirect(r).FieldByName("Status").FieldByName("Error")
-			msg := reflect.Indirect(r).FieldByName("Status").FieldByName("Message")
-			fmt.Printf("Error creating %T: Server error: %v %v\n", res, err, msg)
-			continue
-		}
-		if err != nil {
-			fmt.Printf("Error creating %T: %v\n", obj, err)
-			continue
-		}
+	if dryRun.DryRun == DryRunClient {
+		return renderDryRun(os.Stdout, rendererFactory, objs)
+	}
 
-		if err := renderer.Render(res); err != nil {
-			return fmt.Errorf("error rendering %T: %w", obj, err)
-		}
+	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dpdk client: %w", err)
 	}
+	defer func() {
+		if err := cleanup(); err != nil {
+			fmt.Printf("Error cleaning up client: %v\n", err)
+		}
+	}()
+
+	dc := dynamic.NewFromStructured(client)
 
-	return nil
+	return RunBatch(ctx, os.Stdout, rendererFactory, "create", objs, dc.Create)
 }
@@ -9,6 +9,7 @@ import (
 	"net/netip"
 	"os"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
 	"github.com/ironcore-dev/dpservice-cli/flag"
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/ironcore-dev/dpservice-go/api"
@@ -31,14 +32,15 @@ func CreateFirewallRule(dpdkClientFactory DPDKClientFactory, rendererFactory Ren
 		// if protocol flag is set, require also additional flags
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			filter, _ := cmd.Flags().GetString("protocol")
-			switch filter {
-			case "icmp", "1":
+			protocol, _ := conversion.Protocols.Parse(filter)
+			switch protocol {
+			case dpdkproto.Protocol_ICMP:
 				for _, name := range []string{"icmp-type", "icmp-code"} {
 					if err := cmd.MarkFlagRequired(name); err != nil {
 						return err
 					}
 				}
-			case "tcp", "6", "udp", "17":
+			case dpdkproto.Protocol_TCP, dpdkproto.Protocol_UDP:
 				if err := cmd.MarkFlagRequired("src-port-min"); err != nil {
 					return err
 				}
@@ -127,22 +129,21 @@ func RunCreateFirewallRule(ctx context.Context, dpdkClientFactory DPDKClientFact
 	}
 	defer DpdkClose(cleanup)
 
-	srcPfx, err := netip.ParsePrefix(opts.SourcePrefix.String())
-	if err != nil {
-		return fmt.Errorf("error parsing src prefix: %w", err)
-	}
-	dstPfx, err := netip.ParsePrefix(opts.DestinationPrefix.String())
-	if err != nil {
-		return fmt.Errorf("error parsing dst prefix: %w", err)
+	var protocol dpdkproto.Protocol
+	if opts.ProtocolFilter != "" {
+		protocol, err = conversion.Protocols.Parse(opts.ProtocolFilter)
+		if err != nil {
+			return fmt.Errorf("protocol can be only: icmp = 1/tcp = 6/udp = 17")
+		}
 	}
 
 	var protocolFilter dpdkproto.ProtocolFilter
-	switch opts.ProtocolFilter {
-	case "icmp", "1":
+	switch protocol {
+	case dpdkproto.Protocol_ICMP:
 		protocolFilter.Filter = &dpdkproto.ProtocolFilter_Icmp{Icmp: &dpdkproto.IcmpFilter{
 			IcmpType: opts.IcmpType,
 			IcmpCode: opts.IcmpCode}}
-	case "tcp", "6":
+	case dpdkproto.Protocol_TCP:
 		if opts.SrcPortLower < -1 || opts.SrcPortLower == 0 || opts.SrcPortLower > 65535 ||
 			opts.SrcPortUpper < -1 || opts.SrcPortUpper == 0 || opts.SrcPortUpper > 65535 ||
 			opts.DstPortLower < -1 || opts.DstPortLower == 0 || opts.DstPortLower > 65535 ||
@@ -158,7 +159,7 @@ func RunCreateFirewallRule(ctx context.Context, dpdkClientFactory DPDKClientFact
 			DstPortLower: opts.DstPortLower,
 			DstPortUpper: opts.DstPortUpper,
 		}}
-	case "udp", "17":
+	case dpdkproto.Protocol_UDP:
 		if opts.SrcPortLower < -1 || opts.SrcPortLower == 0 || opts.SrcPortLower > 65535 ||
 			opts.SrcPortUpper < -1 || opts.SrcPortUpper == 0 || opts.SrcPortUpper > 65535 ||
 			opts.DstPortLower < -1 || opts.DstPortLower == 0 || opts.DstPortLower > 65535 ||
@@ -175,7 +176,7 @@ func RunCreateFirewallRule(ctx context.Context, dpdkClientFactory DPDKClientFact
 			DstPortUpper: opts.DstPortUpper,
 		}}
 	// Not defining a protocol filter matches all protocols
-	case "":
+	case dpdkproto.Protocol_UNDEFINED:
 	default:
 		return fmt.Errorf("protocol can be only: icmp = 1/tcp = 6/udp = 17")
 	}
@@ -193,8 +194,8 @@ func RunCreateFirewallRule(ctx context.Context, dpdkClientFactory DPDKClientFact
 			TrafficDirection:  opts.TrafficDirection,
 			FirewallAction:    opts.FirewallAction,
 			Priority:          opts.Priority,
-			SourcePrefix:      &srcPfx,
-			DestinationPrefix: &dstPfx,
+			SourcePrefix:      &opts.SourcePrefix,
+			DestinationPrefix: &opts.DestinationPrefix,
 			ProtocolFilter: &dpdkproto.ProtocolFilter{
 				Filter: protocolFilter.Filter},
 		},
@@ -6,6 +6,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/netip"
 	"os"
 
@@ -28,6 +29,10 @@ func CreateInterface(dpdkClientFactory DPDKClientFactory, rendererFactory Render
 		Aliases: InterfaceAliases,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(cmd); err != nil {
+				return err
+			}
+
 			return RunCreateInterface(
 				cmd.Context(),
 				dpdkClientFactory,
@@ -54,6 +59,9 @@ type CreateInterfaceOptions struct {
 	PxeFileName     string
 	TotalMeterRate  uint64
 	PublicMeterRate uint64
+	UnderlayIP      netip.Addr
+	MAC             string
+	PrintOptions
 }
 
 func (o *CreateInterfaceOptions) AddFlags(fs *pflag.FlagSet) {
@@ -66,6 +74,24 @@ func (o *CreateInterfaceOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.PxeFileName, "pxe-file-name", o.PxeFileName, "PXE boot file name.")
 	fs.Uint64Var(&o.TotalMeterRate, "total-meter-rate", 0, "Total meter rate.")
 	fs.Uint64Var(&o.PublicMeterRate, "public-meter-rate", 0, "Public meter rate.")
+	flag.AddrVar(fs, &o.UnderlayIP, "underlay-ip", o.UnderlayIP, "Underlay IP to request for the interface. Not supported by dpservice yet, the server always assigns the underlay route.")
+	fs.StringVar(&o.MAC, "mac", o.MAC, "MAC address to assign to the interface. Not supported by dpservice yet, the MAC is determined by the device.")
+	o.PrintOptions.AddFlags(fs)
+}
+
+func (o *CreateInterfaceOptions) Validate(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("underlay-ip") {
+		// CreateInterfaceRequest has no field for the underlay address, so it cannot be requested
+		return fmt.Errorf("--underlay-ip is not supported: dpservice does not allow requesting an underlay address, it always assigns the underlay route itself")
+	}
+	if cmd.Flags().Changed("mac") {
+		if _, err := net.ParseMAC(o.MAC); err != nil {
+			return fmt.Errorf("invalid --mac: %w", err)
+		}
+		// neither CreateInterfaceRequest nor the interface returned by dpservice has a MAC address
+		return fmt.Errorf("--mac is not supported: dpservice does not allow setting the MAC address of an interface")
+	}
+	return o.PrintOptions.Validate()
 }
 
 func (o *CreateInterfaceOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -77,6 +103,23 @@ func (o *CreateInterfaceOptions) MarkRequiredFlags(cmd *cobra.Command) error {
 	return nil
 }
 
+// Interface returns the interface specified by the options.
+func (o *CreateInterfaceOptions) Interface() *api.Interface {
+	return &api.Interface{
+		InterfaceMeta: api.InterfaceMeta{
+			ID: o.ID,
+		},
+		Spec: api.InterfaceSpec{
+			VNI:      o.VNI,
+			Device:   o.Device,
+			IPv4:     &o.IPv4,
+			IPv6:     &o.IPv6,
+			PXE:      &api.PXE{Server: o.PxeServer, FileName: o.PxeFileName},
+			Metering: &api.MeteringParams{TotalRate: o.TotalMeterRate, PublicRate: o.PublicMeterRate},
+		},
+	}
+}
+
 func RunCreateInterface(ctx context.Context, dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory, opts CreateInterfaceOptions) error {
 	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
 	if err != nil {
@@ -84,22 +127,10 @@ func RunCreateInterface(ctx context.Context, dpdkClientFactory DPDKClientFactory
 	}
 	defer DpdkClose(cleanup)
 
-	iface, err := client.CreateInterface(ctx, &api.Interface{
-		InterfaceMeta: api.InterfaceMeta{
-			ID: opts.ID,
-		},
-		Spec: api.InterfaceSpec{
-			VNI:      opts.VNI,
-			Device:   opts.Device,
-			IPv4:     &opts.IPv4,
-			IPv6:     &opts.IPv6,
-			PXE:      &api.PXE{Server: opts.PxeServer, FileName: opts.PxeFileName},
-			Metering: &api.MeteringParams{TotalRate: opts.TotalMeterRate, PublicRate: opts.PublicMeterRate},
-		},
-	})
+	iface, err := client.CreateInterface(ctx, opts.Interface())
 	if err != nil && iface.Status.Code == 0 {
 		return fmt.Errorf("error creating interface: %w", err)
 	}
 
-	return rendererFactory.RenderObject(fmt.Sprintf("created, underlay route: %s", iface.Spec.UnderlayRoute), os.Stdout, iface)
+	return opts.Render(os.Stdout, rendererFactory, fmt.Sprintf("created, underlay route: %s", iface.Spec.UnderlayRoute), iface, iface.Spec.UnderlayRoute)
 }
@@ -9,6 +9,7 @@ import (
 	"net/netip"
 	"os"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
 	"github.com/ironcore-dev/dpservice-cli/flag"
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/ironcore-dev/dpservice-go/api"
@@ -22,12 +23,17 @@ func CreateLoadBalancer(dpdkClientFactory DPDKClientFactory, rendererFactory Ren
 	)
 
 	cmd := &cobra.Command{
-		Use:     "loadbalancer <--id> <--vni> <--vip> <--lbports>",
-		Short:   "Create a loadbalancer",
-		Example: "dpservice-cli create loadbalancer --id=4 --vni=100 --vip=10.20.30.40 --lbports=TCP/443,UDP/53",
+		Use:   "loadbalancer <--id> <--vni> <--vip> <--lbports>|<--lbports-json>",
+		Short: "Create a loadbalancer",
+		Example: "dpservice-cli create loadbalancer --id=4 --vni=100 --vip=10.20.30.40 --lbports=TCP/443,UDP/53 --target=ff80::1\n" +
+			`dpservice-cli create loadbalancer --id=4 --vni=100 --vip=10.20.30.40 --lbports-json='[{"protocol":"TCP","port":443}]'`,
 		Aliases: LoadBalancerAliases,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+
 			return RunCreateLoadBalancer(
 				cmd.Context(),
 				dpdkClientFactory,
@@ -40,15 +46,20 @@ func CreateLoadBalancer(dpdkClientFactory DPDKClientFactory, rendererFactory Ren
 	opts.AddFlags(cmd.Flags())
 
 	util.Must(opts.MarkRequiredFlags(cmd))
+	cmd.MarkFlagsOneRequired("lbports", "lbports-json")
 
 	return cmd
 }
 
 type CreateLoadBalancerOptions struct {
-	Id      string
-	VNI     uint32
-	LbVipIP netip.Addr
-	Lbports []string
+	Id            string
+	VNI           uint32
+	LbVipIP       netip.Addr
+	Lbports       []string
+	LbportsJSON   string
+	Targets       []netip.Addr
+	KeepOnPartial bool
+	PrintOptions
 }
 
 func (o *CreateLoadBalancerOptions) AddFlags(fs *pflag.FlagSet) {
@@ -56,10 +67,14 @@ func (o *CreateLoadBalancerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.Uint32Var(&o.VNI, "vni", o.VNI, "VNI to add the loadbalancer to.")
 	flag.AddrVar(fs, &o.LbVipIP, "vip", o.LbVipIP, "VIP to assign to the loadbalancer.")
 	fs.StringSliceVar(&o.Lbports, "lbports", o.Lbports, "LB ports to assign to the loadbalancer.")
+	fs.StringVar(&o.LbportsJSON, "lbports-json", o.LbportsJSON, `LB ports to assign to the loadbalancer as json, e.g. '[{"protocol":"TCP","port":443}]', in addition to --lbports.`)
+	flag.AddrSliceVar(fs, &o.Targets, "target", o.Targets, "Target IP to add to the loadbalancer after creation (repeatable).")
+	fs.BoolVar(&o.KeepOnPartial, "keep-on-partial", o.KeepOnPartial, "Keep the loadbalancer if adding a target fails instead of deleting it.")
+	o.PrintOptions.AddFlags(fs)
 }
 
 func (o *CreateLoadBalancerOptions) MarkRequiredFlags(cmd *cobra.Command) error {
-	for _, name := range []string{"id", "vni", "vip", "lbports"} {
+	for _, name := range []string{"id", "vni", "vip"} {
 		if err := cmd.MarkFlagRequired(name); err != nil {
 			return err
 		}
@@ -67,6 +82,33 @@ func (o *CreateLoadBalancerOptions) MarkRequiredFlags(cmd *cobra.Command) error
 	return nil
 }
 
+func (o *CreateLoadBalancerOptions) Validate() error {
+	if _, err := o.Ports(); err != nil {
+		return err
+	}
+	return o.PrintOptions.Validate()
+}
+
+// Ports returns the ports of --lbports followed by the ports of --lbports-json.
+func (o *CreateLoadBalancerOptions) Ports() ([]api.LBPort, error) {
+	var ports = make([]api.LBPort, 0, len(o.Lbports))
+	for _, p := range o.Lbports {
+		port, err := conversion.ParseLBPort(p)
+		if err != nil {
+			return nil, fmt.Errorf("error converting port: %w", err)
+		}
+		ports = append(ports, port)
+	}
+	if o.LbportsJSON != "" {
+		jsonPorts, err := conversion.ParseLBPortsJSON(o.LbportsJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error converting --lbports-json: %w", err)
+		}
+		ports = append(ports, jsonPorts...)
+	}
+	return ports, nil
+}
+
 func RunCreateLoadBalancer(ctx context.Context, dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory, opts CreateLoadBalancerOptions) error {
 	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
 	if err != nil {
@@ -74,13 +116,9 @@ func RunCreateLoadBalancer(ctx context.Context, dpdkClientFactory DPDKClientFact
 	}
 	defer DpdkClose(cleanup)
 
-	var ports = make([]api.LBPort, 0, len(opts.Lbports))
-	for _, p := range opts.Lbports {
-		port, err := api.StringLbportToLbport(p)
-		if err != nil {
-			return fmt.Errorf("error converting port: %w", err)
-		}
-		ports = append(ports, port)
+	ports, err := opts.Ports()
+	if err != nil {
+		return err
 	}
 
 	lb, err := client.CreateLoadBalancer(ctx, &api.LoadBalancer{
@@ -96,6 +134,35 @@ func RunCreateLoadBalancer(ctx context.Context, dpdkClientFactory DPDKClientFact
 	if err != nil && lb.Status.Code == 0 {
 		return fmt.Errorf("error creating loadbalancer: %w", err)
 	}
+	if err != nil || len(opts.Targets) == 0 {
+		return opts.Render(os.Stdout, rendererFactory, fmt.Sprintf("created, underlay route: %s", lb.Spec.UnderlayRoute), lb, lb.Spec.UnderlayRoute)
+	}
+
+	added := 0
+	for _, target := range opts.Targets {
+		_, err := client.CreateLoadBalancerTarget(ctx, &api.LoadBalancerTarget{
+			TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerTargetKind},
+			LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: opts.Id},
+			Spec:                   api.LoadBalancerTargetSpec{TargetIP: &target},
+		})
+		if err == nil {
+			added++
+			continue
+		}
+		err = fmt.Errorf("error adding loadbalancer target %s: %w", target, err)
+
+		if opts.KeepOnPartial {
+			if renderErr := opts.Render(os.Stdout, rendererFactory, fmt.Sprintf("created, underlay route: %s, targets: %d/%d", lb.Spec.UnderlayRoute, added, len(opts.Targets)), lb, lb.Spec.UnderlayRoute); renderErr != nil {
+				return renderErr
+			}
+			return err
+		}
+
+		if _, delErr := client.DeleteLoadBalancer(ctx, opts.Id); delErr != nil {
+			return fmt.Errorf("%w, deleting loadbalancer %s failed: %v", err, opts.Id, delErr)
+		}
+		return fmt.Errorf("%w, loadbalancer %s was deleted again", err, opts.Id)
+	}
 
-	return rendererFactory.RenderObject(fmt.Sprintf("created, underlay route: %s", lb.Spec.UnderlayRoute), os.Stdout, lb)
+	return opts.Render(os.Stdout, rendererFactory, fmt.Sprintf("created, underlay route: %s, targets: %d", lb.Spec.UnderlayRoute, added), lb, lb.Spec.UnderlayRoute)
 }
@@ -4,11 +4,15 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"net/netip"
 	"os"
+	"strings"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
 	"github.com/ironcore-dev/dpservice-cli/flag"
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/ironcore-dev/dpservice-go/api"
@@ -25,12 +29,15 @@ func CreateLoadBalancerTarget(
 	)
 
 	cmd := &cobra.Command{
-		Use:     "lbtarget <target-ip> <--lb-id>",
+		Use:     "lbtarget <--target-ip>|<--targets-file> <--lb-id>",
 		Short:   "Create a loadbalancer target",
-		Example: "dpservice-cli create lbtarget --target-ip=ff80::5 --lb-id=2",
+		Example: "dpservice-cli create lbtarget --target-ip=ff80::5 --lb-id=2\ncat targets.txt | dpservice-cli create lbtarget --targets-file=- --lb-id=2",
 		Args:    cobra.ExactArgs(0),
 		Aliases: LoadBalancerTargetAliases,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Complete(cmd.Flags(), cmd.InOrStdin()); err != nil {
+				return err
+			}
 
 			return RunCreateLoadBalancerTarget(
 				cmd.Context(),
@@ -50,16 +57,70 @@ func CreateLoadBalancerTarget(
 
 type CreateLoadBalancerTargetOptions struct {
 	TargetIP       netip.Addr
+	TargetsFile    string
 	LoadBalancerID string
+	Weight         uint32
+	// Targets are all targets to create, set by Complete from --target-ip and --targets-file.
+	Targets []netip.Addr
 }
 
 func (o *CreateLoadBalancerTargetOptions) AddFlags(fs *pflag.FlagSet) {
 	flag.AddrVar(fs, &o.TargetIP, "target-ip", o.TargetIP, "Loadbalancer Target IP.")
+	fs.StringVar(&o.TargetsFile, "targets-file", o.TargetsFile, "File with one target IP per line to add ('-' for stdin). Can be combined with --target-ip.")
 	fs.StringVar(&o.LoadBalancerID, "lb-id", o.LoadBalancerID, "ID of the loadbalancer to add the target for.")
+	fs.Uint32Var(&o.Weight, "weight", o.Weight, "Weight of the targets. Not supported yet, dpservice treats all targets of a loadbalancer equally.")
+}
+
+// Complete collects the targets of --target-ip and --targets-file, reading stdin from in.
+func (o *CreateLoadBalancerTargetOptions) Complete(fs *pflag.FlagSet, in io.Reader) error {
+	if fs.Changed("weight") {
+		return fmt.Errorf("--weight is not supported, dpservice has no weighted loadbalancer targets")
+	}
+	o.Targets = nil
+	if fs.Changed("target-ip") {
+		o.Targets = append(o.Targets, o.TargetIP)
+	}
+	if o.TargetsFile != "" {
+		if o.TargetsFile != "-" {
+			f, err := os.Open(o.TargetsFile)
+			if err != nil {
+				return fmt.Errorf("error opening targets file: %w", err)
+			}
+			defer f.Close()
+			in = f
+		}
+		targets, err := ReadTargetIPs(in)
+		if err != nil {
+			return fmt.Errorf("error reading targets file: %w", err)
+		}
+		o.Targets = append(o.Targets, targets...)
+	}
+	if len(o.Targets) == 0 {
+		return fmt.Errorf("either --target-ip or --targets-file with at least one target must be specified")
+	}
+	return nil
+}
+
+// ReadTargetIPs reads one IP address per line. Empty lines and lines starting with # are skipped.
+func ReadTargetIPs(r io.Reader) ([]netip.Addr, error) {
+	var targets []netip.Addr
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		ip, err := netip.ParseAddr(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		targets = append(targets, ip)
+	}
+	return targets, scanner.Err()
 }
 
 func (o *CreateLoadBalancerTargetOptions) MarkRequiredFlags(cmd *cobra.Command) error {
-	for _, name := range []string{"target-ip", "lb-id"} {
+	for _, name := range []string{"lb-id"} {
 		if err := cmd.MarkFlagRequired(name); err != nil {
 			return err
 		}
@@ -79,6 +140,18 @@ func RunCreateLoadBalancerTarget(
 	}
 	defer DpdkClose(cleanup)
 
+	if opts.TargetsFile != "" {
+		objs := make([]any, len(opts.Targets))
+		for i := range opts.Targets {
+			objs[i] = &api.LoadBalancerTarget{
+				TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerTargetKind},
+				LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: opts.LoadBalancerID},
+				Spec:                   api.LoadBalancerTargetSpec{TargetIP: &opts.Targets[i]},
+			}
+		}
+		return RunConcurrentBatch(ctx, os.Stdout, rendererFactory, "create", objs, dynamic.NewFromStructured(client).Create)
+	}
+
 	lbtarget, err := client.CreateLoadBalancerTarget(ctx, &api.LoadBalancerTarget{
 		TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerTargetKind},
 		LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: opts.LoadBalancerID},
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+type loadBalancerTargetClient struct {
+	client.Client
+	mu      sync.Mutex
+	created []string
+	// failTarget is rejected by dpservice
+	failTarget string
+}
+
+func (c *loadBalancerTargetClient) CreateLoadBalancerTarget(ctx context.Context, lbtarget *api.LoadBalancerTarget, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if lbtarget.Spec.TargetIP.String() == c.failTarget {
+		return &api.LoadBalancerTarget{Status: api.Status{Code: apierrors.NO_LB, Message: "NO_LB"}}, apierrors.NewStatusError(apierrors.NO_LB, "NO_LB")
+	}
+	c.created = append(c.created, lbtarget.Spec.TargetIP.String())
+	return lbtarget, nil
+}
+
+// lbTargetProtoClient records the IP version of every target as sent to dpservice.
+type lbTargetProtoClient struct {
+	dpdkproto.DPDKironcoreClient
+	mu       sync.Mutex
+	versions map[string]dpdkproto.IpVersion
+}
+
+func (c *lbTargetProtoClient) CreateLoadBalancerTarget(ctx context.Context, req *dpdkproto.CreateLoadBalancerTargetRequest, opts ...grpc.CallOption) (*dpdkproto.CreateLoadBalancerTargetResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.versions[string(req.TargetIp.Address)] = req.TargetIp.Ipver
+	return &dpdkproto.CreateLoadBalancerTargetResponse{Status: &dpdkproto.Status{}}, nil
+}
+
+var _ = Describe("CreateLoadBalancerTarget", func() {
+	It("should read target IPs skipping empty lines and comments", func() {
+		targets, err := ReadTargetIPs(strings.NewReader("ff80::1\n\n# backends\n 10.0.0.1 \n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(targets).To(Equal([]netip.Addr{netip.MustParseAddr("ff80::1"), netip.MustParseAddr("10.0.0.1")}))
+
+		_, err = ReadTargetIPs(strings.NewReader("ff80::1\nbackend\n"))
+		Expect(err).To(MatchError(ContainSubstring("line 2")))
+	})
+
+	It("should create the targets of stdin and --target-ip", func(ctx SpecContext) {
+		c := &loadBalancerTargetClient{}
+		cmd := CreateLoadBalancerTarget(fakeClientFactory{c}, &RendererOptions{Output: "name"})
+		cmd.SetIn(strings.NewReader("ff80::1\nff80::2\n"))
+		cmd.SetArgs([]string{"--lb-id=lb1", "--targets-file=-", "--target-ip=ff80::3"})
+
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+		Expect(c.created).To(ConsistOf("ff80::1", "ff80::2", "ff80::3"))
+	})
+
+	It("should create the remaining targets and fail if a target of the file failed", func(ctx SpecContext) {
+		captureOutput(&os.Stdout)
+		c := &loadBalancerTargetClient{failTarget: "ff80::2"}
+		cmd := CreateLoadBalancerTarget(fakeClientFactory{c}, &RendererOptions{Output: "name"})
+		cmd.SetIn(strings.NewReader("ff80::1\nff80::2\nff80::3\n"))
+		cmd.SetArgs([]string{"--lb-id=lb1", "--targets-file=-"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError("1 of 3 objects failed to create"))
+		Expect(c.created).To(ConsistOf("ff80::1", "ff80::3"))
+	})
+
+	It("should encode the IP version of every target of a mixed-family batch", func(ctx SpecContext) {
+		proto := &lbTargetProtoClient{versions: map[string]dpdkproto.IpVersion{}}
+		cmd := CreateLoadBalancerTarget(fakeClientFactory{client.NewClient(proto)}, &RendererOptions{Output: "name"})
+		cmd.SetIn(strings.NewReader("10.0.0.1\nff80::1\n10.0.0.2\nff80::2\n"))
+		cmd.SetArgs([]string{"--lb-id=lb1", "--targets-file=-"})
+
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+		Expect(proto.versions).To(Equal(map[string]dpdkproto.IpVersion{
+			"10.0.0.1": dpdkproto.IpVersion_IPV4,
+			"ff80::1":  dpdkproto.IpVersion_IPV6,
+			"10.0.0.2": dpdkproto.IpVersion_IPV4,
+			"ff80::2":  dpdkproto.IpVersion_IPV6,
+		}))
+	})
+
+	It("should require a target", func(ctx SpecContext) {
+		cmd := CreateLoadBalancerTarget(fakeClientFactory{&loadBalancerTargetClient{}}, &RendererOptions{Output: "name"})
+		cmd.SetArgs([]string{"--lb-id=lb1"})
+		cmd.SilenceUsage = true
+
+		Expect(cmd.ExecuteContext(ctx)).NotTo(Succeed())
+	})
+	It("should reject --weight", func(ctx SpecContext) {
+		c := &loadBalancerTargetClient{}
+		cmd := CreateLoadBalancerTarget(fakeClientFactory{c}, &RendererOptions{Output: "name"})
+		cmd.SetArgs([]string{"--lb-id=lb1", "--target-ip=ff80::1", "--weight=2"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("--weight is not supported")))
+		Expect(c.created).To(BeEmpty())
+	})
+})
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// lbClient records the loadbalancer calls and fails to add the target failTarget.
+type lbClient struct {
+	client.Client
+	failTarget netip.Addr
+	deleteErr  error
+	calls      []string
+}
+
+func (c *lbClient) CreateLoadBalancer(ctx context.Context, lb *api.LoadBalancer, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	c.calls = append(c.calls, "create "+lb.ID)
+	return lb, nil
+}
+
+func (c *lbClient) DeleteLoadBalancer(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	c.calls = append(c.calls, "delete "+id)
+	return &api.LoadBalancer{}, c.deleteErr
+}
+
+func (c *lbClient) CreateLoadBalancerTarget(ctx context.Context, lbtarget *api.LoadBalancerTarget, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error) {
+	c.calls = append(c.calls, "create target "+lbtarget.Spec.TargetIP.String())
+	if *lbtarget.Spec.TargetIP == c.failTarget {
+		return &api.LoadBalancerTarget{Status: api.Status{Code: 422, Message: "NO_BACKIP"}}, apierrors.NewStatusError(422, "NO_BACKIP")
+	}
+	return lbtarget, nil
+}
+
+var _ = Describe("CreateLoadBalancerOptions", func() {
+	It("should merge the ports of --lbports and --lbports-json", func() {
+		opts := CreateLoadBalancerOptions{Lbports: []string{"TCP/443"}, LbportsJSON: `[{"protocol":"UDP","port":53}]`}
+		Expect(opts.Validate()).To(Succeed())
+		Expect(opts.Ports()).To(Equal([]api.LBPort{
+			{Protocol: conversion.ProtocolTCP, Port: 443},
+			{Protocol: conversion.ProtocolUDP, Port: 53},
+		}))
+	})
+
+	It("should reject invalid --lbports-json before connecting", func(ctx SpecContext) {
+		cmd := CreateLoadBalancer(unreachableClientFactory{}, &RendererOptions{Output: "name"})
+		cmd.SetArgs([]string{"--id=lb1", "--vni=100", "--vip=10.20.30.40", `--lbports-json=[{"protocol":"TCP"`})
+		cmd.SilenceUsage = true
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("error converting --lbports-json")))
+	})
+})
+
+var _ = Describe("RunCreateLoadBalancer", func() {
+	var (
+		c      *lbClient
+		stdout string
+		opts   CreateLoadBalancerOptions
+	)
+
+	BeforeEach(func() {
+		c = &lbClient{failTarget: netip.MustParseAddr("10.0.0.2")}
+		opts = CreateLoadBalancerOptions{
+			Id:      "lb1",
+			VNI:     100,
+			LbVipIP: netip.MustParseAddr("10.20.30.40"),
+			Targets: []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("10.0.0.3")},
+		}
+
+		stdout = captureOutput(&os.Stdout)
+	})
+
+	It("should add all targets after creating the loadbalancer", func(ctx SpecContext) {
+		c.failTarget = netip.Addr{}
+		Expect(RunCreateLoadBalancer(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, opts)).To(Succeed())
+		Expect(c.calls).To(Equal([]string{"create lb1", "create target 10.0.0.1", "create target 10.0.0.2", "create target 10.0.0.3"}))
+		Expect(os.ReadFile(stdout)).To(ContainSubstring("targets: 3"))
+	})
+
+	It("should delete the loadbalancer again if adding a target fails", func(ctx SpecContext) {
+		err := RunCreateLoadBalancer(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, opts)
+		Expect(err).To(MatchError("error adding loadbalancer target 10.0.0.2: [error code 422] NO_BACKIP, loadbalancer lb1 was deleted again"))
+		Expect(c.calls).To(Equal([]string{"create lb1", "create target 10.0.0.1", "create target 10.0.0.2", "delete lb1"}))
+		Expect(os.ReadFile(stdout)).To(BeEmpty())
+	})
+
+	It("should report both errors if deleting the loadbalancer fails", func(ctx SpecContext) {
+		c.deleteErr = errors.New("connection reset")
+		err := RunCreateLoadBalancer(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, opts)
+		Expect(err).To(MatchError("error adding loadbalancer target 10.0.0.2: [error code 422] NO_BACKIP, deleting loadbalancer lb1 failed: connection reset"))
+	})
+
+	It("should keep the loadbalancer with the added targets with --keep-on-partial", func(ctx SpecContext) {
+		opts.KeepOnPartial = true
+		err := RunCreateLoadBalancer(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, opts)
+		Expect(err).To(MatchError("error adding loadbalancer target 10.0.0.2: [error code 422] NO_BACKIP"))
+		Expect(c.calls).To(Equal([]string{"create lb1", "create target 10.0.0.1", "create target 10.0.0.2"}))
+		Expect(os.ReadFile(stdout)).To(ContainSubstring("targets: 1/3"))
+	})
+})
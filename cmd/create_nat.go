@@ -8,10 +8,13 @@ import (
 	"fmt"
 	"net/netip"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/ironcore-dev/dpservice-cli/flag"
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -22,12 +25,15 @@ func CreateNat(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFact
 	)
 
 	cmd := &cobra.Command{
-		Use:     "nat <--interface-id> <--nat-ip> <--minport> <--maxport>",
+		Use:     "nat <--interface-id>|<--interface-ip> <--nat-ip> <--minport> <--maxport>|<--nat-port-count> [<--nat-port-align>]",
 		Short:   "Create a NAT on interface",
-		Example: "dpservice-cli create nat --interface-id=vm1 --nat-ip=10.20.30.40 --minport=30000 --maxport=30100",
+		Example: "dpservice-cli create nat --interface-id=vm1 --nat-ip=10.20.30.40 --minport=30000 --maxport=30100\ndpservice-cli create nat --interface-id=vm1 --nat-ip=10.20.30.40 --nat-port-count=64 --nat-port-align=64\ndpservice-cli create nat --interface-ip=10.200.1.4 --nat-ip=10.20.30.40 --minport=30000 --maxport=30100",
 		Aliases: NatAliases,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(cmd); err != nil {
+				return err
+			}
 
 			return RunCreateNat(
 				cmd.Context(),
@@ -41,26 +47,36 @@ func CreateNat(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFact
 	opts.AddFlags(cmd.Flags())
 
 	util.Must(opts.MarkRequiredFlags(cmd))
+	cmd.MarkFlagsMutuallyExclusive("interface-id", "interface-ip")
+	cmd.MarkFlagsOneRequired("interface-id", "interface-ip")
 
 	return cmd
 }
 
 type CreateNatOptions struct {
 	InterfaceID string
+	InterfaceIP netip.Addr
 	NatIP       netip.Addr
 	MinPort     uint32
 	MaxPort     uint32
+	PortCount   uint32
+	PortAlign   uint32
+	PrintOptions
 }
 
 func (o *CreateNatOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.InterfaceID, "interface-id", o.InterfaceID, "Interface ID where to create NAT.")
+	flag.AddrVar(fs, &o.InterfaceIP, "interface-ip", o.InterfaceIP, "IPv4 or IPv6 address of the interface where to create NAT, instead of its ID.")
 	fs.Uint32Var(&o.MinPort, "minport", o.MinPort, "MinPort of NAT.")
 	fs.Uint32Var(&o.MaxPort, "maxport", o.MaxPort, "MaxPort of NAT.")
 	flag.AddrVar(fs, &o.NatIP, "nat-ip", o.NatIP, "NAT IP to assign to the interface.")
+	fs.Uint32Var(&o.PortCount, "nat-port-count", o.PortCount, "Number of ports to allocate instead of specifying minport/maxport.")
+	fs.Uint32Var(&o.PortAlign, "nat-port-align", 1, "Alignment (power of two) of the first port allocated with --nat-port-count.")
+	o.PrintOptions.AddFlags(fs)
 }
 
 func (o *CreateNatOptions) MarkRequiredFlags(cmd *cobra.Command) error {
-	for _, name := range []string{"interface-id", "minport", "maxport", "nat-ip"} {
+	for _, name := range []string{"nat-ip"} {
 		if err := cmd.MarkFlagRequired(name); err != nil {
 			return err
 		}
@@ -68,6 +84,83 @@ func (o *CreateNatOptions) MarkRequiredFlags(cmd *cobra.Command) error {
 	return nil
 }
 
+func (o *CreateNatOptions) Validate(cmd *cobra.Command) error {
+	fs := cmd.Flags()
+	explicit := fs.Changed("minport") || fs.Changed("maxport")
+	allocated := fs.Changed("nat-port-count") || fs.Changed("nat-port-align")
+	switch {
+	case explicit && allocated:
+		return fmt.Errorf("--minport/--maxport cannot be combined with --nat-port-count/--nat-port-align")
+	case allocated:
+		if o.PortCount == 0 || o.PortCount > natPortRangeEnd-natPortRangeStart {
+			return fmt.Errorf("--nat-port-count must be between 1 and %d", natPortRangeEnd-natPortRangeStart)
+		}
+		if o.PortAlign == 0 || o.PortAlign&(o.PortAlign-1) != 0 {
+			return fmt.Errorf("--nat-port-align must be a power of two, got %d", o.PortAlign)
+		}
+	case !fs.Changed("minport") || !fs.Changed("maxport"):
+		return fmt.Errorf("either --minport and --maxport or --nat-port-count must be specified")
+	}
+	return o.PrintOptions.Validate()
+}
+
+const (
+	// natPortRangeStart is the first port considered when allocating NAT ports.
+	natPortRangeStart = 1024
+	// natPortRangeEnd is the exclusive upper bound of NAT ports.
+	natPortRangeEnd = 65536
+)
+
+// allocateNatPorts returns the lowest range of count ports starting at a multiple of align
+// that does not overlap any of the used NAT port ranges. The returned max port is exclusive.
+func allocateNatPorts(used []api.Nat, count, align uint32) (uint32, uint32, error) {
+	sort.Slice(used, func(i, j int) bool {
+		return used[i].Spec.MinPort < used[j].Spec.MinPort
+	})
+
+	alignUp := func(port uint32) uint32 {
+		return (port + align - 1) / align * align
+	}
+
+	start := alignUp(natPortRangeStart)
+	for _, nat := range used {
+		if start+count <= nat.Spec.MinPort {
+			break
+		}
+		if nat.Spec.MaxPort > start {
+			start = alignUp(nat.Spec.MaxPort)
+		}
+	}
+	if start+count > natPortRangeEnd {
+		return 0, 0, fmt.Errorf("no free range of %d ports aligned to %d left", count, align)
+	}
+	return start, start + count, nil
+}
+
+// InterfaceIDByIP returns the ID of the only interface with ip as IPv4 or IPv6 address.
+func InterfaceIDByIP(ctx context.Context, client client.Client, ip netip.Addr) (string, error) {
+	ifaces, err := client.ListInterfaces(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error listing interfaces: %w", err)
+	}
+
+	var ids []string
+	for _, iface := range ifaces.Items {
+		if (iface.Spec.IPv4 != nil && *iface.Spec.IPv4 == ip) || (iface.Spec.IPv6 != nil && *iface.Spec.IPv6 == ip) {
+			ids = append(ids, iface.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no interface has ip %s", ip)
+	case 1:
+		return ids[0], nil
+	default:
+		sort.Strings(ids)
+		return "", fmt.Errorf("ip %s is ambiguous, interfaces: %s", ip, strings.Join(ids, ", "))
+	}
+}
+
 func RunCreateNat(ctx context.Context, dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory, opts CreateNatOptions) error {
 	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
 	if err != nil {
@@ -75,6 +168,24 @@ func RunCreateNat(ctx context.Context, dpdkClientFactory DPDKClientFactory, rend
 	}
 	defer DpdkClose(cleanup)
 
+	if opts.InterfaceIP.IsValid() {
+		opts.InterfaceID, err = InterfaceIDByIP(ctx, client, opts.InterfaceIP)
+		if err != nil {
+			return fmt.Errorf("error resolving --interface-ip: %w", err)
+		}
+	}
+
+	if opts.PortCount != 0 {
+		natList, err := client.ListNats(ctx, &opts.NatIP, "0")
+		if err != nil {
+			return fmt.Errorf("error listing nats of %s: %w", opts.NatIP, err)
+		}
+		opts.MinPort, opts.MaxPort, err = allocateNatPorts(natList.Items, opts.PortCount, opts.PortAlign)
+		if err != nil {
+			return fmt.Errorf("error allocating nat ports: %w", err)
+		}
+	}
+
 	nat, err := client.CreateNat(ctx, &api.Nat{
 		NatMeta: api.NatMeta{
 			InterfaceID: opts.InterfaceID,
@@ -89,5 +200,9 @@ func RunCreateNat(ctx context.Context, dpdkClientFactory DPDKClientFactory, rend
 		return fmt.Errorf("error creating nat: %w", err)
 	}
 
-	return rendererFactory.RenderObject(fmt.Sprintf("created, underlay route: %s", nat.Spec.UnderlayRoute), os.Stdout, nat)
+	operation := fmt.Sprintf("created, underlay route: %s", nat.Spec.UnderlayRoute)
+	if opts.PortCount != 0 {
+		operation = fmt.Sprintf("created, ports: <%d, %d>, underlay route: %s", opts.MinPort, opts.MaxPort, nat.Spec.UnderlayRoute)
+	}
+	return opts.Render(os.Stdout, rendererFactory, operation, nat, nat.Spec.UnderlayRoute)
 }
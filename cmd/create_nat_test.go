@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// natClient has interfaces and NATs and records the interfaces and port ranges NATs are created with.
+type natClient struct {
+	client.Client
+	ifaces  []api.Interface
+	nats    []api.Nat
+	created []string
+	ports   [][2]uint32
+}
+
+func (c *natClient) ListInterfaces(ctx context.Context, ignoredErrors ...[]uint32) (*api.InterfaceList, error) {
+	return &api.InterfaceList{Items: c.ifaces}, nil
+}
+
+func (c *natClient) CreateNat(ctx context.Context, nat *api.Nat, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	c.created = append(c.created, nat.InterfaceID)
+	c.ports = append(c.ports, [2]uint32{nat.Spec.MinPort, nat.Spec.MaxPort})
+	return nat, nil
+}
+
+func (c *natClient) ListNats(ctx context.Context, natIP *netip.Addr, natType string, ignoredErrors ...[]uint32) (*api.NatList, error) {
+	return &api.NatList{Items: c.nats}, nil
+}
+
+var _ = Describe("CreateNat", func() {
+	var c *natClient
+
+	BeforeEach(func() {
+		newInterface := func(id, ipv4, ipv6 string) api.Interface {
+			v4, v6 := netip.MustParseAddr(ipv4), netip.MustParseAddr(ipv6)
+			return api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}, Spec: api.InterfaceSpec{IPv4: &v4, IPv6: &v6}}
+		}
+		c = &natClient{ifaces: []api.Interface{
+			newInterface("vm1", "10.200.1.4", "2000::1"),
+			newInterface("vm2", "10.200.1.5", "2000::2"),
+			newInterface("vm3", "10.200.1.5", "2000::3"),
+		}}
+	})
+
+	execute := func(ctx context.Context, args ...string) error {
+		cmd := CreateNat(fakeClientFactory{c}, &RendererOptions{Output: "name"})
+		cmd.SetArgs(append([]string{"--nat-ip=10.20.30.40"}, args...))
+		cmd.SilenceUsage = true
+		return cmd.ExecuteContext(ctx)
+	}
+	run := func(ctx context.Context, args ...string) error {
+		return execute(ctx, append([]string{"--minport=30000", "--maxport=30100"}, args...)...)
+	}
+	usedPorts := func(ranges ...[2]uint32) []api.Nat {
+		var nats []api.Nat
+		for _, r := range ranges {
+			nats = append(nats, api.Nat{Spec: api.NatSpec{MinPort: r[0], MaxPort: r[1]}})
+		}
+		return nats
+	}
+
+	It("should create the nat on the interface with the given IPv4 or IPv6 address", func(ctx SpecContext) {
+		Expect(run(ctx, "--interface-ip=10.200.1.4")).To(Succeed())
+		Expect(run(ctx, "--interface-ip=2000::3")).To(Succeed())
+		Expect(c.created).To(Equal([]string{"vm1", "vm3"}))
+	})
+
+	It("should fail if no or several interfaces have the address", func(ctx SpecContext) {
+		Expect(run(ctx, "--interface-ip=10.200.1.6")).To(MatchError(ContainSubstring("no interface has ip 10.200.1.6")))
+		Expect(run(ctx, "--interface-ip=10.200.1.5")).To(MatchError(ContainSubstring("ip 10.200.1.5 is ambiguous, interfaces: vm2, vm3")))
+		Expect(c.created).To(BeEmpty())
+	})
+
+	It("should reject --interface-id together with --interface-ip", func(ctx SpecContext) {
+		Expect(run(ctx, "--interface-id=vm1", "--interface-ip=10.200.1.4")).To(MatchError(ContainSubstring("none of the others can be")))
+		Expect(run(ctx)).To(MatchError(ContainSubstring("at least one of the flags in the group [interface-id interface-ip] is required")))
+	})
+
+	It("should allocate the first aligned port range if no ports are used", func(ctx SpecContext) {
+		Expect(execute(ctx, "--interface-id=vm1", "--nat-port-count=64", "--nat-port-align=64")).To(Succeed())
+		Expect(c.ports).To(Equal([][2]uint32{{1024, 1088}}))
+	})
+
+	It("should allocate the lowest aligned range after the used ranges in port order", func(ctx SpecContext) {
+		c.nats = usedPorts([2]uint32{1100, 1200}, [2]uint32{1024, 1088})
+		Expect(execute(ctx, "--interface-id=vm1", "--nat-port-count=64", "--nat-port-align=64")).To(Succeed())
+		Expect(c.ports).To(Equal([][2]uint32{{1216, 1280}}))
+	})
+
+	It("should allocate a range that exactly fills a gap between used ranges", func(ctx SpecContext) {
+		c.nats = usedPorts([2]uint32{1024, 1088}, [2]uint32{2048, 3000})
+		Expect(execute(ctx, "--interface-id=vm1", "--nat-port-count=512", "--nat-port-align=512")).To(Succeed())
+		Expect(c.ports).To(Equal([][2]uint32{{1536, 2048}}))
+	})
+
+	It("should fail without creating the nat if no aligned range is free", func(ctx SpecContext) {
+		c.nats = usedPorts([2]uint32{1024, 65500})
+		err := execute(ctx, "--interface-id=vm1", "--nat-port-count=64", "--nat-port-align=64")
+		Expect(err).To(MatchError(ContainSubstring("no free range of 64 ports aligned to 64 left")))
+		Expect(c.created).To(BeEmpty())
+	})
+
+	It("should reject invalid port allocation flags", func(ctx SpecContext) {
+		Expect(execute(ctx, "--interface-id=vm1", "--nat-port-count=64", "--nat-port-align=48")).To(MatchError("--nat-port-align must be a power of two, got 48"))
+		Expect(execute(ctx, "--interface-id=vm1", "--nat-port-count=0")).To(MatchError("--nat-port-count must be between 1 and 64512"))
+		Expect(run(ctx, "--interface-id=vm1", "--nat-port-count=64")).To(MatchError("--minport/--maxport cannot be combined with --nat-port-count/--nat-port-align"))
+		Expect(execute(ctx, "--interface-id=vm1")).To(MatchError("either --minport and --maxport or --nat-port-count must be specified"))
+		Expect(c.created).To(BeEmpty())
+	})
+})
@@ -6,12 +6,16 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/netip"
 	"os"
 
+	"github.com/ironcore-dev/dpservice-cli/diff"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
 	"github.com/ironcore-dev/dpservice-cli/flag"
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -22,12 +26,15 @@ func CreateRoute(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFa
 	)
 
 	cmd := &cobra.Command{
-		Use:     "route <--prefix> <--next-hop-vni> <--next-hop-ip> <--vni>",
-		Short:   "Create a route",
-		Example: "dpservice-cli create route --prefix=10.100.3.0/24 --next-hop-vni=0 --next-hop-ip=fc00:2::64:0:1 --vni=100",
+		Use:     "route <--prefix> <--next-hop-vni> <--next-hop-ip> <--vni> | <--routes-file>",
+		Short:   "Create a route or import routes from a routing-table file",
+		Example: "dpservice-cli create route --prefix=10.100.3.0/24 --next-hop-vni=0 --next-hop-ip=fc00:2::64:0:1 --vni=100\ndpservice-cli create routes --routes-file=static.conf --vni=100",
 		Aliases: RouteAliases,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Complete(cmd.Flags(), cmd.InOrStdin()); err != nil {
+				return err
+			}
 
 			return RunCreateRoute(
 				cmd.Context(),
@@ -46,10 +53,16 @@ func CreateRoute(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFa
 }
 
 type CreateRouteOptions struct {
-	Prefix     netip.Prefix
-	NextHopVNI uint32
-	NextHopIP  netip.Addr
-	VNI        uint32
+	Prefix         netip.Prefix
+	NextHopVNI     uint32
+	NextHopIP      netip.Addr
+	VNI            uint32
+	ReadAfterWrite bool
+	RoutesFile     string
+	RoutesFormat   string
+	RouteTagOptions
+	// Routes are the routes read from --routes-file by Complete.
+	Routes []api.Route
 }
 
 func (o *CreateRouteOptions) AddFlags(fs *pflag.FlagSet) {
@@ -57,14 +70,72 @@ func (o *CreateRouteOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.Uint32Var(&o.NextHopVNI, "next-hop-vni", o.NextHopVNI, "Next hop VNI for the route.")
 	flag.AddrVar(fs, &o.NextHopIP, "next-hop-ip", o.NextHopIP, "Next hop IP for the route.")
 	fs.Uint32Var(&o.VNI, "vni", o.VNI, "Source VNI for the route.")
+	o.RouteTagOptions.AddFlags(fs, "Record the route under this tag, so that it can be deleted with 'delete routes --tag'.")
+	fs.BoolVar(&o.ReadAfterWrite, "read-after-write", o.ReadAfterWrite, "List the routes after creating the route and show it as stored by dpservice, warning about differences to the request.")
+	fs.StringVar(&o.RoutesFile, "routes-file", o.RoutesFile, "File with routes to create, one '[route] <prefix> via <next-hop-ip> [vni <vni>] [next-hop-vni <vni>] [;]' per line ('-' for stdin). --vni and --next-hop-vni are the defaults for routes without them.")
+	fs.StringVar(&o.RoutesFormat, "routes-format", RoutesFormatBird, fmt.Sprintf("Format of --routes-file: [%s].", RoutesFormatBird))
 }
 
 func (o *CreateRouteOptions) MarkRequiredFlags(cmd *cobra.Command) error {
-	for _, name := range []string{"prefix", "next-hop-vni", "next-hop-ip", "vni"} {
-		if err := cmd.MarkFlagRequired(name); err != nil {
-			return err
+	return nil
+}
+
+// Complete checks the flags and reads the routes of --routes-file, reading stdin from in.
+func (o *CreateRouteOptions) Complete(fs *pflag.FlagSet, in io.Reader) error {
+	o.Routes = nil
+	if o.RoutesFile == "" {
+		for _, name := range []string{"prefix", "next-hop-vni", "next-hop-ip", "vni"} {
+			if !fs.Changed(name) {
+				return fmt.Errorf("either --prefix, --next-hop-vni, --next-hop-ip and --vni or --routes-file must be specified")
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case fs.Changed("prefix") || fs.Changed("next-hop-ip"):
+		return fmt.Errorf("--routes-file cannot be combined with --prefix/--next-hop-ip")
+	case o.ReadAfterWrite:
+		return fmt.Errorf("--routes-file cannot be combined with --read-after-write")
+	case o.RoutesFormat != RoutesFormatBird:
+		return fmt.Errorf("unsupported --routes-format %q, supported: %s", o.RoutesFormat, RoutesFormatBird)
+	}
+
+	if o.RoutesFile != "-" {
+		f, err := os.Open(o.RoutesFile)
+		if err != nil {
+			return fmt.Errorf("error opening routes file: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var vni *uint32
+	if fs.Changed("vni") {
+		vni = &o.VNI
+	}
+	routes, err := ReadBirdRoutes(in, vni, o.NextHopVNI)
+	if err != nil {
+		return fmt.Errorf("error reading routes file: %w", err)
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("no routes found in %s", o.RoutesFile)
+	}
+
+	// the vni attributes of the file are not covered by the validation of the --vni flags
+	maxVNI := DefaultMaxVNI
+	if v, err := fs.GetUint32("max-vni"); err == nil {
+		maxVNI = v
+	}
+	for _, route := range routes {
+		if err := ValidateVNI(route.VNI, maxVNI); err != nil {
+			return fmt.Errorf("invalid route %s: %w", route.Spec.Prefix, err)
+		}
+		if err := ValidateVNI(route.Spec.NextHop.VNI, maxVNI); err != nil {
+			return fmt.Errorf("invalid route %s: next hop %w", route.Spec.Prefix, err)
 		}
 	}
+	o.Routes = routes
 	return nil
 }
 
@@ -80,6 +151,10 @@ func RunCreateRoute(
 	}
 	defer DpdkClose(cleanup)
 
+	if opts.RoutesFile != "" {
+		return createRoutes(ctx, client, rendererFactory, opts)
+	}
+
 	route, err := client.CreateRoute(ctx, &api.Route{
 		RouteMeta: api.RouteMeta{
 			VNI: opts.VNI,
@@ -94,5 +169,87 @@ func RunCreateRoute(
 		return fmt.Errorf("error creating route: %w", err)
 	}
 
+	if err == nil && opts.ReadAfterWrite {
+		stored, err := findRoute(ctx, client, opts.VNI, opts.Prefix, &api.RouteNextHop{VNI: opts.NextHopVNI, IP: &opts.NextHopIP})
+		if err != nil {
+			return fmt.Errorf("error reading back route: %w", err)
+		}
+
+		changes, err := diff.Objects(route.Spec, stored.Spec)
+		if err != nil {
+			return fmt.Errorf("error comparing route: %w", err)
+		}
+		for _, change := range changes {
+			fmt.Fprintf(os.Stderr, "Warning: stored route differs from request: %s\n", change)
+		}
+		route = stored
+	}
+
+	if err == nil && opts.Tag != "" {
+		store, err := opts.Store()
+		if err != nil {
+			return err
+		}
+		if err := store.Add(opts.Tag, TaggedRoute{
+			VNI:        opts.VNI,
+			Prefix:     opts.Prefix,
+			NextHopVNI: opts.NextHopVNI,
+			NextHopIP:  opts.NextHopIP,
+		}); err != nil {
+			return fmt.Errorf("error tagging route: %w", err)
+		}
+	}
+
 	return rendererFactory.RenderObject(fmt.Sprintf("created, Next Hop IP: %s", opts.NextHopIP), os.Stdout, route)
 }
+
+// createRoutes creates the routes read from --routes-file, continuing after failures, and
+// records the created ones under --tag.
+func createRoutes(ctx context.Context, c client.Client, rendererFactory RendererFactory, opts CreateRouteOptions) error {
+	var store *RouteTagStore
+	if opts.Tag != "" {
+		var err error
+		if store, err = opts.Store(); err != nil {
+			return err
+		}
+	}
+
+	dc := dynamic.NewFromStructured(c)
+	objs := make([]any, len(opts.Routes))
+	for i := range opts.Routes {
+		objs[i] = &opts.Routes[i]
+	}
+	return RunBatch(ctx, os.Stdout, rendererFactory, "create", objs, func(ctx context.Context, obj any) (any, error) {
+		// the route is tagged as requested, dc.Create replaces obj with the route dpservice returned
+		requested := *obj.(*api.Route)
+		res, err := dc.Create(ctx, obj)
+		if err != nil || store == nil {
+			return res, err
+		}
+		if err := store.Add(opts.Tag, TaggedRoute{
+			VNI:        requested.VNI,
+			Prefix:     *requested.Spec.Prefix,
+			NextHopVNI: requested.Spec.NextHop.VNI,
+			NextHopIP:  *requested.Spec.NextHop.IP,
+		}); err != nil {
+			return res, fmt.Errorf("error tagging route: %w", err)
+		}
+		return res, nil
+	})
+}
+
+// findRoute returns the route with the given prefix and next hop as listed by dpservice.
+func findRoute(ctx context.Context, client client.Client, vni uint32, prefix netip.Prefix, nextHop *api.RouteNextHop) (*api.Route, error) {
+	prefix = prefix.Masked()
+	res, err := dynamic.NewFromStructured(client).Get(ctx, &api.Route{
+		RouteMeta: api.RouteMeta{VNI: vni},
+		Spec:      api.RouteSpec{Prefix: &prefix, NextHop: nextHop},
+	})
+	if dynamic.IsNotFound(err) {
+		return nil, fmt.Errorf("route %s via %d:%s not found in vni %d", prefix, nextHop.VNI, nextHop.IP, vni)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listing routes: %w", err)
+	}
+	return res.(*api.Route), nil
+}
@@ -24,10 +24,13 @@ func CreateVirtualIP(dpdkClientFactory DPDKClientFactory, rendererFactory Render
 	cmd := &cobra.Command{
 		Use:     "virtualip <--vip> <--interface-id>",
 		Short:   "Create a virtual IP on interface.",
-		Example: "dpservice-cli create virtualip --vip=20.20.20.20 --interface-id=vm1",
+		Example: "dpservice-cli create virtualip --vip=20.20.20.20 --interface-id=vm1\ndpservice-cli create virtualip --vip=::ffff:20.20.20.20 --interface-id=vm1 --ip-version=ipv4 --force",
 		Aliases: VirtualIPAliases,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
 
 			return RunCreateVirtualIP(
 				cmd.Context(),
@@ -48,11 +51,32 @@ func CreateVirtualIP(dpdkClientFactory DPDKClientFactory, rendererFactory Render
 type CreateVirtualIPOptions struct {
 	Vip         netip.Addr
 	InterfaceID string
+	IPVersion   string
+	Force       bool
 }
 
 func (o *CreateVirtualIPOptions) AddFlags(fs *pflag.FlagSet) {
 	flag.AddrVar(fs, &o.Vip, "vip", o.Vip, "Virtual IP to create on interface.")
 	fs.StringVar(&o.InterfaceID, "interface-id", o.InterfaceID, "Interface ID where to create the virtual IP.")
+	fs.StringVar(&o.IPVersion, "ip-version", o.IPVersion, fmt.Sprintf("Send this IP version instead of the one detected from --vip: %s or %s.", IPVersion4, IPVersion6))
+	fs.BoolVar(&o.Force, "force", o.Force, "Send --ip-version even if it does not match --vip.")
+}
+
+func (o *CreateVirtualIPOptions) Validate() error {
+	if o.IPVersion == "" {
+		if o.Force {
+			return fmt.Errorf("--force requires --ip-version")
+		}
+		return nil
+	}
+	version, err := ParseIPVersion(o.IPVersion)
+	if err != nil {
+		return err
+	}
+	if !o.Force {
+		return CheckIPVersion(o.Vip, version)
+	}
+	return nil
 }
 
 func (o *CreateVirtualIPOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -76,6 +100,14 @@ func RunCreateVirtualIP(
 	}
 	defer DpdkClose(cleanup)
 
+	if opts.IPVersion != "" {
+		version, err := ParseIPVersion(opts.IPVersion)
+		if err != nil {
+			return err
+		}
+		ctx = WithIPVersionOverride(ctx, version)
+	}
+
 	virtualIP, err := client.CreateVirtualIP(ctx, &api.VirtualIP{
 		VirtualIPMeta: api.VirtualIPMeta{
 			InterfaceID: opts.InterfaceID,
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func Debug() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "debug [command]",
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		RunE:   SubcommandRequired,
+	}
+
+	subcommands := []*cobra.Command{
+		DebugRoundTrip(),
+	}
+
+	cmd.Short = fmt.Sprintf("Debugging helpers, one of %v", CommandNames(subcommands))
+	cmd.Long = fmt.Sprintf("Debugging helpers, one of %v", CommandNames(subcommands))
+
+	cmd.AddCommand(
+		subcommands...,
+	)
+
+	return cmd
+}
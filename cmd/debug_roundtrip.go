@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-cli/diff"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/runtime"
+	"github.com/ironcore-dev/dpservice-cli/sources"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/spf13/cobra"
+)
+
+func DebugRoundTrip() *cobra.Command {
+	sourcesOptions := &SourcesOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "roundtrip <kind> <-f>",
+		Short:   "Convert objects to their proto form and back and report fields lost or changed in conversion",
+		Example: "dpservice-cli debug roundtrip route -f route.yaml",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunDebugRoundTrip(cmd.Context(), os.Stdout, args[0], sourcesOptions)
+		},
+	}
+
+	sourcesOptions.AddFlags(cmd.Flags())
+
+	util.Must(cmd.MarkFlagRequired("filename"))
+
+	return cmd
+}
+
+func RunDebugRoundTrip(ctx context.Context, w io.Writer, kind string, sourcesReaderFactory SourcesReaderFactory) error {
+	iterator, err := sourcesReaderFactory.NewIterator()
+	if err != nil {
+		return fmt.Errorf("error creating sources iterator: %w", err)
+	}
+
+	objs, err := sources.CollectObjects(iterator, runtime.DefaultScheme)
+	if err != nil {
+		return fmt.Errorf("error collecting objects: %w", err)
+	}
+
+	var matched, lossy int
+	for i, obj := range objs {
+		objKind, err := runtime.DefaultScheme.KindFor(obj)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(objKind, kind) {
+			continue
+		}
+		matched++
+
+		res, err := conversion.RoundTrip(ctx, obj)
+		if err != nil {
+			fmt.Fprintf(w, "%s [object %d]: error converting: %v\n", objKind, i, err)
+			lossy++
+			continue
+		}
+
+		changes, err := diff.Objects(obj, res)
+		if err != nil {
+			return fmt.Errorf("error comparing %s: %w", objKind, err)
+		}
+		if len(changes) == 0 {
+			fmt.Fprintf(w, "%s [object %d]: no fields lost or changed in conversion\n", objKind, i)
+			continue
+		}
+
+		lossy++
+		fmt.Fprintf(w, "%s [object %d]: %d field(s) lost or changed in conversion\n", objKind, i, len(changes))
+		for _, change := range changes {
+			fmt.Fprintf(w, "  %s\n", change)
+		}
+	}
+
+	if matched == 0 {
+		return fmt.Errorf("no objects of kind %s found", kind)
+	}
+	if lossy > 0 {
+		return fmt.Errorf("conversion of %d object(s) lost or changed fields", lossy)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DebugRoundTrip", func() {
+	roundTrip := func(ctx SpecContext, kind, objects string) (string, error) {
+		filename := filepath.Join(GinkgoT().TempDir(), "objects.yaml")
+		Expect(os.WriteFile(filename, []byte(objects), 0o644)).To(Succeed())
+
+		var buf bytes.Buffer
+		err := RunDebugRoundTrip(ctx, &buf, kind, &SourcesOptions{Filename: []string{filename}})
+		return buf.String(), err
+	}
+
+	It("should report objects that convert without changes", func(ctx SpecContext) {
+		out, err := roundTrip(ctx, "route",
+			"kind: Route\nmetadata:\n  vni: 100\nspec:\n  prefix: 10.0.2.0/24\n  next_hop:\n    vni: 0\n    address: fc00::2\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("Route [object 0]: no fields lost or changed in conversion\n"))
+	})
+
+	It("should report the fields the requests of dpservice-go do not carry", func(ctx SpecContext) {
+		out, err := roundTrip(ctx, "interface",
+			"kind: Interface\nmetadata:\n  id: vm1\nspec:\n  vni: 100\n  device: net_tap5\n  primary_ipv4: 10.0.0.1\n  primary_ipv6: fc00::1\n  pxe:\n    next_server: 10.0.0.100\n    boot_filename: boot.ipxe\n")
+		Expect(err).To(MatchError("conversion of 1 object(s) lost or changed fields"))
+		Expect(out).To(ContainSubstring("- spec.pxe.boot_filename: boot.ipxe\n"))
+		Expect(out).To(ContainSubstring("- spec.pxe.next_server: 10.0.0.100\n"))
+	})
+
+	It("should report the spelling the firewall action is read back with", func(ctx SpecContext) {
+		out, err := roundTrip(ctx, "firewallrule",
+			"kind: FirewallRule\nmetadata:\n  interface_id: vm1\nspec:\n  id: fr1\n  direction: ingress\n  action: allow\n  priority: 1000\n  source_prefix: 0.0.0.0/0\n  destination_prefix: 10.0.0.0/24\n")
+		Expect(err).To(MatchError("conversion of 1 object(s) lost or changed fields"))
+		Expect(out).To(ContainSubstring("~ spec.action: allow -> Accept\n"))
+		Expect(out).To(ContainSubstring("~ spec.direction: ingress -> Ingress\n"))
+	})
+
+	It("should fail if no object is of the kind", func(ctx SpecContext) {
+		_, err := roundTrip(ctx, "nat",
+			"kind: Route\nmetadata:\n  vni: 100\nspec:\n  prefix: 10.0.2.0/24\n  next_hop:\n    vni: 0\n    address: fc00::2\n")
+		Expect(err).To(MatchError("no objects of kind nat found"))
+	})
+})
@@ -7,32 +7,36 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"reflect"
-	"strings"
+	"slices"
 
 	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
 	"github.com/ironcore-dev/dpservice-cli/dpdk/runtime"
 	"github.com/ironcore-dev/dpservice-cli/sources"
-	"github.com/ironcore-dev/dpservice-go/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func Delete(factory DPDKClientFactory) *cobra.Command {
+	var (
+		opts DeleteOptions
+	)
 	sourcesOptions := &SourcesOptions{}
 	rendererOptions := &RendererOptions{Output: "name"}
 
 	cmd := &cobra.Command{
-		Use:     "delete [command]",
-		Aliases: []string{"del"},
-		Args:    cobra.NoArgs,
+		Use:         "delete [command]",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Aliases:     []string{"del"},
+		Args:        cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
-			return RunDelete(ctx, factory, rendererOptions, sourcesOptions)
+			return RunDelete(ctx, factory, rendererOptions, sourcesOptions, opts)
 		},
 	}
 
 	rendererOptions.AddFlags(cmd.PersistentFlags())
 
+	opts.AddFlags(cmd.Flags())
 	sourcesOptions.AddFlags(cmd.Flags())
 
 	subcommands := []*cobra.Command{
@@ -56,11 +60,24 @@ func Delete(factory DPDKClientFactory) *cobra.Command {
 	return cmd
 }
 
+type DeleteOptions struct {
+	NoReverse      bool
+	IgnoreNotFound bool
+}
+
+func (o *DeleteOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.NoReverse, "no-reverse", o.NoReverse, "Delete the objects of -f in the order of the file instead of in reverse order.")
+	fs.BoolVar(&o.IgnoreNotFound, "ignore-not-found", o.IgnoreNotFound, "Treat objects of -f that do not exist as deleted.")
+}
+
+// RunDelete deletes the objects of the sources in reverse order, so that objects that
+// are listed after the objects they depend on are deleted first.
 func RunDelete(
 	ctx context.Context,
 	dpdkClientFactory DPDKClientFactory,
 	rendererFactory RendererFactory,
 	sourcesReaderFactory SourcesReaderFactory,
+	opts DeleteOptions,
 ) error {
 	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
 	if err != nil {
@@ -72,11 +89,6 @@ func RunDelete(
 		}
 	}()
 
-	renderer, err := rendererFactory.NewRenderer("deleted", os.Stdout)
-	if err != nil {
-		return fmt.Errorf("error creating renderer: %w", err)
-	}
-
 	dc := dynamic.NewFromStructured(client)
 
 	iterator, err := sourcesReaderFactory.NewIterator()
@@ -89,26 +101,15 @@ func RunDelete(
 		return fmt.Errorf("error collecting objects: %w", err)
 	}
 
-	for _, obj := range objs {
-		key := dynamic.ObjectKeyFromObject(obj)
+	if !opts.NoReverse {
+		slices.Reverse(objs)
+	}
 
+	return RunBatch(ctx, os.Stdout, rendererFactory, "delete", objs, func(ctx context.Context, obj any) (any, error) {
 		res, err := dc.Delete(ctx, obj)
-		if strings.Contains(err.Error(), errors.StatusErrorString) {
-			r := reflect.ValueOf(res)
-			err := reflect.Indirect(r).FieldByName("Status").FieldByName("Error")
-			msg := reflect.Indirect(r).FieldByName("Status").FieldByName("Message")
-			fmt.Printf("Error deleting %T %s: Server error: %v %v\n", res, key, err, msg)
-			continue
-		}
-		if err != nil {
-			fmt.Printf("Error deleting %T %s: %v\n", res, key, err)
-			continue
-		}
-
-		if err := renderer.Render(obj); err != nil {
-			return fmt.Errorf("error rendering %T: %w", obj, err)
+		if opts.IgnoreNotFound && dynamic.IsNotFound(err) {
+			return obj, nil
 		}
-	}
-
-	return nil
+		return res, err
+	})
 }
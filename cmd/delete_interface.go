@@ -9,6 +9,7 @@ import (
 	"os"
 
 	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -21,7 +22,7 @@ func DeleteInterface(dpdkClientFactory DPDKClientFactory, rendererFactory Render
 	cmd := &cobra.Command{
 		Use:     "interface <--id>",
 		Short:   "Delete interface",
-		Example: "dpservice-cli delete interface --id=vm1",
+		Example: "dpservice-cli delete interface --id=vm1 --wait",
 		Aliases: InterfaceAliases,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -44,10 +45,12 @@ func DeleteInterface(dpdkClientFactory DPDKClientFactory, rendererFactory Render
 
 type DeleteInterfaceOptions struct {
 	ID string
+	WaitOptions
 }
 
 func (o *DeleteInterfaceOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.ID, "id", o.ID, "Interface ID to delete.")
+	o.WaitOptions.AddFlags(fs)
 }
 
 func (o *DeleteInterfaceOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -70,6 +73,14 @@ func RunDeleteInterface(ctx context.Context, dpdkClientFactory DPDKClientFactory
 	if err != nil && iface.Status.Code == 0 {
 		return fmt.Errorf("error deleting interface: %w", err)
 	}
+	if err == nil && opts.Wait {
+		if err := opts.WaitForDeletion(ctx, api.InterfaceKind, opts.ID, waitPollInterval, func(ctx context.Context) error {
+			_, err := client.GetInterface(ctx, opts.ID)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
 
 	return rendererFactory.RenderObject("deleted", os.Stdout, iface)
 }
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
 	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -19,7 +21,7 @@ func DeleteNat(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFact
 	)
 
 	cmd := &cobra.Command{
-		Use:     "nat <--interface-id>",
+		Use:     "nat <--interface-id> [--ignore-not-found]",
 		Short:   "Delete nat from interface",
 		Example: "dpservice-cli delete nat --interface-id=vm1",
 		Aliases: NatAliases,
@@ -44,10 +46,12 @@ func DeleteNat(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFact
 
 type DeleteNatOptions struct {
 	InterfaceID string
+	NotFoundOptions
 }
 
 func (o *DeleteNatOptions) AddFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&o.InterfaceID, "interface-id", o.InterfaceID, "Interface ID of the Virtual IP.")
+	fs.StringVar(&o.InterfaceID, "interface-id", o.InterfaceID, "Interface ID of the NAT.")
+	o.NotFoundOptions.AddFlags(fs)
 }
 
 func (o *DeleteNatOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -67,6 +71,9 @@ func RunDeleteNat(ctx context.Context, dpdkClientFactory DPDKClientFactory, rend
 	defer DpdkClose(cleanup)
 
 	nat, err := client.DeleteNat(ctx, opts.InterfaceID)
+	if dynamic.IsNotFound(err) {
+		return opts.NotFound(api.NatKind, opts.InterfaceID)
+	}
 	if err != nil && nat.Status.Code == 0 {
 		return fmt.Errorf("error deleting nat: %w", err)
 	}
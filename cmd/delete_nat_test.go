@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"slices"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func (c *natClient) DeleteNat(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	if i := slices.Index(c.created, interfaceID); i >= 0 {
+		c.created = slices.Delete(c.created, i, i+1)
+		return &api.Nat{TypeMeta: api.TypeMeta{Kind: api.NatKind}, NatMeta: api.NatMeta{InterfaceID: interfaceID}}, nil
+	}
+	return &api.Nat{Status: api.Status{Code: apierrors.SNAT_NO_DATA}}, apierrors.NewStatusError(apierrors.SNAT_NO_DATA, "SNAT_NO_DATA")
+}
+
+var _ = Describe("DeleteNat", func() {
+	It("should delete the nat of the interface", func(ctx SpecContext) {
+		c := &natClient{created: []string{"vm1"}}
+		Expect(RunDeleteNat(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, DeleteNatOptions{InterfaceID: "vm1"})).To(Succeed())
+		Expect(c.created).To(BeEmpty())
+	})
+
+	It("should report a missing nat as not found", func(ctx SpecContext) {
+		c := &natClient{}
+		err := RunDeleteNat(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, DeleteNatOptions{InterfaceID: "vm1"})
+		Expect(err).To(MatchError("Nat vm1 not found"))
+		Expect(ExitCode(err)).To(Equal(ExitCodeNotFound))
+
+		opts := DeleteNatOptions{InterfaceID: "vm1", NotFoundOptions: NotFoundOptions{IgnoreNotFound: true}}
+		Expect(RunDeleteNat(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, opts)).To(Succeed())
+	})
+})
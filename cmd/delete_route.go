@@ -11,6 +11,7 @@ import (
 
 	"github.com/ironcore-dev/dpservice-cli/flag"
 	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -21,12 +22,15 @@ func DeleteRoute(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFa
 	)
 
 	cmd := &cobra.Command{
-		Use:     "route <--prefix> <--vni>",
-		Short:   "Delete a route",
-		Example: "dpservice-cli delete route --prefix=10.100.2.0/24 --vni=100",
+		Use:     "route <--prefix> <--vni> | <--tag>",
+		Short:   "Delete a route or all routes with a tag",
+		Example: "dpservice-cli delete route --prefix=10.100.2.0/24 --vni=100\ndpservice-cli delete routes --tag=mytag",
 		Aliases: RouteAliases,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(cmd); err != nil {
+				return err
+			}
 
 			return RunDeleteRoute(
 				cmd.Context(),
@@ -47,18 +51,27 @@ func DeleteRoute(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFa
 type DeleteRouteOptions struct {
 	Prefix netip.Prefix
 	VNI    uint32
+	RouteTagOptions
 }
 
 func (o *DeleteRouteOptions) AddFlags(fs *pflag.FlagSet) {
 	flag.PrefixVar(fs, &o.Prefix, "prefix", o.Prefix, "Prefix of the route.")
 	fs.Uint32Var(&o.VNI, "vni", o.VNI, "VNI of the route.")
+	o.RouteTagOptions.AddFlags(fs, "Delete exactly the routes recorded under this tag by 'create route --tag'.")
 }
 
 func (o *DeleteRouteOptions) MarkRequiredFlags(cmd *cobra.Command) error {
-	for _, name := range []string{"prefix", "vni"} {
-		if err := cmd.MarkFlagRequired(name); err != nil {
-			return err
-		}
+	return nil
+}
+
+func (o *DeleteRouteOptions) Validate(cmd *cobra.Command) error {
+	fs := cmd.Flags()
+	keyed := fs.Changed("prefix") || fs.Changed("vni")
+	switch {
+	case o.Tag != "" && keyed:
+		return fmt.Errorf("--tag cannot be combined with --prefix/--vni")
+	case o.Tag == "" && (!fs.Changed("prefix") || !fs.Changed("vni")):
+		return fmt.Errorf("either --prefix and --vni or --tag must be specified")
 	}
 	return nil
 }
@@ -70,6 +83,10 @@ func RunDeleteRoute(ctx context.Context, dpdkClientFactory DPDKClientFactory, re
 	}
 	defer DpdkClose(cleanup)
 
+	if opts.Tag != "" {
+		return deleteTaggedRoutes(ctx, client, rendererFactory, opts.RouteTagOptions)
+	}
+
 	route, err := client.DeleteRoute(ctx, opts.VNI, &opts.Prefix)
 	if err != nil && route.Status.Code == 0 {
 		return fmt.Errorf("error deleting route: %w", err)
@@ -77,3 +94,31 @@ func RunDeleteRoute(ctx context.Context, dpdkClientFactory DPDKClientFactory, re
 
 	return rendererFactory.RenderObject("deleted", os.Stdout, route)
 }
+
+func deleteTaggedRoutes(ctx context.Context, client client.Client, rendererFactory RendererFactory, opts RouteTagOptions) error {
+	store, err := opts.Store()
+	if err != nil {
+		return err
+	}
+	routes, err := store.Get(opts.Tag)
+	if err != nil {
+		return err
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("no routes recorded under tag %q in %s", opts.Tag, store.Path)
+	}
+
+	for _, tagged := range routes {
+		route, err := client.DeleteRoute(ctx, tagged.VNI, &tagged.Prefix)
+		if err != nil && route.Status.Code == 0 {
+			return fmt.Errorf("error deleting route: %w", err)
+		}
+		if err := rendererFactory.RenderObject("deleted", os.Stdout, route); err != nil {
+			return err
+		}
+		if err := store.Remove(opts.Tag, tagged); err != nil {
+			return fmt.Errorf("error untagging route: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunDelete", func() {
+	var filename string
+
+	BeforeEach(func() {
+		filename = filepath.Join(GinkgoT().TempDir(), "objects.yaml")
+		Expect(os.WriteFile(filename, []byte(
+			"kind: Interface\nmetadata:\n  id: vm1\nspec:\n  vni: 100\n  device: net_tap5\n"+
+				"---\n"+
+				"kind: Prefix\nmetadata:\n  interface_id: vm1\nspec:\n  prefix: 10.0.0.0/24\n",
+		), 0o644)).To(Succeed())
+	})
+
+	It("should delete the objects in reverse order of the file", func(ctx SpecContext) {
+		c := &replaceClient{iface: true, prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")}}
+		Expect(RunDelete(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, &SourcesOptions{Filename: []string{filename}}, DeleteOptions{})).To(Succeed())
+		Expect(c.calls).To(Equal([]string{"delete prefix/10.0.0.0/24", "delete interface/vm1"}))
+	})
+
+	It("should keep the order of the file with --no-reverse", func(ctx SpecContext) {
+		c := &replaceClient{iface: true, prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")}}
+		Expect(RunDelete(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, &SourcesOptions{Filename: []string{filename}}, DeleteOptions{NoReverse: true})).To(Succeed())
+		Expect(c.calls).To(Equal([]string{"delete interface/vm1", "delete prefix/10.0.0.0/24"}))
+	})
+
+	It("should treat objects that do not exist as deleted with --ignore-not-found", func(ctx SpecContext) {
+		Expect(os.WriteFile(filename, []byte("kind: Nat\nmetadata:\n  interface_id: vm1\nspec:\n  nat_ip: 10.20.30.40\n  min_port: 30000\n  max_port: 30100\n"), 0o644)).To(Succeed())
+
+		stdout := captureOutput(&os.Stdout)
+
+		sources := &SourcesOptions{Filename: []string{filename}}
+		Expect(RunDelete(ctx, fakeClientFactory{&natClient{}}, &RendererOptions{Output: "name"}, sources, DeleteOptions{})).To(Succeed())
+		Expect(RunDelete(ctx, fakeClientFactory{&natClient{}}, &RendererOptions{Output: "name"}, sources, DeleteOptions{IgnoreNotFound: true})).To(Succeed())
+		out, err := os.ReadFile(stdout)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Split(string(out), "\n")).To(ConsistOf(
+			HavePrefix("Error: failed to delete Nat vm1: server error"),
+			"nat/10.20.30.40:30000-30100 deleted",
+			"",
+		))
+	})
+})
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/ironcore-dev/dpservice-cli/diff"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/runtime"
+	"github.com/ironcore-dev/dpservice-cli/sources"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func Diff() *cobra.Command {
+	var (
+		opts DiffOptions
+	)
+	sourcesOptions := &SourcesOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff <-f a.yaml -f b.yaml | --file-a a.yaml --file-b b.yaml>",
+		Short: "Compare the objects of two apply or export files without contacting dpservice",
+		Long: "Compare the objects of two apply or export files without contacting dpservice.\n" +
+			"Objects are normalized before comparing them, objects only in the first file are shown as removed,\n" +
+			"objects only in the second file as added.",
+		Example: "dpservice-cli diff -f backup.yaml -f proposed.yaml",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(sourcesOptions.Filename) > 0 {
+				if len(sourcesOptions.Filename) != 2 {
+					return fmt.Errorf("-f must be given exactly twice, got %d files", len(sourcesOptions.Filename))
+				}
+				opts.FileA, opts.FileB = sourcesOptions.Filename[0], sourcesOptions.Filename[1]
+			}
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			return RunDiff(os.Stdout, opts)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+	sourcesOptions.AddFlags(cmd.Flags())
+
+	cmd.MarkFlagsMutuallyExclusive("filename", "file-a")
+	cmd.MarkFlagsMutuallyExclusive("filename", "file-b")
+
+	return cmd
+}
+
+type DiffOptions struct {
+	FileA string
+	FileB string
+}
+
+func (o *DiffOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.FileA, "file-a", o.FileA, "File with the old objects.")
+	fs.StringVar(&o.FileB, "file-b", o.FileB, "File with the new objects.")
+}
+
+func (o *DiffOptions) Validate() error {
+	if o.FileA == "" || o.FileB == "" {
+		return fmt.Errorf("two files are required, use -f twice or --file-a and --file-b")
+	}
+	return nil
+}
+
+// diffObject is a normalized object of a diffed file.
+type diffObject struct {
+	name string
+	doc  map[string]any
+}
+
+// RunDiff prints the objects added, removed and changed from the file a to the file b,
+// ordered by kind and name.
+func RunDiff(w io.Writer, opts DiffOptions) error {
+	a, err := collectDiffObjects(opts.FileA)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", opts.FileA, err)
+	}
+	b, err := collectDiffObjects(opts.FileB)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", opts.FileB, err)
+	}
+
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var added, removed, changed, unchanged int
+	for _, key := range keys {
+		oldObj, inA := a[key]
+		newObj, inB := b[key]
+		switch {
+		case !inB:
+			removed++
+			fmt.Fprintf(w, "- removed %s\n", oldObj.name)
+		case !inA:
+			added++
+			fmt.Fprintf(w, "+ added %s\n", newObj.name)
+		default:
+			changes, err := diff.Objects(oldObj.doc, newObj.doc)
+			if err != nil {
+				return fmt.Errorf("error comparing %s: %w", newObj.name, err)
+			}
+			if len(changes) == 0 {
+				unchanged++
+				continue
+			}
+			changed++
+			fmt.Fprintf(w, "~ changed %s\n", newObj.name)
+			for _, change := range changes {
+				fmt.Fprintf(w, "    %s\n", change)
+			}
+		}
+	}
+	fmt.Fprintf(w, "\nDiff: %d added, %d removed, %d changed, %d unchanged.\n", added, removed, changed, unchanged)
+	return nil
+}
+
+// collectDiffObjects decodes and normalizes the objects of filename, keyed by kind and identity.
+func collectDiffObjects(filename string) (map[string]diffObject, error) {
+	objs, err := sources.CollectObjects(sources.NewIterator([]string{filename}), runtime.DefaultScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]diffObject, len(objs))
+	for _, obj := range objs {
+		// normalized first, so that e.g. unmasked prefixes identify the same object
+		doc, err := NormalizeObject(obj)
+		if err != nil {
+			return nil, fmt.Errorf("error normalizing %s: %w", planName(obj), err)
+		}
+		key := replaceKey(obj)
+		if _, ok := res[key]; ok {
+			return nil, fmt.Errorf("duplicate object %s", planName(obj))
+		}
+		res[key] = diffObject{name: planName(obj), doc: doc}
+	}
+	return res, nil
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	var fileA, fileB string
+
+	BeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		fileA = filepath.Join(dir, "a.yaml")
+		fileB = filepath.Join(dir, "b.yaml")
+		Expect(os.WriteFile(fileA, []byte(
+			"kind: Interface\nmetadata:\n  id: vm1\nspec:\n  vni: 100\n  device: net_tap5\n"+
+				"---\n"+
+				"kind: Prefix\nmetadata:\n  interface_id: vm1\nspec:\n  prefix: 10.0.0.1/24\n"+
+				"---\n"+
+				"kind: Interface\nmetadata:\n  id: vm2\nspec:\n  vni: 100\n  device: net_tap6\n",
+		), 0o644)).To(Succeed())
+		Expect(os.WriteFile(fileB, []byte(
+			"kind: Interface\nmetadata:\n  id: vm1\nspec:\n  vni: 200\n  device: net_tap5\n"+
+				"---\n"+
+				"kind: Prefix\nmetadata:\n  interface_id: vm1\nspec:\n  prefix: 10.0.0.0/24\n"+
+				"---\n"+
+				"kind: Prefix\nmetadata:\n  interface_id: vm1\nspec:\n  prefix: 10.1.0.0/24\n",
+		), 0o644)).To(Succeed())
+	})
+
+	It("should print the added, removed and changed objects per kind", func() {
+		var buf bytes.Buffer
+		Expect(RunDiff(&buf, DiffOptions{FileA: fileA, FileB: fileB})).To(Succeed())
+		Expect(buf.String()).To(Equal(
+			"~ changed interface/vm1\n" +
+				"    ~ spec.vni: 100 -> 200\n" +
+				"- removed interface/vm2\n" +
+				"+ added prefix/10.1.0.0/24\n" +
+				"\nDiff: 1 added, 1 removed, 1 changed, 1 unchanged.\n",
+		))
+	})
+
+	It("should tell routes with the same prefix and different next hops apart", func() {
+		route := func(nextHop string) string {
+			return "kind: Route\nmetadata:\n  vni: 100\nspec:\n  prefix: 10.0.0.0/24\n  next_hop:\n    vni: 100\n    address: " + nextHop + "\n"
+		}
+		Expect(os.WriteFile(fileA, []byte(route("fc00::1")), 0o644)).To(Succeed())
+		Expect(os.WriteFile(fileB, []byte(route("fc00::1")+"---\n"+route("fc00::2")), 0o644)).To(Succeed())
+
+		var buf bytes.Buffer
+		Expect(RunDiff(&buf, DiffOptions{FileA: fileA, FileB: fileB})).To(Succeed())
+		Expect(buf.String()).To(Equal(
+			"+ added route/10.0.0.0/24-100:fc00::2\n" +
+				"\nDiff: 1 added, 0 removed, 0 changed, 1 unchanged.\n",
+		))
+	})
+
+	It("should fail on duplicate objects in one file", func() {
+		Expect(os.WriteFile(fileB, []byte(
+			"kind: Prefix\nmetadata:\n  interface_id: vm1\nspec:\n  prefix: 10.0.0.0/24\n"+
+				"---\n"+
+				"kind: Prefix\nmetadata:\n  interface_id: vm1\nspec:\n  prefix: 10.0.0.1/24\n",
+		), 0o644)).To(Succeed())
+
+		Expect(RunDiff(&bytes.Buffer{}, DiffOptions{FileA: fileA, FileB: fileB})).To(MatchError(ContainSubstring("duplicate object prefix/10.0.0.0/24")))
+	})
+
+	It("should require exactly two files", func(ctx SpecContext) {
+		cmd := Diff()
+		cmd.SetArgs([]string{"-f", fileA})
+		cmd.SilenceUsage = true
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("exactly twice")))
+	})
+})
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/spf13/cobra"
+)
+
+func Doctor(dpdkClientOptions *DPDKClientOptions) *cobra.Command {
+	var (
+		opts DoctorOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:     "doctor",
+		Short:   "Run connectivity and configuration diagnostics against dpservice",
+		Long:    "Run connectivity and configuration diagnostics against dpservice. No state is changed on dpservice.",
+		Example: "dpservice-cli doctor --address=localhost:1337",
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Timeout = dpdkClientOptions.Timeout
+			return RunDoctor(
+				cmd.Context(),
+				os.Stdout,
+				dpdkClientOptions,
+				opts,
+			)
+		},
+	}
+
+	util.Must(opts.MarkRequiredFlags(cmd))
+
+	return cmd
+}
+
+type DoctorOptions struct {
+	// Timeout is the overall timeout for all diagnostics, given by --timeout.
+	Timeout time.Duration
+}
+
+func (o *DoctorOptions) MarkRequiredFlags(cmd *cobra.Command) error {
+	return nil
+}
+
+var errDoctorCheckSkipped = errors.New("skipped")
+
+type doctorCheck struct {
+	name string
+	hint string
+	run  func(ctx context.Context) (string, error)
+}
+
+func RunDoctor(ctx context.Context, w io.Writer, dpdkClientOptions *DPDKClientOptions, opts DoctorOptions) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	// the client is shared between the checks and created by the gRPC handshake check
+	var (
+		dpdkClient client.Client
+		cleanup    func() error
+	)
+	defer func() {
+		if cleanup != nil {
+			DpdkClose(cleanup)
+		}
+	}()
+
+	// gRPC targets such as unix:// or dns:/// are resolved by gRPC and only checked through the client
+	resolverTarget := IsResolverTarget(dpdkClientOptions.Address)
+
+	checks := []doctorCheck{
+		{
+			name: "address",
+			hint: "check that --address has the form host:port or is a gRPC target such as unix:///path or dns:///host:port",
+			run: func(ctx context.Context) (string, error) {
+				if resolverTarget {
+					return fmt.Sprintf("dial target %s", dpdkClientOptions.Address), nil
+				}
+				host, port, err := net.SplitHostPort(dpdkClientOptions.Address)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("host %q, port %s, dial target %s", host, port, DialTarget(dpdkClientOptions.Address)), nil
+			},
+		},
+		{
+			name: "tcp",
+			hint: "check that dpservice is running and that the address is reachable (firewall, port forwarding)",
+			run: func(ctx context.Context) (string, error) {
+				if resolverTarget {
+					return "the address is a gRPC target, connectivity is checked by the grpc check", errDoctorCheckSkipped
+				}
+				var d net.Dialer
+				conn, err := d.DialContext(ctx, "tcp", dpdkClientOptions.Address)
+				if err != nil {
+					return "", err
+				}
+				defer conn.Close()
+				return fmt.Sprintf("connected to %s", conn.RemoteAddr()), nil
+			},
+		},
+		{
+			name: "tls",
+			hint: "check --tls-ca and --tls-server-name against the certificate of dpservice, and that dpservice serves TLS",
+			run: func(ctx context.Context) (string, error) {
+				if !dpdkClientOptions.Enabled() {
+					return "TLS is not configured, using an insecure connection", errDoctorCheckSkipped
+				}
+				if resolverTarget {
+					return "the address is a gRPC target, the TLS handshake is checked by the grpc check", errDoctorCheckSkipped
+				}
+				config, err := dpdkClientOptions.Config()
+				if err != nil {
+					return "", err
+				}
+				if config.ServerName == "" {
+					config.ServerName, _, _ = net.SplitHostPort(dpdkClientOptions.Address)
+				}
+				// gRPC negotiates HTTP/2 by ALPN
+				config.NextProtos = []string{"h2"}
+				d := tls.Dialer{Config: config}
+				conn, err := d.DialContext(ctx, "tcp", dpdkClientOptions.Address)
+				if err != nil {
+					return "", err
+				}
+				defer conn.Close()
+				state := conn.(*tls.Conn).ConnectionState()
+				return fmt.Sprintf("%s, server certificate %s", tls.VersionName(state.Version), state.PeerCertificates[0].Subject), nil
+			},
+		},
+		{
+			name: "grpc",
+			hint: "check that the address points to the dpservice gRPC port and not to another service, or increase --connect-timeout",
+			run: func(ctx context.Context) (string, error) {
+				c, cl, err := dpdkClientOptions.NewClient(ctx)
+				if err != nil {
+					return "", err
+				}
+				dpdkClient, cleanup = c, cl
+				return "handshake succeeded", nil
+			},
+		},
+		{
+			name: "version",
+			hint: "dpservice accepted the connection but did not answer, check the dpservice logs",
+			run: func(ctx context.Context) (string, error) {
+				version, err := dpdkClient.GetVersion(ctx, &api.Version{
+					TypeMeta: api.TypeMeta{Kind: api.VersionKind},
+					VersionMeta: api.VersionMeta{
+						ClientName:    "dpservice-cli",
+						ClientVersion: util.BuildVersion,
+					},
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("service version %s, protocol %s", version.Spec.ServiceVersion, version.Spec.ServiceProtocol), nil
+			},
+		},
+	}
+
+	failed := false
+	for _, check := range checks {
+		if failed {
+			fmt.Fprintf(w, "[SKIP] %s: previous check failed\n", check.name)
+			continue
+		}
+
+		detail, err := check.run(ctx)
+		switch {
+		case errors.Is(err, errDoctorCheckSkipped):
+			fmt.Fprintf(w, "[SKIP] %s: %s\n", check.name, detail)
+		case err != nil:
+			failed = true
+			fmt.Fprintf(w, "[FAIL] %s: %v\n", check.name, err)
+			fmt.Fprintf(w, "       hint: %s\n", check.hint)
+		default:
+			fmt.Fprintf(w, "[PASS] %s: %s\n", check.name, detail)
+		}
+	}
+
+	if failed {
+		return fmt.Errorf("diagnostics failed")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"net"
+	"path/filepath"
+	"time"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+var _ = Describe("Doctor", func() {
+	serve := func(network, address string) net.Addr {
+		lis, err := net.Listen(network, address)
+		Expect(err).NotTo(HaveOccurred())
+		server := grpc.NewServer()
+		dpdkproto.RegisterDPDKironcoreServer(server, &redirectServer{})
+		go func() { _ = server.Serve(lis) }()
+		DeferCleanup(server.Stop)
+		return lis.Addr()
+	}
+
+	run := func(ctx SpecContext, address string) (string, error) {
+		var buf bytes.Buffer
+		opts := &DPDKClientOptions{Address: address, ConnectTimeout: time.Second, RetryPolicy: string(RetryPolicyNone)}
+		err := RunDoctor(ctx, &buf, opts, DoctorOptions{Timeout: 5 * time.Second})
+		return buf.String(), err
+	}
+
+	It("should run all checks against a host:port address", func(ctx SpecContext) {
+		addr := serve("tcp", "127.0.0.1:0")
+
+		out, err := run(ctx, addr.String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("[PASS] address: host \"127.0.0.1\""))
+		Expect(out).To(ContainSubstring("[PASS] tcp: connected to " + addr.String()))
+		Expect(out).To(ContainSubstring("[PASS] version: service version v0.3.1"))
+	})
+
+	It("should skip the tcp and tls probes for a unix socket and connect through gRPC", func(ctx SpecContext) {
+		socket := filepath.Join(GinkgoT().TempDir(), "dpservice.sock")
+		serve("unix", socket)
+
+		out, err := run(ctx, "unix://"+socket)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("[PASS] address: dial target unix://" + socket))
+		Expect(out).To(ContainSubstring("[SKIP] tcp: the address is a gRPC target"))
+		Expect(out).To(ContainSubstring("[SKIP] tls: TLS is not configured"))
+		Expect(out).To(ContainSubstring("[PASS] grpc: handshake succeeded"))
+		Expect(out).To(ContainSubstring("[PASS] version: service version v0.3.1"))
+	})
+
+	It("should skip the tcp probe for a dns target and connect through gRPC", func(ctx SpecContext) {
+		addr := serve("tcp", "127.0.0.1:0")
+
+		out, err := run(ctx, "dns:///"+addr.String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("[PASS] address: dial target dns:///" + addr.String()))
+		Expect(out).To(ContainSubstring("[SKIP] tcp: the address is a gRPC target"))
+		Expect(out).To(ContainSubstring("[PASS] grpc: handshake succeeded"))
+		Expect(out).To(ContainSubstring("[PASS] version: service version v0.3.1"))
+	})
+
+	It("should fail the address check for an address without port and skip the other checks", func(ctx SpecContext) {
+		out, err := run(ctx, "localhost")
+		Expect(err).To(MatchError("diagnostics failed"))
+		Expect(out).To(ContainSubstring("[FAIL] address: address localhost: missing port in address"))
+		Expect(out).To(ContainSubstring("[SKIP] grpc: previous check failed"))
+	})
+})
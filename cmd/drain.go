@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func Drain(factory DPDKClientFactory) *cobra.Command {
+	rendererOptions := &RendererOptions{Output: "name"}
+
+	cmd := &cobra.Command{
+		Use:         "drain [command]",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Args:        cobra.NoArgs,
+		RunE:        SubcommandRequired,
+	}
+
+	rendererOptions.AddFlags(cmd.PersistentFlags())
+
+	subcommands := []*cobra.Command{
+		DrainLoadBalancer(factory, rendererOptions),
+	}
+
+	cmd.Short = fmt.Sprintf("Drains one of %v", CommandNames(subcommands))
+	cmd.Long = fmt.Sprintf("Drains one of %v", CommandNames(subcommands))
+
+	cmd.AddCommand(
+		subcommands...,
+	)
+
+	return cmd
+}
+
+func Undrain(factory DPDKClientFactory) *cobra.Command {
+	rendererOptions := &RendererOptions{Output: "name"}
+
+	cmd := &cobra.Command{
+		Use:         "undrain [command]",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Args:        cobra.NoArgs,
+		RunE:        SubcommandRequired,
+	}
+
+	rendererOptions.AddFlags(cmd.PersistentFlags())
+
+	subcommands := []*cobra.Command{
+		UndrainLoadBalancer(factory, rendererOptions),
+	}
+
+	cmd.Short = fmt.Sprintf("Restores one of %v", CommandNames(subcommands))
+	cmd.Long = fmt.Sprintf("Restores one of %v", CommandNames(subcommands))
+
+	cmd.AddCommand(
+		subcommands...,
+	)
+
+	return cmd
+}
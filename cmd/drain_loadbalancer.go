@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// LoadBalancerTargetSnapshot records the targets of a loadbalancer at the time it was drained.
+type LoadBalancerTargetSnapshot struct {
+	LoadBalancerID string       `json:"loadBalancerId"`
+	Targets        []netip.Addr `json:"targets"`
+}
+
+func DrainLoadBalancer(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory) *cobra.Command {
+	var (
+		opts DrainLoadBalancerOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:     "loadbalancer <--id>",
+		Short:   "Remove all targets of a loadbalancer and save them to a snapshot",
+		Example: "dpservice-cli drain loadbalancer --id=4 --snapshot=lb4.json",
+		Aliases: LoadBalancerAliases,
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			return RunDrainLoadBalancer(
+				cmd.Context(),
+				dpdkClientFactory,
+				rendererFactory,
+				opts,
+			)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	util.Must(opts.MarkRequiredFlags(cmd))
+
+	return cmd
+}
+
+type DrainLoadBalancerOptions struct {
+	ID       string
+	Snapshot string
+}
+
+func (o *DrainLoadBalancerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ID, "id", o.ID, "Loadbalancer ID to drain.")
+	fs.StringVar(&o.Snapshot, "snapshot", "-", "File to write the targets snapshot to ('-' for stdout).")
+}
+
+func (o *DrainLoadBalancerOptions) MarkRequiredFlags(cmd *cobra.Command) error {
+	for _, name := range []string{"id"} {
+		if err := cmd.MarkFlagRequired(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func RunDrainLoadBalancer(
+	ctx context.Context,
+	dpdkClientFactory DPDKClientFactory,
+	rendererFactory RendererFactory,
+	opts DrainLoadBalancerOptions,
+) error {
+	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dpdk client: %w", err)
+	}
+	defer DpdkClose(cleanup)
+
+	lbtargets, err := client.ListLoadBalancerTargets(ctx, opts.ID)
+	if err != nil && lbtargets.Status.Code == 0 {
+		return fmt.Errorf("error listing loadbalancer targets: %w", err)
+	}
+	if err != nil {
+		return rendererFactory.RenderList("", os.Stdout, lbtargets)
+	}
+
+	snapshot := LoadBalancerTargetSnapshot{LoadBalancerID: opts.ID}
+	for _, lbtarget := range lbtargets.Items {
+		if lbtarget.Spec.TargetIP != nil {
+			snapshot.Targets = append(snapshot.Targets, *lbtarget.Spec.TargetIP)
+		}
+	}
+
+	// the snapshot is written before anything is removed, so that a failed drain can be undrained
+	out := io.Writer(os.Stdout)
+	if opts.Snapshot == "-" {
+		if err := writeLoadBalancerTargetSnapshot(os.Stdout, &snapshot); err != nil {
+			return err
+		}
+		out = os.Stderr
+	} else if err := writeLoadBalancerTargetSnapshotFile(opts.Snapshot, &snapshot); err != nil {
+		return err
+	}
+
+	for _, target := range snapshot.Targets {
+		lbtarget, err := client.DeleteLoadBalancerTarget(ctx, opts.ID, &target)
+		if err != nil && lbtarget.Status.Code == 0 {
+			return fmt.Errorf("error deleting loadbalancer target %s: %w", target, err)
+		}
+		if err := rendererFactory.RenderObject("deleted", out, lbtarget); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func UndrainLoadBalancer(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory) *cobra.Command {
+	var (
+		opts UndrainLoadBalancerOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:     "loadbalancer <--filename>",
+		Short:   "Restore the targets of a drained loadbalancer from a snapshot",
+		Example: "dpservice-cli undrain loadbalancer --id=4 -f lb4.json",
+		Aliases: LoadBalancerAliases,
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			return RunUndrainLoadBalancer(
+				cmd.Context(),
+				dpdkClientFactory,
+				rendererFactory,
+				opts,
+			)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	util.Must(opts.MarkRequiredFlags(cmd))
+
+	return cmd
+}
+
+type UndrainLoadBalancerOptions struct {
+	ID       string
+	Filename string
+}
+
+func (o *UndrainLoadBalancerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ID, "id", o.ID, "Loadbalancer ID to restore, defaults to the one recorded in the snapshot.")
+	fs.StringVarP(&o.Filename, "filename", "f", o.Filename, "Snapshot file written by drain ('-' for stdin).")
+}
+
+func (o *UndrainLoadBalancerOptions) MarkRequiredFlags(cmd *cobra.Command) error {
+	for _, name := range []string{"filename"} {
+		if err := cmd.MarkFlagRequired(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func RunUndrainLoadBalancer(
+	ctx context.Context,
+	dpdkClientFactory DPDKClientFactory,
+	rendererFactory RendererFactory,
+	opts UndrainLoadBalancerOptions,
+) error {
+	snapshot, err := readLoadBalancerTargetSnapshot(opts.Filename)
+	if err != nil {
+		return err
+	}
+	if opts.ID != "" && snapshot.LoadBalancerID != "" && opts.ID != snapshot.LoadBalancerID {
+		return fmt.Errorf("snapshot was taken for loadbalancer %q, not %q", snapshot.LoadBalancerID, opts.ID)
+	}
+	id := snapshot.LoadBalancerID
+	if opts.ID != "" {
+		id = opts.ID
+	}
+	if id == "" {
+		return fmt.Errorf("loadbalancer id is neither set nor recorded in the snapshot")
+	}
+
+	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dpdk client: %w", err)
+	}
+	defer DpdkClose(cleanup)
+
+	lbtargets, err := client.ListLoadBalancerTargets(ctx, id)
+	if err != nil && lbtargets.Status.Code == 0 {
+		return fmt.Errorf("error listing loadbalancer targets: %w", err)
+	}
+	if err != nil {
+		return rendererFactory.RenderList("", os.Stdout, lbtargets)
+	}
+
+	current := make(map[netip.Addr]struct{})
+	for _, lbtarget := range lbtargets.Items {
+		if lbtarget.Spec.TargetIP != nil {
+			current[*lbtarget.Spec.TargetIP] = struct{}{}
+		}
+	}
+	if changed := changedLoadBalancerTargets(snapshot.Targets, current); len(changed) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: targets of loadbalancer %s changed since drain: %v\n", id, changed)
+	}
+
+	for _, target := range snapshot.Targets {
+		if _, ok := current[target]; ok {
+			continue
+		}
+
+		lbtarget, err := client.CreateLoadBalancerTarget(ctx, &api.LoadBalancerTarget{
+			TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerTargetKind},
+			LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: id},
+			Spec:                   api.LoadBalancerTargetSpec{TargetIP: &target},
+		})
+		if err != nil && lbtarget.Status.Code == 0 {
+			return fmt.Errorf("error creating loadbalancer target %s: %w", target, err)
+		}
+		if err := rendererFactory.RenderObject("created", os.Stdout, lbtarget); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// changedLoadBalancerTargets returns the targets currently configured on the loadbalancer
+// that are not part of the snapshot, i.e. targets that appeared after the drain.
+func changedLoadBalancerTargets(snapshot []netip.Addr, current map[netip.Addr]struct{}) []netip.Addr {
+	known := make(map[netip.Addr]struct{}, len(snapshot))
+	for _, target := range snapshot {
+		known[target] = struct{}{}
+	}
+
+	var changed []netip.Addr
+	for target := range current {
+		if _, ok := known[target]; !ok {
+			changed = append(changed, target)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		return changed[i].Less(changed[j])
+	})
+	return changed
+}
+
+func writeLoadBalancerTargetSnapshot(w io.Writer, snapshot *LoadBalancerTargetSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling snapshot: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", data); err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	return nil
+}
+
+// writeLoadBalancerTargetSnapshotFile writes snapshot to filename and only succeeds once
+// it is synced to disk.
+func writeLoadBalancerTargetSnapshotFile(filename string, snapshot *LoadBalancerTargetSnapshot) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("error creating snapshot file: %w", err)
+	}
+	if err := writeLoadBalancerTargetSnapshot(f, snapshot); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("error syncing snapshot file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error closing snapshot file: %w", err)
+	}
+	return nil
+}
+
+func readLoadBalancerTargetSnapshot(filename string) (*LoadBalancerTargetSnapshot, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if filename == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %w", err)
+	}
+
+	snapshot := &LoadBalancerTargetSnapshot{}
+	if err := yaml.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("error unmarshaling snapshot: %w", err)
+	}
+	return snapshot, nil
+}
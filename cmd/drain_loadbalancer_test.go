@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// drainClient has the targets of one loadbalancer and records deleted and created targets.
+type drainClient struct {
+	client.Client
+	targets []netip.Addr
+	deleted []string
+	created []string
+}
+
+func (c *drainClient) ListLoadBalancerTargets(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancerTargetList, error) {
+	list := &api.LoadBalancerTargetList{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerTargetListKind}}
+	for i := range c.targets {
+		list.Items = append(list.Items, api.LoadBalancerTarget{Spec: api.LoadBalancerTargetSpec{TargetIP: &c.targets[i]}})
+	}
+	return list, nil
+}
+
+func (c *drainClient) DeleteLoadBalancerTarget(ctx context.Context, id string, targetIP *netip.Addr, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error) {
+	c.deleted = append(c.deleted, id+"/"+targetIP.String())
+	for i, target := range c.targets {
+		if target == *targetIP {
+			c.targets = append(c.targets[:i], c.targets[i+1:]...)
+			break
+		}
+	}
+	return &api.LoadBalancerTarget{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerTargetKind}}, nil
+}
+
+func (c *drainClient) CreateLoadBalancerTarget(ctx context.Context, lbtarget *api.LoadBalancerTarget, ignoredErrors ...[]uint32) (*api.LoadBalancerTarget, error) {
+	c.created = append(c.created, lbtarget.LoadbalancerID+"/"+lbtarget.Spec.TargetIP.String())
+	c.targets = append(c.targets, *lbtarget.Spec.TargetIP)
+	return lbtarget, nil
+}
+
+var _ = Describe("DrainLoadBalancer", func() {
+	var (
+		c   *drainClient
+		dir string
+	)
+
+	BeforeEach(func() {
+		c = &drainClient{targets: []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")}}
+		dir = GinkgoT().TempDir()
+
+		captureOutput(&os.Stdout)
+	})
+
+	It("should remove all targets and restore them from the snapshot", func(ctx SpecContext) {
+		snapshot := filepath.Join(dir, "lb4.json")
+		Expect(RunDrainLoadBalancer(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, DrainLoadBalancerOptions{ID: "lb4", Snapshot: snapshot})).To(Succeed())
+		Expect(c.deleted).To(Equal([]string{"lb4/10.0.0.1", "lb4/10.0.0.2"}))
+		Expect(c.targets).To(BeEmpty())
+		Expect(os.ReadFile(snapshot)).To(MatchJSON(`{"loadBalancerId":"lb4","targets":["10.0.0.1","10.0.0.2"]}`))
+
+		Expect(RunUndrainLoadBalancer(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, UndrainLoadBalancerOptions{Filename: snapshot})).To(Succeed())
+		Expect(c.created).To(Equal([]string{"lb4/10.0.0.1", "lb4/10.0.0.2"}))
+	})
+
+	It("should not remove any target if the snapshot cannot be written", func(ctx SpecContext) {
+		snapshot := filepath.Join(dir, "missing", "lb4.json")
+		err := RunDrainLoadBalancer(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, DrainLoadBalancerOptions{ID: "lb4", Snapshot: snapshot})
+		Expect(err).To(MatchError(ContainSubstring("error creating snapshot file")))
+		Expect(c.deleted).To(BeEmpty())
+	})
+
+	It("should only restore the targets that are missing", func(ctx SpecContext) {
+		snapshot := filepath.Join(dir, "lb4.json")
+		Expect(os.WriteFile(snapshot, []byte(`{"loadBalancerId":"lb4","targets":["10.0.0.2","10.0.0.3"]}`), 0o644)).To(Succeed())
+
+		Expect(RunUndrainLoadBalancer(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, UndrainLoadBalancerOptions{Filename: snapshot})).To(Succeed())
+		Expect(c.created).To(Equal([]string{"lb4/10.0.0.3"}))
+	})
+})
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/spf13/pflag"
+)
+
+const (
+	DryRunClient = "client"
+	DryRunServer = "server"
+)
+
+// ErrServerDryRunUnsupported is returned for --dry-run=server, as dpservice has no
+// validate-only requests.
+var ErrServerDryRunUnsupported = errors.New("dpservice cannot validate requests without applying them, --dry-run=server is not supported")
+
+type DryRunOptions struct {
+	DryRun string
+}
+
+func (o *DryRunOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.DryRun, "dry-run", o.DryRun, fmt.Sprintf("Only validate instead of creating: %s (decode and validate the objects of -f locally) or %s (not supported by dpservice).", DryRunClient, DryRunServer))
+}
+
+func (o *DryRunOptions) Validate() error {
+	switch o.DryRun {
+	case "", DryRunClient:
+		return nil
+	case DryRunServer:
+		return ErrServerDryRunUnsupported
+	default:
+		return fmt.Errorf("invalid --dry-run %q, must be %s or %s", o.DryRun, DryRunClient, DryRunServer)
+	}
+}
+
+// dryRunClientFactory refuses to connect for a dry run, so that create subcommands
+// never reach dpservice with --dry-run set.
+type dryRunClientFactory struct {
+	DPDKClientFactory
+	opts *DryRunOptions
+}
+
+func (f dryRunClientFactory) NewClient(ctx context.Context) (client.Client, func() error, error) {
+	if err := f.opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if f.opts.DryRun == DryRunClient {
+		return nil, nil, fmt.Errorf("--dry-run=%s is only supported with 'create -f'", DryRunClient)
+	}
+	return f.DPDKClientFactory.NewClient(ctx)
+}
+
+// renderDryRun renders the objects that would be created.
+func renderDryRun(w io.Writer, rendererFactory RendererFactory, objs []any) error {
+	r, err := rendererFactory.NewRenderer("created (dry run)", w)
+	if err != nil {
+		return fmt.Errorf("error creating renderer: %w", err)
+	}
+	for _, obj := range objs {
+		if err := r.Render(obj); err != nil {
+			return fmt.Errorf("error rendering %T: %w", obj, err)
+		}
+	}
+	return nil
+}
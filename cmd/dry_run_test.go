@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type unreachableClientFactory struct{}
+
+func (unreachableClientFactory) NewClient(context.Context) (client.Client, func() error, error) {
+	return nil, nil, errors.New("dpservice must not be contacted")
+}
+
+var _ = Describe("DryRun", func() {
+	It("should reject --dry-run=server", func(ctx SpecContext) {
+		cmd := Create(unreachableClientFactory{})
+		cmd.SetArgs([]string{"prefix", "--dry-run=server", "--prefix=10.0.0.0/24", "--interface-id=vm1"})
+		cmd.SilenceUsage = true
+
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError(ErrServerDryRunUnsupported))
+	})
+
+	It("should validate the objects of -f without contacting dpservice with --dry-run=client", func(ctx SpecContext) {
+		filename := filepath.Join(GinkgoT().TempDir(), "prefix.yaml")
+		Expect(os.WriteFile(filename, []byte("kind: Prefix\nmetadata:\n  interface_id: vm1\nspec:\n  prefix: 10.0.0.0/24\n"), 0o644)).To(Succeed())
+
+		cmd := Create(unreachableClientFactory{})
+		cmd.SetArgs([]string{"-f", filename, "--dry-run=client"})
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+	})
+
+	It("should not support --dry-run=client for create subcommands", func(ctx SpecContext) {
+		cmd := Create(unreachableClientFactory{})
+		cmd.SetArgs([]string{"prefix", "--dry-run=client", "--prefix=10.0.0.0/24", "--interface-id=vm1"})
+		cmd.SilenceUsage = true
+
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError(ContainSubstring("only supported with 'create -f'")))
+	})
+
+	It("should not count dry runs as mutating", func() {
+		cmd := Create(unreachableClientFactory{})
+		Expect(cmd.ParseFlags([]string{"--dry-run=client"})).To(Succeed())
+		Expect(IsMutating(cmd)).To(BeFalse())
+	})
+})
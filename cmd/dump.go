@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// dumpVersionTimeout bounds getting the server version for a dump, as dpservice may be the reason of the failure.
+const dumpVersionTimeout = 2 * time.Second
+
+type DumpOptions struct {
+	File string
+
+	// factory connects to dpservice to get its version.
+	factory DPDKClientFactory
+}
+
+func (o *DumpOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.File, "dump-on-error", o.File, "When the command fails, write a json diagnostic file for bug reports to this path (configuration, command, failed RPCs, versions).")
+}
+
+type DumpFlag struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// Dump is the diagnostic context of a failed run. Flags are dumped with their values,
+// which are file paths at most, the contents of files are never included.
+type Dump struct {
+	Time          string       `json:"time"`
+	Command       string       `json:"command"`
+	Args          []string     `json:"args"`
+	Flags         []DumpFlag   `json:"flags"`
+	DialTarget    string       `json:"dialTarget,omitempty"`
+	CLIVersion    string       `json:"cliVersion"`
+	ServerVersion string       `json:"serverVersion"`
+	RPCs          RunMetrics   `json:"rpcs"`
+	FailedRPCs    []RPCFailure `json:"failedRPCs"`
+	Error         string       `json:"error"`
+	ExitCode      int          `json:"exitCode"`
+}
+
+// NewDump collects the diagnostic context of the failed command cmd.
+func NewDump(ctx context.Context, cmd *cobra.Command, args []string, recorder *MetricsRecorder, factory DPDKClientFactory, runErr error, exitCode int) *Dump {
+	dump := &Dump{
+		Time:       time.Now().UTC().Format(time.RFC3339),
+		Command:    cmd.CommandPath(),
+		Args:       args,
+		CLIVersion: util.BuildVersion,
+		RPCs:       recorder.Summary(),
+		FailedRPCs: recorder.Failures(),
+		Error:      runErr.Error(),
+		ExitCode:   exitCode,
+	}
+	// after the RPCs of the run were collected, so that getting the version is not included
+	dump.ServerVersion = serverVersion(ctx, factory)
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		source := "default"
+		if f.Changed {
+			source = "flag"
+		}
+		dump.Flags = append(dump.Flags, DumpFlag{Name: f.Name, Value: f.Value.String(), Source: source})
+	})
+	if f := cmd.Flags().Lookup("address"); f != nil {
+		dump.DialTarget = DialTarget(f.Value.String())
+	}
+	return dump
+}
+
+// serverVersion returns the version of dpservice or why it could not be got.
+func serverVersion(ctx context.Context, factory DPDKClientFactory) string {
+	if factory == nil {
+		return "unknown"
+	}
+	ctx, cancel := context.WithTimeout(ctx, dumpVersionTimeout)
+	defer cancel()
+
+	version, err := getServerVersion(ctx, factory)
+	if err != nil {
+		return fmt.Sprintf("unknown (%v)", err)
+	}
+	return fmt.Sprintf("%s (proto %s)", version.Spec.ServiceVersion, version.Spec.ServiceProtocol)
+}
+
+// Write writes the dump of the failed command cmd to the dump file, if one was given.
+func (o *DumpOptions) Write(ctx context.Context, cmd *cobra.Command, args []string, recorder *MetricsRecorder, runErr error, exitCode int) error {
+	if o.File == "" || runErr == nil || exitCode == 0 {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(NewDump(ctx, cmd, args, recorder, o.factory, runErr, exitCode), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling dump: %w", err)
+	}
+	if err := os.WriteFile(o.File, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("error writing dump file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Dump", func() {
+	It("should write the context of a failed command", func(ctx SpecContext) {
+		recorder := NewMetricsRecorder()
+		failing := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			return status.Error(codes.Unavailable, "connection reset")
+		}
+		notFound := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			reply.(*dpdkproto.GetInterfaceResponse).Status = &dpdkproto.Status{Code: 201, Message: "NO_VM"}
+			return nil
+		}
+		Expect(recorder.UnaryClientInterceptor(ctx, "/dpdkironcore.v1.DPDKironcore/ListInterfaces", nil, nil, nil, failing)).NotTo(Succeed())
+		Expect(recorder.UnaryClientInterceptor(ctx, "/dpdkironcore.v1.DPDKironcore/GetInterface", nil, &dpdkproto.GetInterfaceResponse{}, nil, notFound)).To(Succeed())
+
+		root := Command()
+		cmd, _, err := root.Find([]string{"list", "interfaces"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.ParseFlags([]string{"--address=10.0.0.1:1337"})).To(Succeed())
+
+		dump := NewDump(ctx, cmd, []string{"list", "interfaces", "--address=10.0.0.1:1337"}, recorder, unreachableClientFactory{}, errors.New("failed"), 1)
+		Expect(dump.Command).To(Equal("dpservice-cli list interfaces"))
+		Expect(dump.Flags).To(ContainElement(DumpFlag{Name: "address", Value: "10.0.0.1:1337", Source: "flag"}))
+		Expect(dump.DialTarget).To(Equal("passthrough:///10.0.0.1:1337"))
+		Expect(dump.ServerVersion).To(Equal("unknown (error creating dpdk client: dpservice must not be contacted)"))
+		Expect(dump.RPCs.Errors).To(Equal(1))
+		Expect(dump.FailedRPCs).To(Equal([]RPCFailure{
+			{Method: "ListInterfaces", Error: "rpc error: code = Unavailable desc = connection reset"},
+			{Method: "GetInterface", Error: "status 201: NO_VM"},
+		}))
+		Expect(dump.Error).To(Equal("failed"))
+		Expect(dump.ExitCode).To(Equal(1))
+
+		opts := &DumpOptions{File: filepath.Join(GinkgoT().TempDir(), "dump.json")}
+		Expect(opts.Write(ctx, cmd, nil, recorder, errors.New("failed"), 1)).To(Succeed())
+		data, err := os.ReadFile(opts.File)
+		Expect(err).NotTo(HaveOccurred())
+		var written Dump
+		Expect(json.Unmarshal(data, &written)).To(Succeed())
+		Expect(written.FailedRPCs).To(HaveLen(2))
+	})
+
+	It("should not write a dump for successful commands", func(ctx SpecContext) {
+		opts := &DumpOptions{File: filepath.Join(GinkgoT().TempDir(), "dump.json")}
+		Expect(opts.Write(ctx, Command(), nil, NewMetricsRecorder(), nil, 0)).To(Succeed())
+		Expect(opts.File).NotTo(BeAnExistingFile())
+	})
+})
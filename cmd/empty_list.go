@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/spf13/pflag"
+)
+
+// ExitCodeEmpty is the exit code of list commands with --exit-on-empty if the list has no items.
+const ExitCodeEmpty = 5
+
+// NoResourcesFound is printed to stderr instead of rendering an empty list in name, table and line output.
+const NoResourcesFound = "No resources found."
+
+var ErrEmptyList = errors.New("list is empty")
+
+type EmptyListOptions struct {
+	ExitOnEmpty bool
+}
+
+func (o *EmptyListOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.ExitOnEmpty, "exit-on-empty", o.ExitOnEmpty, fmt.Sprintf("Exit with %d after rendering if the list has no items.", ExitCodeEmpty))
+}
+
+// CheckEmpty returns ErrEmptyList if --exit-on-empty is set and the rendered list has no items.
+func (o *EmptyListOptions) CheckEmpty(list api.List) error {
+	if o.ExitOnEmpty && len(list.GetItems()) == 0 {
+		return ErrEmptyList
+	}
+	return nil
+}
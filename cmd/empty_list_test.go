@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"net/netip"
+	"os"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EmptyListOptions", func() {
+	prefix := netip.MustParsePrefix("10.0.0.0/24")
+	routes := fakeClientFactory{&auditClient{routes: map[uint32][]api.Route{
+		100: {{Spec: api.RouteSpec{Prefix: &prefix, NextHop: &api.RouteNextHop{VNI: 100}}}},
+	}}}
+
+	It("should fail with a distinct exit code if the list is empty", func(ctx SpecContext) {
+		opts := ListRoutesOptions{VNI: 200, EmptyListOptions: EmptyListOptions{ExitOnEmpty: true}}
+		err := RunGetRoute(ctx, routes, &RendererOptions{Output: "name"}, opts)
+		Expect(err).To(MatchError(ErrEmptyList))
+		Expect(ExitCode(err)).To(Equal(ExitCodeEmpty))
+	})
+
+	It("should succeed if the list has items", func(ctx SpecContext) {
+		opts := ListRoutesOptions{VNI: 100, EmptyListOptions: EmptyListOptions{ExitOnEmpty: true}}
+		Expect(RunGetRoute(ctx, routes, &RendererOptions{Output: "name"}, opts)).To(Succeed())
+	})
+
+	It("should succeed on empty lists without --exit-on-empty", func(ctx SpecContext) {
+		Expect(RunGetRoute(ctx, routes, &RendererOptions{Output: "name"}, ListRoutesOptions{VNI: 200})).To(Succeed())
+	})
+
+	It("should print a notice to stderr instead of rendering an empty list in name and table output", func() {
+		stderr := captureOutput(&os.Stderr)
+
+		var buf bytes.Buffer
+		Expect((&RendererOptions{Output: "table"}).RenderList("", &buf, &api.RouteList{})).To(Succeed())
+		Expect((&RendererOptions{Output: "name", Quiet: true}).RenderList("", &buf, &api.RouteList{})).To(Succeed())
+		Expect(buf.String()).To(BeEmpty())
+		Expect(os.ReadFile(stderr)).To(Equal([]byte(NoResourcesFound + "\n")))
+	})
+
+	It("should render an empty array for empty lists in structured output", func() {
+		var buf bytes.Buffer
+		Expect((&RendererOptions{Output: "json"}).RenderList("", &buf, &api.RouteList{TypeMeta: api.TypeMeta{Kind: api.RouteListKind}})).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(`"items":[]`))
+
+		buf.Reset()
+		Expect((&RendererOptions{Output: "yaml"}).RenderList("", &buf, &api.RouteList{})).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("items: []"))
+	})
+})
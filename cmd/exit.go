@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+)
+
+// Execute runs the dpservice-cli command and returns the exit code of the process.
+func Execute() int {
+	metricsOptions := &MetricsOptions{}
+	dumpOptions := &DumpOptions{}
+	root := newCommand(metricsOptions, dumpOptions)
+
+	start := time.Now()
+	executed, err := root.ExecuteC()
+	exitCode := ExitCode(err)
+
+	command := root.Name()
+	if executed != nil {
+		command = executed.CommandPath()
+	}
+	if err := metricsOptions.Write(command, time.Since(start), err, exitCode); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing metrics: %v\n", err)
+	}
+	if executed != nil {
+		if err := dumpOptions.Write(context.Background(), executed, os.Args[1:], metricsOptions.Recorder(), err, exitCode); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing dump: %v\n", err)
+		}
+	}
+	return exitCode
+}
+
+// ExitCode reports err to the user and returns the matching exit code.
+func ExitCode(err error) int {
+	if err == nil || errors.Is(err, ErrFlagsPrinted) {
+		return 0
+	}
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		reportError("Error", err, ExitCodeNotFound)
+		return ExitCodeNotFound
+	}
+	if errors.Is(err, ErrEmptyList) {
+		reportError("Error", err, ExitCodeEmpty)
+		return ExitCodeEmpty
+	}
+	if strings.Contains(err.Error(), "Unimplemented desc") {
+		fmt.Println("Error in gRPC, client and server are probably using different proto version")
+		return apierrors.SERVER_ERROR
+	}
+	// check if it is Server side error
+	if err.Error() == strconv.Itoa(apierrors.SERVER_ERROR) || strings.Contains(err.Error(), "error code") {
+		return apierrors.SERVER_ERROR
+	}
+	// else it is Client side error
+	reportError("Error running command", err, apierrors.CLIENT_ERROR)
+	return apierrors.CLIENT_ERROR
+}
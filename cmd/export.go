@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// serverAssignedSpecFields are left out of exported documents, since dpservice
+// assigns them on creation.
+var serverAssignedSpecFields = []string{"underlay_route"}
+
+// WriteApplyDocuments writes objs as YAML documents that can be created again
+// with `create -f`. Only kind, metadata and spec are written.
+func WriteApplyDocuments(w io.Writer, objs ...any) error {
+	dw := NewApplyDocumentWriter(w)
+	for _, obj := range objs {
+		if err := dw.Write(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyDocumentWriter writes objects one at a time as YAML documents like
+// WriteApplyDocuments does, so that exports need not hold all objects in memory.
+type ApplyDocumentWriter struct {
+	w       io.Writer
+	written int
+}
+
+func NewApplyDocumentWriter(w io.Writer) *ApplyDocumentWriter {
+	return &ApplyDocumentWriter{w: w}
+}
+
+// Write writes obj as the next document.
+func (d *ApplyDocumentWriter) Write(obj any) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("error marshaling %T: %w", obj, err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("error unmarshaling %T: %w", obj, err)
+	}
+
+	doc := map[string]any{
+		"kind":     fields["kind"],
+		"metadata": fields["metadata"],
+	}
+	if spec, ok := fields["spec"].(map[string]any); ok {
+		for _, field := range serverAssignedSpecFields {
+			delete(spec, field)
+		}
+		doc["spec"] = spec
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("error marshaling %T to yaml: %w", obj, err)
+	}
+
+	if d.written > 0 {
+		out = append([]byte("---\n"), out...)
+	}
+	if _, err := d.w.Write(out); err != nil {
+		return err
+	}
+	d.written++
+	return nil
+}
+
+// ApplyDocumentDirWriter writes the objects of every kind as apply documents to a file
+// of their own in a directory, e.g. interfaces.yaml and prefixes.yaml.
+type ApplyDocumentDirWriter struct {
+	dir       string
+	skipEmpty bool
+	files     map[string]*os.File
+	writers   map[string]*ApplyDocumentWriter
+}
+
+// NewApplyDocumentDirWriter creates dir if needed. Unless skipEmpty is set, Close
+// creates empty files for the expected kinds no object was written for.
+func NewApplyDocumentDirWriter(dir string, skipEmpty bool) (*ApplyDocumentDirWriter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating output directory: %w", err)
+	}
+	return &ApplyDocumentDirWriter{
+		dir:       dir,
+		skipEmpty: skipEmpty,
+		files:     make(map[string]*os.File),
+		writers:   make(map[string]*ApplyDocumentWriter),
+	}, nil
+}
+
+// KindFilename returns the name of the file the objects of kind are written to.
+func KindFilename(kind string) string {
+	name := strings.ToLower(kind)
+	if strings.HasSuffix(name, "x") || strings.HasSuffix(name, "s") {
+		return name + "es.yaml"
+	}
+	return name + "s.yaml"
+}
+
+func (d *ApplyDocumentDirWriter) open(kind string) (*ApplyDocumentWriter, error) {
+	if dw, ok := d.writers[kind]; ok {
+		return dw, nil
+	}
+	f, err := os.Create(filepath.Join(d.dir, KindFilename(kind)))
+	if err != nil {
+		return nil, err
+	}
+	d.files[kind] = f
+	d.writers[kind] = NewApplyDocumentWriter(f)
+	return d.writers[kind], nil
+}
+
+// Write writes obj as the next document of the file of its kind.
+func (d *ApplyDocumentDirWriter) Write(obj any) error {
+	dw, err := d.open(batchKind(obj))
+	if err != nil {
+		return err
+	}
+	return dw.Write(obj)
+}
+
+// Close closes all files, creating empty ones for the expected kinds first.
+func (d *ApplyDocumentDirWriter) Close(expectedKinds ...string) error {
+	var errs []error
+	if !d.skipEmpty {
+		for _, kind := range expectedKinds {
+			if _, err := d.open(kind); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for _, f := range d.files {
+		errs = append(errs, f.Close())
+	}
+	return errors.Join(errs...)
+}
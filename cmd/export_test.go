@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"io"
+	"net/netip"
+	"os"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/runtime"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WriteApplyDocuments", func() {
+	It("should write documents that decode cleanly", func() {
+		ipv4 := netip.MustParseAddr("10.200.1.4")
+		underlayRoute := netip.MustParseAddr("fc00:1::1")
+		prefix := netip.MustParsePrefix("10.10.10.0/24")
+
+		iface := &api.Interface{
+			TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+			InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			Spec:          api.InterfaceSpec{VNI: 100, Device: "net_tap5", IPv4: &ipv4, UnderlayRoute: &underlayRoute},
+			Status:        api.Status{Code: 0, Message: "ok"},
+		}
+		pfx := &api.Prefix{
+			TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+			PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"},
+			Spec:       api.PrefixSpec{Prefix: prefix, UnderlayRoute: &underlayRoute},
+		}
+
+		buf := &bytes.Buffer{}
+		Expect(WriteApplyDocuments(buf, iface, pfx)).To(Succeed())
+		Expect(buf.String()).NotTo(ContainSubstring("underlay_route"))
+		Expect(buf.String()).NotTo(ContainSubstring("status"))
+
+		factory, err := runtime.NewExtDecoderFactory("yaml")
+		Expect(err).NotTo(HaveOccurred())
+		decoder := runtime.NewKindDecoder(runtime.DefaultScheme, runtime.NewPeekDecoder(buf, factory))
+
+		obj, err := decoder.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj).To(Equal(&api.Interface{
+			TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+			InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			Spec:          api.InterfaceSpec{VNI: 100, Device: "net_tap5", IPv4: &ipv4},
+		}))
+
+		obj, err = decoder.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(obj).To(Equal(&api.Prefix{
+			TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+			PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"},
+			Spec:       api.PrefixSpec{Prefix: prefix},
+		}))
+
+		_, err = decoder.Next()
+		Expect(err).To(MatchError(io.EOF))
+	})
+})
+
+// countingWriter counts the YAML documents written to it so far.
+type countingWriter struct {
+	docs int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.docs += bytes.Count(p, []byte("kind:"))
+	return len(p), nil
+}
+
+var _ = Describe("ApplyDocumentWriter", func() {
+	It("should write every document as soon as it is passed", func() {
+		w := &countingWriter{}
+		dw := NewApplyDocumentWriter(w)
+		for i := 1; i <= 3; i++ {
+			Expect(dw.Write(&api.Prefix{
+				TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+				PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"},
+				Spec:       api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.0.0/24")},
+			})).To(Succeed())
+			Expect(w.docs).To(Equal(i))
+		}
+	})
+})
+
+var _ = Describe("ApplyDocumentDirWriter", func() {
+	prefix := &api.Prefix{
+		TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+		PrefixMeta: api.PrefixMeta{InterfaceID: "vm1"},
+		Spec:       api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.0.0/24")},
+	}
+
+	It("should write every kind to its own file", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "export")
+		dw, err := NewApplyDocumentDirWriter(dir, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dw.Write(&api.Interface{TypeMeta: api.TypeMeta{Kind: api.InterfaceKind}, InterfaceMeta: api.InterfaceMeta{ID: "vm1"}})).To(Succeed())
+		Expect(dw.Write(prefix)).To(Succeed())
+		Expect(dw.Write(prefix)).To(Succeed())
+		Expect(dw.Close(api.InterfaceKind, api.NatKind, api.PrefixKind)).To(Succeed())
+
+		data, err := os.ReadFile(filepath.Join(dir, "interfaces.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("id: vm1"))
+		data, err = os.ReadFile(filepath.Join(dir, "prefixes.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bytes.Count(data, []byte("kind: Prefix"))).To(Equal(2))
+		data, err = os.ReadFile(filepath.Join(dir, "nats.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(BeEmpty())
+	})
+
+	It("should not create files for empty kinds with skip empty", func() {
+		dir := GinkgoT().TempDir()
+		dw, err := NewApplyDocumentDirWriter(dir, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dw.Write(prefix)).To(Succeed())
+		Expect(dw.Close(api.InterfaceKind, api.PrefixKind)).To(Succeed())
+
+		entries, err := os.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Name()).To(Equal("prefixes.yaml"))
+	})
+})
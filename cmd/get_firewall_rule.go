@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
 	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -54,11 +56,13 @@ func GetFirewallRule(dpdkClientFactory DPDKClientFactory, rendererFactory Render
 type GetFirewallRuleOptions struct {
 	RuleID      string
 	InterfaceID string
+	NotFoundOptions
 }
 
 func (o *GetFirewallRuleOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.RuleID, "rule-id", o.RuleID, "Rule ID to get.")
 	fs.StringVar(&o.InterfaceID, "interface-id", o.InterfaceID, "Interface ID where is firewall rule.")
+	o.NotFoundOptions.AddFlags(fs)
 }
 
 func (o *GetFirewallRuleOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -86,6 +90,9 @@ func RunGetFirewallRule(
 		)
 	} else {
 		fwrule, err := client.GetFirewallRule(ctx, opts.InterfaceID, opts.RuleID)
+		if dynamic.IsNotFound(err) {
+			return opts.NotFound(api.FirewallRuleKind, opts.InterfaceID+"/"+opts.RuleID)
+		}
 		if err != nil && fwrule.Status.Code == 0 {
 			return fmt.Errorf("error getting firewall rule: %w", err)
 		}
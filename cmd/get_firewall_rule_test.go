@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/client"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+type fwRuleProtoClient struct {
+	dpdkproto.DPDKironcoreClient
+}
+
+func (fwRuleProtoClient) GetFirewallRule(ctx context.Context, req *dpdkproto.GetFirewallRuleRequest, opts ...grpc.CallOption) (*dpdkproto.GetFirewallRuleResponse, error) {
+	return &dpdkproto.GetFirewallRuleResponse{
+		Status: &dpdkproto.Status{},
+		Rule: &dpdkproto.FirewallRule{
+			Id:        req.RuleId,
+			Direction: dpdkproto.TrafficDirection_EGRESS,
+			Action:    dpdkproto.FirewallAction_ACCEPT,
+			Priority:  1000,
+			SourcePrefix: &dpdkproto.Prefix{
+				Ip:     &dpdkproto.IpAddress{Ipver: dpdkproto.IpVersion_IPV4, Address: []byte("10.0.0.0")},
+				Length: 8,
+			},
+			DestinationPrefix: &dpdkproto.Prefix{
+				Ip:     &dpdkproto.IpAddress{Ipver: dpdkproto.IpVersion_IPV4, Address: []byte("192.168.1.0")},
+				Length: 24,
+			},
+			ProtocolFilter: &dpdkproto.ProtocolFilter{Filter: &dpdkproto.ProtocolFilter_Tcp{Tcp: &dpdkproto.TcpFilter{
+				SrcPortLower: -1, DstPortLower: 443, DstPortUpper: 443,
+			}}},
+		},
+	}, nil
+}
+
+var _ = Describe("GetFirewallRule", func() {
+	It("should populate all fields of the rule", func(ctx SpecContext) {
+		fwrule, err := client.NewClient(fwRuleProtoClient{}).GetFirewallRule(ctx, "vm1", "fw1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fwrule.InterfaceID).To(Equal("vm1"))
+		Expect(fwrule.Spec.RuleID).To(Equal("fw1"))
+		Expect(fwrule.Spec.TrafficDirection).To(Equal("Egress"))
+		Expect(fwrule.Spec.FirewallAction).To(Equal("Accept"))
+		Expect(fwrule.Spec.Priority).To(Equal(uint32(1000)))
+		Expect(fwrule.Spec.SourcePrefix.String()).To(Equal("10.0.0.0/8"))
+		Expect(fwrule.Spec.DestinationPrefix.String()).To(Equal("192.168.1.0/24"))
+		Expect(fwrule.Spec.ProtocolFilter.GetTcp().GetDstPortLower()).To(Equal(int32(443)))
+
+		data, err := renderer.DefaultTableConverter.ConvertToTable(fwrule)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data.Headers).To(Equal([]any{"InterfaceID", "RuleID", "Direction", "Src", "Dst", "Action", "Protocol", "Priority"}))
+		Expect(data.Columns).To(HaveLen(1))
+		Expect(data.Columns[0][0:3]).To(Equal([]any{"vm1", "fw1", "Egress"}))
+		Expect(data.Columns[0][5]).To(Equal("Accept"))
+		Expect(data.Columns[0][7]).To(Equal(uint32(1000)))
+
+		var buf bytes.Buffer
+		Expect(renderer.NewJSON(&buf, false).Render(fwrule)).To(Succeed())
+		var doc map[string]any
+		Expect(json.Unmarshal(buf.Bytes(), &doc)).To(Succeed())
+		Expect(doc["spec"]).To(HaveKeyWithValue("priority", BeEquivalentTo(1000)))
+		Expect(doc["spec"]).To(HaveKey("protocol_filter"))
+	})
+})
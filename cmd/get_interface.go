@@ -5,10 +5,16 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
 	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -21,7 +27,7 @@ func GetInterface(dpdkClientFactory DPDKClientFactory, rendererFactory RendererF
 	cmd := &cobra.Command{
 		Use:     "interface <--id>",
 		Short:   "Get interface",
-		Example: "dpservice-cli get interface --id=vm1",
+		Example: "dpservice-cli get interface --id=vm1\ndpservice-cli get interface --id=3f2a --prefix-match",
 		Aliases: InterfaceAliases,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -42,11 +48,23 @@ func GetInterface(dpdkClientFactory DPDKClientFactory, rendererFactory RendererF
 }
 
 type GetInterfaceOptions struct {
-	ID string
+	ID                string
+	Export            bool
+	IncludeDependents bool
+	PrefixMatch       bool
+	OutputDir         string
+	SkipEmpty         bool
+	NotFoundOptions
 }
 
 func (o *GetInterfaceOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.ID, "id", o.ID, "ID of the interface.")
+	fs.BoolVar(&o.Export, "export", o.Export, "Print the interface as a YAML document that can be used with 'create -f'.")
+	fs.BoolVar(&o.IncludeDependents, "include-dependents", o.IncludeDependents, "With --export, also export the virtual IP, NAT and prefixes of the interface.")
+	fs.StringVar(&o.OutputDir, "output-dir", o.OutputDir, "With --export, write the objects of every kind to their own file in this directory (interfaces.yaml, prefixes.yaml, ...).")
+	fs.BoolVar(&o.SkipEmpty, "skip-empty", o.SkipEmpty, "With --output-dir, do not create files for kinds without objects.")
+	fs.BoolVar(&o.PrefixMatch, "prefix-match", o.PrefixMatch, "If no interface has exactly the given ID, get the only interface whose ID starts with it.")
+	o.NotFoundOptions.AddFlags(fs)
 }
 
 func (o *GetInterfaceOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -65,6 +83,28 @@ func RunGetInterface(
 	}
 	defer DpdkClose(cleanup)
 
+	if opts.Export {
+		if opts.ID == "" {
+			return fmt.Errorf("--export requires --id")
+		}
+		if opts.OutputDir == "" {
+			return exportInterface(ctx, client, NewApplyDocumentWriter(os.Stdout), opts)
+		}
+		dw, err := NewApplyDocumentDirWriter(opts.OutputDir, opts.SkipEmpty)
+		if err != nil {
+			return err
+		}
+		err = exportInterface(ctx, client, dw, opts)
+		kinds := []string{api.InterfaceKind}
+		if opts.IncludeDependents {
+			kinds = append(kinds, api.VirtualIPKind, api.NatKind, api.PrefixKind)
+		}
+		return errors.Join(err, dw.Close(kinds...))
+	}
+	if opts.OutputDir != "" {
+		return fmt.Errorf("--output-dir requires --export")
+	}
+
 	if opts.ID == "" {
 		return RunListInterfaces(
 			ctx,
@@ -73,7 +113,10 @@ func RunGetInterface(
 			ListInterfacesOptions{},
 		)
 	} else {
-		iface, err := client.GetInterface(ctx, opts.ID)
+		iface, err := getInterface(ctx, client, opts)
+		if dynamic.IsNotFound(err) {
+			return opts.NotFound(api.InterfaceKind, opts.ID)
+		}
 		if err != nil && iface.Status.Code == 0 {
 			return fmt.Errorf("error getting interface: %w", err)
 		}
@@ -93,3 +136,80 @@ func RunGetInterface(
 		return rendererFactory.RenderObject("", os.Stdout, iface)
 	}
 }
+
+// getInterface gets the interface with the ID of opts, falling back to the only interface
+// whose ID starts with it if --prefix-match is set.
+func getInterface(ctx context.Context, client client.Client, opts GetInterfaceOptions) (*api.Interface, error) {
+	iface, err := client.GetInterface(ctx, opts.ID)
+	if !opts.PrefixMatch || !dynamic.IsNotFound(err) {
+		return iface, err
+	}
+
+	ifaces, listErr := client.ListInterfaces(ctx)
+	if listErr != nil {
+		return &api.Interface{}, fmt.Errorf("error listing interfaces: %w", listErr)
+	}
+	var candidates []string
+	for _, candidate := range ifaces.Items {
+		if strings.HasPrefix(candidate.ID, opts.ID) {
+			candidates = append(candidates, candidate.ID)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return iface, err
+	case 1:
+		return client.GetInterface(ctx, candidates[0])
+	default:
+		sort.Strings(candidates)
+		return &api.Interface{}, fmt.Errorf("interface ID prefix %q is ambiguous, candidates: %s", opts.ID, strings.Join(candidates, ", "))
+	}
+}
+
+// exportInterface writes every object to dw as soon as it was fetched.
+func exportInterface(ctx context.Context, client client.Client, dw interface{ Write(obj any) error }, opts GetInterfaceOptions) error {
+	iface, err := getInterface(ctx, client, opts)
+	if dynamic.IsNotFound(err) {
+		return opts.NotFound(api.InterfaceKind, opts.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting interface: %w", err)
+	}
+
+	if err := dw.Write(iface); err != nil {
+		return err
+	}
+	if !opts.IncludeDependents {
+		return nil
+	}
+
+	vip, err := client.GetVirtualIP(ctx, iface.ID)
+	switch {
+	case err == nil:
+		if err := dw.Write(vip); err != nil {
+			return err
+		}
+	case !dynamic.IsNotFound(err):
+		return fmt.Errorf("error getting virtual ip of interface %s: %w", iface.ID, err)
+	}
+	nat, err := client.GetNat(ctx, iface.ID)
+	switch {
+	case err == nil:
+		if err := dw.Write(nat); err != nil {
+			return err
+		}
+	case !dynamic.IsNotFound(err):
+		return fmt.Errorf("error getting nat of interface %s: %w", iface.ID, err)
+	}
+
+	prefixes, err := client.ListPrefixes(ctx, iface.ID)
+	if err != nil {
+		return fmt.Errorf("error listing prefixes of interface %s: %w", iface.ID, err)
+	}
+	for i := range prefixes.Items {
+		if err := dw.Write(&prefixes.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
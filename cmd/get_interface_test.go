@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type interfaceClient struct {
+	client.Client
+	ids []string
+	got []string
+	// natErr is returned by GetNat, the interfaces have no virtual IP, NAT or prefixes
+	natErr error
+}
+
+func (c *interfaceClient) GetInterface(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	c.got = append(c.got, id)
+	for _, existing := range c.ids {
+		if existing == id {
+			return &api.Interface{TypeMeta: api.TypeMeta{Kind: api.InterfaceKind}, InterfaceMeta: api.InterfaceMeta{ID: id}}, nil
+		}
+	}
+	return &api.Interface{Status: api.Status{Code: apierrors.NO_VM, Message: "NO_VM"}}, apierrors.NewStatusError(apierrors.NO_VM, "NO_VM")
+}
+
+func (c *interfaceClient) ListInterfaces(ctx context.Context, ignoredErrors ...[]uint32) (*api.InterfaceList, error) {
+	list := &api.InterfaceList{}
+	for _, id := range c.ids {
+		list.Items = append(list.Items, api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}})
+	}
+	return list, nil
+}
+
+func (c *interfaceClient) GetVirtualIP(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	return &api.VirtualIP{Status: api.Status{Code: apierrors.SNAT_NO_DATA}}, apierrors.NewStatusError(apierrors.SNAT_NO_DATA, "SNAT_NO_DATA")
+}
+
+func (c *interfaceClient) GetNat(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	return &api.Nat{}, c.natErr
+}
+
+func (c *interfaceClient) ListPrefixes(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error) {
+	return &api.PrefixList{}, nil
+}
+
+var _ = Describe("GetInterface", func() {
+	var c *interfaceClient
+
+	BeforeEach(func() {
+		c = &interfaceClient{ids: []string{"3f2a-0001", "3f2b-0002", "7c00-0003"}}
+	})
+
+	run := func(ctx context.Context, id string, prefixMatch bool) error {
+		return RunGetInterface(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, GetInterfaceOptions{ID: id, PrefixMatch: prefixMatch})
+	}
+
+	It("should get the only interface matching the ID prefix", func(ctx SpecContext) {
+		Expect(run(ctx, "7c", true)).To(Succeed())
+		Expect(c.got).To(Equal([]string{"7c", "7c00-0003"}))
+	})
+
+	It("should list the candidates of an ambiguous prefix", func(ctx SpecContext) {
+		Expect(run(ctx, "3f2", true)).To(MatchError(ContainSubstring(`interface ID prefix "3f2" is ambiguous, candidates: 3f2a-0001, 3f2b-0002`)))
+	})
+
+	It("should only match exactly by default", func(ctx SpecContext) {
+		var notFound *NotFoundError
+		Expect(errors.As(run(ctx, "7c", false), &notFound)).To(BeTrue())
+		Expect(*notFound).To(Equal(NotFoundError{Kind: api.InterfaceKind, Name: "7c"}))
+		Expect(c.got).To(Equal([]string{"7c"}))
+	})
+
+	It("should fail with not found if no ID matches the prefix", func(ctx SpecContext) {
+		var notFound *NotFoundError
+		Expect(errors.As(run(ctx, "ff", true), &notFound)).To(BeTrue())
+		Expect(*notFound).To(Equal(NotFoundError{Kind: api.InterfaceKind, Name: "ff"}))
+	})
+
+	Context("with --export --include-dependents", func() {
+		export := func(ctx context.Context) (string, error) {
+			dir := GinkgoT().TempDir()
+			return dir, RunGetInterface(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, GetInterfaceOptions{ID: "7c00-0003", Export: true, IncludeDependents: true, OutputDir: dir})
+		}
+
+		It("should skip dependents that do not exist", func(ctx SpecContext) {
+			c.natErr = apierrors.NewStatusError(apierrors.SNAT_NO_DATA, "SNAT_NO_DATA")
+			dir, err := export(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.ReadFile(filepath.Join(dir, "interfaces.yaml"))).To(ContainSubstring("id: 7c00-0003"))
+		})
+
+		It("should fail if a dependent cannot be fetched", func(ctx SpecContext) {
+			c.natErr = errors.New("connection reset")
+			_, err := export(ctx)
+			Expect(err).To(MatchError("error getting nat of interface 7c00-0003: connection reset"))
+		})
+	})
+})
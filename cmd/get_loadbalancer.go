@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
 	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -44,10 +46,12 @@ func GetLoadBalancer(dpdkClientFactory DPDKClientFactory, rendererFactory Render
 
 type GetLoadBalancerOptions struct {
 	ID string
+	NotFoundOptions
 }
 
 func (o *GetLoadBalancerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.ID, "id", o.ID, "ID of the LoadBalancer.")
+	o.NotFoundOptions.AddFlags(fs)
 }
 
 func (o *GetLoadBalancerOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -72,6 +76,9 @@ func RunGetLoadBalancer(
 	defer DpdkClose(cleanup)
 
 	lb, err := client.GetLoadBalancer(ctx, opts.ID)
+	if dynamic.IsNotFound(err) {
+		return opts.NotFound(api.LoadBalancerKind, opts.ID)
+	}
 	if err != nil && lb.Status.Code == 0 {
 		return fmt.Errorf("error getting loadbalancer: %w", err)
 	}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/ironcore-dev/dpservice-go/api"
 	"github.com/spf13/cobra"
@@ -45,10 +46,12 @@ func GetNat(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory
 
 type GetNatOptions struct {
 	InterfaceID string
+	NotFoundOptions
 }
 
 func (o *GetNatOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.InterfaceID, "interface-id", o.InterfaceID, "Interface ID of the NAT.")
+	o.NotFoundOptions.AddFlags(fs)
 }
 
 func (o *GetNatOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -87,6 +90,9 @@ func RunGetNat(
 	}
 
 	nat, err := client.GetNat(ctx, opts.InterfaceID)
+	if dynamic.IsNotFound(err) {
+		return opts.NotFound(api.NatKind, opts.InterfaceID)
+	}
 	if err != nil && nat.Status.Code == 0 {
 		return fmt.Errorf("error getting nat: %w", err)
 	}
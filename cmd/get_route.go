@@ -4,24 +4,42 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
+	"github.com/ironcore-dev/dpservice-cli/flag"
 	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func GetRoute(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory) *cobra.Command {
 	var (
-		opts ListRoutesOptions
+		opts GetRouteOptions
 	)
 
 	cmd := &cobra.Command{
-		Use:     "route <--vni>",
-		Short:   "List routes of specified VNI",
-		Example: "dpservice-cli get route --vni=100",
+		Use:     "route <--vni> [--prefix] [--next-hop-vni] [--next-hop-ip]",
+		Short:   "Get the route of a prefix or list routes of specified VNI",
+		Example: "dpservice-cli get route --vni=100 --prefix=10.100.3.0/24",
 		Aliases: RouteAliases,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.FilterByNextHopVNI = cmd.Flags().Changed("next-hop-vni")
+			if !opts.Prefix.IsValid() {
+				return RunGetRoute(
+					cmd.Context(),
+					dpdkClientFactory,
+					rendererFactory,
+					opts.ListRoutesOptions,
+				)
+			}
 
-			return RunGetRoute(
+			return RunGetRouteByPrefix(
 				cmd.Context(),
 				dpdkClientFactory,
 				rendererFactory,
@@ -36,3 +54,79 @@ func GetRoute(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFacto
 
 	return cmd
 }
+
+type GetRouteOptions struct {
+	ListRoutesOptions
+	Prefix     netip.Prefix
+	NextHopVNI uint32
+	// FilterByNextHopVNI is only set if --next-hop-vni was given, so that VNI 0 can be selected explicitly.
+	FilterByNextHopVNI bool
+	NextHopIP          netip.Addr
+	NotFoundOptions
+}
+
+func (o *GetRouteOptions) AddFlags(fs *pflag.FlagSet) {
+	o.ListRoutesOptions.AddFlags(fs)
+	flag.PrefixVar(fs, &o.Prefix, "prefix", o.Prefix, "Prefix of the route to get, instead of listing all routes of the VNI.")
+	fs.Uint32Var(&o.NextHopVNI, "next-hop-vni", o.NextHopVNI, "Only get the route of --prefix with this next hop VNI.")
+	flag.AddrVar(fs, &o.NextHopIP, "next-hop-ip", o.NextHopIP, "Only get the route of --prefix with this next hop IP.")
+	o.NotFoundOptions.AddFlags(fs)
+}
+
+// matches reports whether route has the prefix and next hop selected by the options.
+func (o *GetRouteOptions) matches(route *api.Route) bool {
+	if route.Spec.Prefix == nil || route.Spec.Prefix.Masked() != o.Prefix.Masked() {
+		return false
+	}
+	nextHop := route.Spec.NextHop
+	if o.FilterByNextHopVNI && (nextHop == nil || nextHop.VNI != o.NextHopVNI) {
+		return false
+	}
+	if o.NextHopIP.IsValid() && (nextHop == nil || nextHop.IP == nil || *nextHop.IP != o.NextHopIP) {
+		return false
+	}
+	return true
+}
+
+// RunGetRouteByPrefix renders the route of opts.Prefix in opts.VNI. dpservice has no call
+// to get a single route, so it is looked up in the routes of the VNI. If several (ECMP) routes
+// with different next hops match, all of them are rendered as a list.
+func RunGetRouteByPrefix(
+	ctx context.Context,
+	dpdkClientFactory DPDKClientFactory,
+	rendererFactory RendererFactory,
+	opts GetRouteOptions,
+) error {
+	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dpdk client: %w", err)
+	}
+	defer DpdkClose(cleanup)
+
+	routes, err := client.ListRoutes(ctx, opts.VNI)
+	if err != nil && !dynamic.IsNotFound(err) {
+		return fmt.Errorf("error listing routes: %w", err)
+	}
+
+	var matches []api.Route
+	if routes != nil {
+		for i := range routes.Items {
+			if opts.matches(&routes.Items[i]) {
+				matches = append(matches, routes.Items[i])
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return opts.NotFound(api.RouteKind, fmt.Sprintf("%s in VNI %d", opts.Prefix.Masked(), opts.VNI))
+	case 1:
+		return rendererFactory.RenderObject("", os.Stdout, &matches[0])
+	default:
+		return rendererFactory.RenderList("", os.Stdout, &api.RouteList{
+			TypeMeta:      api.TypeMeta{Kind: api.RouteListKind},
+			RouteListMeta: api.RouteListMeta{VNI: opts.VNI},
+			Items:         matches,
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func (c *routeClient) ListRoutes(ctx context.Context, vni uint32, ignoredErrors ...[]uint32) (*api.RouteList, error) {
+	return &api.RouteList{TypeMeta: api.TypeMeta{Kind: api.RouteListKind}, Items: c.routes}, nil
+}
+
+var _ = Describe("GetRoute", func() {
+	var (
+		c   *routeClient
+		out string
+	)
+
+	BeforeEach(func() {
+		route := func(prefix string, nextHopVNI uint32) api.Route {
+			p := netip.MustParsePrefix(prefix)
+			return api.Route{
+				TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+				RouteMeta: api.RouteMeta{VNI: 100},
+				Spec:      api.RouteSpec{Prefix: &p, NextHop: &api.RouteNextHop{VNI: nextHopVNI}},
+			}
+		}
+		c = &routeClient{routes: []api.Route{route("10.0.0.0/24", 200), route("10.0.1.0/24", 300), route("10.0.2.0/24", 400), route("10.0.2.0/24", 401)}}
+
+		out = captureOutput(&os.Stdout)
+	})
+
+	run := func(ctx context.Context, output string, args ...string) error {
+		cmd := GetRoute(fakeClientFactory{c}, &RendererOptions{Output: output})
+		cmd.SetArgs(append([]string{"--vni=100"}, args...))
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return cmd.ExecuteContext(ctx)
+	}
+
+	It("should get the route of the prefix", func(ctx SpecContext) {
+		Expect(run(ctx, "jsonpath={.spec.next_hop.vni}", "--prefix=10.0.1.0/24")).To(Succeed())
+		Expect(os.ReadFile(out)).To(BeEquivalentTo("300\n"))
+	})
+
+	It("should get all routes of the prefix with different next hops", func(ctx SpecContext) {
+		Expect(run(ctx, "jsonpath={.items[*].spec.next_hop.vni}", "--prefix=10.0.2.0/24")).To(Succeed())
+		Expect(os.ReadFile(out)).To(BeEquivalentTo("400 401\n"))
+	})
+
+	It("should filter the routes of the prefix by next hop", func(ctx SpecContext) {
+		Expect(run(ctx, "jsonpath={.spec.next_hop.vni}", "--prefix=10.0.2.0/24", "--next-hop-vni=401")).To(Succeed())
+		Expect(os.ReadFile(out)).To(BeEquivalentTo("401\n"))
+	})
+
+	It("should report a missing route as not found", func(ctx SpecContext) {
+		err := run(ctx, "jsonpath={.spec.next_hop.vni}", "--prefix=10.0.3.0/24")
+		Expect(err).To(MatchError("Route 10.0.3.0/24 in VNI 100 not found"))
+		var notFound *NotFoundError
+		Expect(errors.As(err, &notFound)).To(BeTrue())
+
+		Expect(run(ctx, "jsonpath={.spec.next_hop.vni}", "--prefix=10.0.3.0/24", "--ignore-not-found")).To(Succeed())
+		Expect(run(ctx, "jsonpath={.spec.next_hop.vni}", "--prefix=10.0.2.0/24", "--next-hop-vni=402")).To(MatchError("Route 10.0.2.0/24 in VNI 100 not found"))
+	})
+})
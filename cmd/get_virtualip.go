@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/ironcore-dev/dpservice-go/api"
 	"github.com/ironcore-dev/dpservice-go/errors"
@@ -46,10 +47,12 @@ func GetVirtualIP(dpdkClientFactory DPDKClientFactory, rendererFactory RendererF
 
 type GetVirtualIPOptions struct {
 	InterfaceID string
+	NotFoundOptions
 }
 
 func (o *GetVirtualIPOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.InterfaceID, "interface-id", o.InterfaceID, "Interface ID of the Virtual IP.")
+	o.NotFoundOptions.AddFlags(fs)
 }
 
 func (o *GetVirtualIPOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -105,6 +108,9 @@ func RunGetVirtualIP(
 	}
 
 	virtualIP, err := client.GetVirtualIP(ctx, opts.InterfaceID)
+	if dynamic.IsNotFound(err) {
+		return opts.NotFound(api.VirtualIPKind, opts.InterfaceID)
+	}
 	if err != nil && virtualIP.Status.Code == 0 {
 		return fmt.Errorf("error getting virtual ip: %w", err)
 	}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func Graph(dpdkClientFactory DPDKClientFactory) *cobra.Command {
+	var (
+		opts GraphOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:     "graph [--loadbalancer-id]",
+		Short:   "Print the VNIs, interfaces, routes and loadbalancers as a Graphviz DOT graph",
+		Example: "dpservice-cli graph --loadbalancer-id=lb1 | dot -Tsvg > topology.svg",
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGraph(cmd.Context(), os.Stdout, dpdkClientFactory, opts)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+type GraphOptions struct {
+	LoadBalancerIDs []string
+}
+
+func (o *GraphOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&o.LoadBalancerIDs, "loadbalancer-id", o.LoadBalancerIDs, "Loadbalancers to include (repeatable). dpservice cannot list loadbalancers, so only these are shown.")
+}
+
+func RunGraph(ctx context.Context, w io.Writer, dpdkClientFactory DPDKClientFactory, opts GraphOptions) error {
+	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dpdk client: %w", err)
+	}
+	defer DpdkClose(cleanup)
+
+	topology, err := FetchTopology(ctx, client, opts.LoadBalancerIDs)
+	if err != nil {
+		return err
+	}
+	return renderer.WriteDOT(w, *topology)
+}
+
+// FetchTopology lists the interfaces, the routes of their VNIs and the given loadbalancers
+// with their targets. The routes and loadbalancers are fetched concurrently.
+func FetchTopology(ctx context.Context, client client.Client, loadBalancerIDs []string) (*renderer.Topology, error) {
+	ifaces, err := client.ListInterfaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing interfaces: %w", err)
+	}
+	topology := &renderer.Topology{Interfaces: ifaces.Items}
+
+	lbs := make([]renderer.TopologyLoadBalancer, len(loadBalancerIDs))
+	if err := util.ForEachBounded(len(loadBalancerIDs), auditConcurrency, func(i int) error {
+		lb, err := client.GetLoadBalancer(ctx, loadBalancerIDs[i])
+		if err != nil {
+			return fmt.Errorf("error getting loadbalancer %s: %w", loadBalancerIDs[i], err)
+		}
+		targets, err := client.ListLoadBalancerTargets(ctx, loadBalancerIDs[i])
+		if err != nil {
+			return fmt.Errorf("error listing targets of loadbalancer %s: %w", loadBalancerIDs[i], err)
+		}
+		lbs[i] = renderer.TopologyLoadBalancer{LoadBalancer: *lb, Targets: targets.Items}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	topology.LoadBalancers = lbs
+
+	vniSet := make(map[uint32]struct{})
+	for _, iface := range ifaces.Items {
+		vniSet[iface.Spec.VNI] = struct{}{}
+	}
+	for _, lb := range lbs {
+		vniSet[lb.LoadBalancer.Spec.VNI] = struct{}{}
+	}
+	vnis := make([]uint32, 0, len(vniSet))
+	for vni := range vniSet {
+		vnis = append(vnis, vni)
+	}
+	sort.Slice(vnis, func(i, j int) bool { return vnis[i] < vnis[j] })
+
+	routes := make([][]api.Route, len(vnis))
+	if err := util.ForEachBounded(len(vnis), auditConcurrency, func(i int) error {
+		list, err := client.ListRoutes(ctx, vnis[i])
+		if err != nil {
+			return fmt.Errorf("error listing routes of vni %d: %w", vnis[i], err)
+		}
+		routes[i] = list.Items
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for _, r := range routes {
+		topology.Routes = append(topology.Routes, r...)
+	}
+
+	return topology, nil
+}
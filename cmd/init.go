@@ -21,10 +21,11 @@ func Init(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory)
 	)
 
 	cmd := &cobra.Command{
-		Use:     "init",
-		Short:   "Initial set up of the DPDK app",
-		Example: "dpservice-cli init",
-		Args:    cobra.ExactArgs(0),
+		Use:         "init",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Short:       "Initial set up of the DPDK app",
+		Example:     "dpservice-cli init",
+		Args:        cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			return RunInit(
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	IntentRead  = "read"
+	IntentWrite = "write"
+
+	// IntentMetadataKey is the gRPC metadata key the declared intent is sent with.
+	IntentMetadataKey = "x-dpservice-cli-intent"
+
+	// AnnotationMutating marks commands (and their subcommands) that change the state of dpservice.
+	AnnotationMutating = "dpservice-cli/mutating"
+)
+
+// previewFlags make a mutating command only show what it would do.
+var previewFlags = []string{"plan", "dry-run"}
+
+// IsMutating reports whether running cmd changes the state of dpservice.
+func IsMutating(cmd *cobra.Command) bool {
+	for _, name := range previewFlags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed && f.Value.String() != "false" && f.Value.String() != "" {
+			return false
+		}
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[AnnotationMutating] == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateIntent rejects running a mutating command with --intent=read.
+func ValidateIntent(cmd *cobra.Command, intent string) error {
+	switch intent {
+	case "", IntentWrite:
+		return nil
+	case IntentRead:
+		if IsMutating(cmd) {
+			return fmt.Errorf("%s changes dpservice and cannot be run with --intent=%s", cmd.CommandPath(), IntentRead)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid --intent %q, must be %s or %s", intent, IntentRead, IntentWrite)
+	}
+}
+
+// IntentInterceptor sends the declared intent with every call. With the read intent it
+// also rejects calls that are not reads, before they reach dpservice.
+type IntentInterceptor struct {
+	Intent string
+}
+
+func (i IntentInterceptor) UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if i.Intent == IntentRead && !IsReadMethod(method) {
+		return status.Errorf(codes.PermissionDenied, "%s is not allowed with --intent=%s", method, IntentRead)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, IntentMetadataKey, i.Intent)
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Intent", func() {
+	It("should reject mutating commands with the read intent", func() {
+		root := Command()
+		root.SetArgs([]string{"--intent=read", "delete", "interface", "--id=vm1"})
+		Expect(root.Execute()).To(MatchError(ContainSubstring("cannot be run with --intent=read")))
+	})
+
+	It("should tell mutating commands apart", func() {
+		root := Command()
+		for args, mutating := range map[string]bool{
+			"create interface": true,
+			"capture stop":     true,
+			"capture status":   false,
+			"list interfaces":  false,
+			"apply":            true,
+		} {
+			cmd, _, err := root.Find(strings.Fields(args))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(IsMutating(cmd)).To(Equal(mutating), args)
+		}
+
+		apply, _, err := root.Find([]string{"apply"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(apply.Flags().Set("plan", "true")).To(Succeed())
+		Expect(IsMutating(apply)).To(BeFalse())
+	})
+
+	It("should send the intent and reject writes with the read intent", func(ctx SpecContext) {
+		var sent []string
+		invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			sent = md.Get(IntentMetadataKey)
+			return nil
+		}
+		interceptor := IntentInterceptor{Intent: IntentRead}
+
+		Expect(interceptor.UnaryClientInterceptor(ctx, "/dpdkironcore.v1.DPDKironcore/ListInterfaces", nil, nil, nil, invoker)).To(Succeed())
+		Expect(sent).To(Equal([]string{IntentRead}))
+
+		err := interceptor.UnaryClientInterceptor(ctx, "/dpdkironcore.v1.DPDKironcore/DeleteInterface", nil, nil, nil, invoker)
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	})
+})
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+)
+
+const (
+	IPVersion4 = "ipv4"
+	IPVersion6 = "ipv6"
+)
+
+// ParseIPVersion parses an --ip-version value.
+func ParseIPVersion(version string) (dpdkproto.IpVersion, error) {
+	switch version {
+	case IPVersion4:
+		return dpdkproto.IpVersion_IPV4, nil
+	case IPVersion6:
+		return dpdkproto.IpVersion_IPV6, nil
+	default:
+		return 0, fmt.Errorf("invalid ip version %q, must be %s or %s", version, IPVersion4, IPVersion6)
+	}
+}
+
+// CheckIPVersion rejects a version that differs from the one detected for addr.
+func CheckIPVersion(addr netip.Addr, version dpdkproto.IpVersion) error {
+	if detected := api.NetIPAddrToProtoIPVersion(&addr); detected != version {
+		return fmt.Errorf("ip version %s does not match the %s address %s, use --force to send it anyway", version, detected, addr)
+	}
+	return nil
+}
+
+// LessAddr orders addresses of the preferred IP version before the others and
+// addresses of the same version in address order.
+func LessAddr(a, b netip.Addr, preferIPv6 bool) bool {
+	if a.Is6() != b.Is6() {
+		return a.Is6() == preferIPv6
+	}
+	return a.Compare(b) < 0
+}
+
+type ipVersionKey struct{}
+
+// WithIPVersionOverride makes IPVersionInterceptor send version instead of the detected
+// IP version for the calls made with the returned context.
+func WithIPVersionOverride(ctx context.Context, version dpdkproto.IpVersion) context.Context {
+	return context.WithValue(ctx, ipVersionKey{}, version)
+}
+
+// IPVersionInterceptor overrides the IP version of virtual IPs created with a context of
+// WithIPVersionOverride. dpservice-go always derives it from the address.
+func IPVersionInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if version, ok := ctx.Value(ipVersionKey{}).(dpdkproto.IpVersion); ok {
+		if req, ok := req.(*dpdkproto.CreateVipRequest); ok && req.VipIp != nil {
+			req.VipIp.Ipver = version
+		}
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"net/netip"
+	"sort"
+	"strings"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+var _ = Describe("IPVersion", func() {
+	const createVip = "/dpdkironcore.v1.DPDKironcore/CreateVip"
+
+	newRequest := func(addr string) *dpdkproto.CreateVipRequest {
+		ip := netip.MustParseAddr(addr)
+		return &dpdkproto.CreateVipRequest{VipIp: api.NetIPAddrToProtoIpAddress(&ip)}
+	}
+
+	var sent dpdkproto.IpVersion
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		sent = req.(*dpdkproto.CreateVipRequest).VipIp.Ipver
+		return nil
+	}
+
+	It("should keep the detected version by default", func(ctx SpecContext) {
+		Expect(IPVersionInterceptor(ctx, createVip, newRequest("::ffff:10.0.0.1"), nil, nil, invoker)).To(Succeed())
+		Expect(sent).To(Equal(dpdkproto.IpVersion_IPV6))
+	})
+
+	It("should send the overridden version", func(ctx SpecContext) {
+		ctx2 := WithIPVersionOverride(ctx, dpdkproto.IpVersion_IPV4)
+		Expect(IPVersionInterceptor(ctx2, createVip, newRequest("::ffff:10.0.0.1"), nil, nil, invoker)).To(Succeed())
+		Expect(sent).To(Equal(dpdkproto.IpVersion_IPV4))
+	})
+
+	It("should check that the version matches the address unless forced", func() {
+		opts := CreateVirtualIPOptions{Vip: netip.MustParseAddr("::ffff:10.0.0.1"), IPVersion: IPVersion4}
+		Expect(opts.Validate()).To(MatchError(ContainSubstring("does not match the IPV6 address ::ffff:10.0.0.1")))
+
+		opts.Force = true
+		Expect(opts.Validate()).To(Succeed())
+
+		opts = CreateVirtualIPOptions{Vip: netip.MustParseAddr("10.0.0.1"), IPVersion: IPVersion4}
+		Expect(opts.Validate()).To(Succeed())
+
+		opts.IPVersion = "ip4"
+		Expect(opts.Validate()).To(MatchError(ContainSubstring(`invalid ip version "ip4"`)))
+	})
+})
+
+var _ = Describe("PreferIPVersion", func() {
+	It("should sort addresses of the preferred version first", func() {
+		addrs := []netip.Addr{netip.MustParseAddr("fc00::2"), netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("fc00::1"), netip.MustParseAddr("10.0.0.1")}
+		sort.SliceStable(addrs, func(i, j int) bool { return LessAddr(addrs[i], addrs[j], false) })
+		Expect(addrs).To(Equal([]netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("fc00::1"), netip.MustParseAddr("fc00::2")}))
+
+		sort.SliceStable(addrs, func(i, j int) bool { return LessAddr(addrs[i], addrs[j], true) })
+		Expect(addrs).To(Equal([]netip.Addr{netip.MustParseAddr("fc00::1"), netip.MustParseAddr("fc00::2"), netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")}))
+	})
+
+	It("should sort addresses of the same version in address order", func() {
+		addrs := []netip.Addr{netip.MustParseAddr("10.0.0.10"), netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("fc00::a"), netip.MustParseAddr("fc00::9")}
+		sort.SliceStable(addrs, func(i, j int) bool { return LessAddr(addrs[i], addrs[j], false) })
+		Expect(addrs).To(Equal([]netip.Addr{netip.MustParseAddr("10.0.0.2"), netip.MustParseAddr("10.0.0.10"), netip.MustParseAddr("fc00::9"), netip.MustParseAddr("fc00::a")}))
+	})
+
+	It("should show the IPv6 column first when IPv6 is preferred", func() {
+		DeferCleanup(renderer.DefaultTableConverter.SetPreferIPv6, false)
+		ipv4 := netip.MustParseAddr("10.0.0.1")
+		ipv6 := netip.MustParseAddr("fc00::1")
+		list := &api.InterfaceList{Items: []api.Interface{{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{IPv4: &ipv4, IPv6: &ipv6, Metering: &api.MeteringParams{}}}}}
+
+		header := func(preferIPVersion string) string {
+			var buf bytes.Buffer
+			Expect((&RendererOptions{Output: "table", PreferIPVersion: preferIPVersion}).RenderList("", &buf, list)).To(Succeed())
+			return strings.SplitN(buf.String(), "\n", 2)[0]
+		}
+		Expect(header(IPVersion4)).To(MatchRegexp(`IPv4\s+IPv6`))
+		Expect(header(IPVersion6)).To(MatchRegexp(`IPv6\s+IPv4`))
+
+		Expect((&RendererOptions{Output: "table", PreferIPVersion: "ip6"}).RenderList("", &bytes.Buffer{}, list)).To(MatchError(ContainSubstring("invalid --prefer-ip-version")))
+	})
+})
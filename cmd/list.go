@@ -11,6 +11,8 @@ import (
 
 func List(factory DPDKClientFactory) *cobra.Command {
 	rendererOptions := &RendererOptions{Output: "table"}
+	watchOptions := &ListWatchOptions{}
+	watchFactory := &listWatchClientFactory{DPDKClientFactory: factory, opts: watchOptions}
 
 	cmd := &cobra.Command{
 		Use:  "list [command]",
@@ -19,17 +21,20 @@ func List(factory DPDKClientFactory) *cobra.Command {
 	}
 
 	rendererOptions.AddFlags(cmd.PersistentFlags())
+	watchOptions.AddFlags(cmd.PersistentFlags())
 
 	subcommands := []*cobra.Command{
-		ListFirewallRules(factory, rendererOptions),
-		ListInterfaces(factory, rendererOptions),
-		ListPrefixes(factory, rendererOptions),
-		ListLoadBalancerPrefixes(factory, rendererOptions),
-		ListRoutes(factory, rendererOptions),
-		ListLoadBalancerTargets(factory, rendererOptions),
-		ListNats(factory, rendererOptions),
+		ListFirewallRules(watchFactory, rendererOptions),
+		ListInterfaces(watchFactory, rendererOptions),
+		ListPrefixes(watchFactory, rendererOptions),
+		ListLoadBalancerPrefixes(watchFactory, rendererOptions),
+		ListRoutes(watchFactory, rendererOptions),
+		ListLoadBalancerTargets(watchFactory, rendererOptions),
+		ListNats(watchFactory, rendererOptions),
 	}
 
+	watchListCommands(subcommands, rendererOptions, watchFactory)
+
 	cmd.Short = fmt.Sprintf("Lists one of %v", CommandNames(subcommands))
 	cmd.Long = fmt.Sprintf("Lists one of %v", CommandNames(subcommands))
 
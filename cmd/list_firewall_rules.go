@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"sort"
 	"strings"
 
 	"github.com/ironcore-dev/dpservice-cli/util"
@@ -47,17 +46,26 @@ func ListFirewallRules(dpdkClientFactory DPDKClientFactory, rendererFactory Rend
 type ListFirewallRulesOptions struct {
 	InterfaceID string
 	SortBy      string
+	SkipErrors  bool
+	PageOptions
+	EmptyListOptions
 }
 
 func (o *ListFirewallRulesOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.InterfaceID, "interface-id", o.InterfaceID, "InterfaceID from which to list firewall rules.")
-	fs.StringVar(&o.SortBy, "sort-by", "", "Column to sort by.")
+	fs.StringVar(&o.SortBy, "sort-by", "", "Table column to sort by, addresses and prefixes are sorted in address order. source and destination are aliases of src and dst.")
+	fs.BoolVar(&o.SkipErrors, "skip-errors", o.SkipErrors, "Continue listing other interfaces if one of them fails and print a summary of failures.")
+	o.PageOptions.AddFlags(fs)
+	o.EmptyListOptions.AddFlags(fs)
 }
 
 func (o *ListFirewallRulesOptions) MarkRequiredFlags(cmd *cobra.Command) error {
 	return nil
 }
 
+// firewallRuleSortAliases maps the --sort-by names of earlier versions to the table columns.
+var firewallRuleSortAliases = map[string]string{"source": "Src", "destination": "Dst"}
+
 func RunListFirewallRules(
 	ctx context.Context,
 	dpdkClientFactory DPDKClientFactory,
@@ -73,6 +81,7 @@ func RunListFirewallRules(
 	fwruleList := &api.FirewallRuleList{
 		TypeMeta: api.TypeMeta{Kind: api.FirewallRuleListKind},
 	}
+	sourceErrs := &SourceErrors{}
 	if opts.InterfaceID == "" {
 		ifaces, err := client.ListInterfaces(ctx)
 		if err != nil && ifaces.Status.Code == 0 {
@@ -81,6 +90,10 @@ func RunListFirewallRules(
 
 		for _, iface := range ifaces.Items {
 			fwrule, err := client.ListFirewallRules(ctx, iface.ID)
+			if err != nil && opts.SkipErrors {
+				sourceErrs.Add("interface/"+iface.ID, err)
+				continue
+			}
 			if err != nil && fwrule.Status.Code == 0 {
 				return fmt.Errorf("error getting firewall rules: %w", err)
 			}
@@ -95,26 +108,20 @@ func RunListFirewallRules(
 
 	// sort items in list
 	fwrules := fwruleList.Items
-	sort.SliceStable(fwrules, func(i, j int) bool {
-		mi, mj := fwrules[i], fwrules[j]
-		switch strings.ToLower(opts.SortBy) {
-		case "direction":
-			return mi.Spec.TrafficDirection < mj.Spec.TrafficDirection
-		case "src", "source":
-			return mi.Spec.SourcePrefix.String() < mj.Spec.SourcePrefix.String()
-		case "dst", "destination":
-			return mi.Spec.DestinationPrefix.String() < mj.Spec.DestinationPrefix.String()
-		case "action":
-			return mi.Spec.FirewallAction < mj.Spec.FirewallAction
-		case "protocol":
-			return mi.Spec.ProtocolFilter.String() < mj.Spec.ProtocolFilter.String()
-		case "priority":
-			return mi.Spec.Priority < mj.Spec.Priority
-		default:
-			return mi.Spec.RuleID < mj.Spec.RuleID
-		}
-	})
-	fwruleList.Items = fwrules
+	sortBy := opts.SortBy
+	if column, ok := firewallRuleSortAliases[strings.ToLower(sortBy)]; ok {
+		sortBy = column
+	}
+	if err := SortItems(fwruleList, fwrules, rendererFactory.GetPreferIPv6(), sortBy, "RuleID"); err != nil {
+		return err
+	}
+	fwruleList.Items = Paginate(os.Stderr, fwrules, opts.PageOptions)
 
-	return rendererFactory.RenderList("", os.Stdout, fwruleList)
+	if err := rendererFactory.RenderList("", os.Stdout, fwruleList); err != nil {
+		return err
+	}
+	if err := sourceErrs.Summarize(os.Stderr); err != nil {
+		return err
+	}
+	return opts.CheckEmpty(fwruleList)
 }
@@ -7,10 +7,9 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"sort"
-	"strings"
 
 	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -23,10 +22,15 @@ func ListInterfaces(dpdkClientFactory DPDKClientFactory, rendererFactory Rendere
 	cmd := &cobra.Command{
 		Use:     "interfaces",
 		Short:   "List all interfaces",
-		Example: "dpservice-cli list interfaces",
+		Example: "dpservice-cli list interfaces --vni=100\ndpservice-cli list interfaces --vni-min=1000 --vni-max=1099",
 		Aliases: InterfaceAliases,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Complete(cmd.Flags())
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+
 			return RunListInterfaces(
 				cmd.Context(),
 				dpdkClientFactory,
@@ -39,16 +43,57 @@ func ListInterfaces(dpdkClientFactory DPDKClientFactory, rendererFactory Rendere
 	opts.AddFlags(cmd.Flags())
 
 	util.Must(opts.MarkRequiredFlags(cmd))
+	cmd.MarkFlagsMutuallyExclusive("audit", "with-fw-count")
+	cmd.MarkFlagsMutuallyExclusive("vni", "vni-min")
+	cmd.MarkFlagsMutuallyExclusive("vni", "vni-max")
 
 	return cmd
 }
 
 type ListInterfacesOptions struct {
 	SortBy string
+	VNI    uint32
+	// FilterByVNI is only set if --vni was given, so that VNI 0 can be selected explicitly.
+	FilterByVNI bool
+	// FilterByRange is only set if --vni-min or --vni-max was given, so that zero-value
+	// options do not select VNI 0 only.
+	FilterByRange bool
+	Audit         bool
+	WithFWCount   bool
+	VNIRangeOptions
+	PageOptions
+	EmptyListOptions
 }
 
 func (o *ListInterfacesOptions) AddFlags(fs *pflag.FlagSet) {
-	fs.StringVar(&o.SortBy, "sort-by", "", "Column to sort by.")
+	fs.StringVar(&o.SortBy, "sort-by", "", "Table column to sort by, addresses and prefixes are sorted in address order.")
+	fs.Uint32Var(&o.VNI, "vni", o.VNI, "Only list interfaces in this VNI.")
+	fs.BoolVar(&o.Audit, "audit", o.Audit, "Annotate each interface with configuration warnings (no virtual IP, no routes to its underlay route, only interface in its VNI).")
+	fs.BoolVar(&o.WithFWCount, "with-fw-count", o.WithFWCount, "Add a column with the number of firewall rules of each interface.")
+	o.VNIRangeOptions.AddFlags(fs)
+	o.PageOptions.AddFlags(fs)
+	o.EmptyListOptions.AddFlags(fs)
+}
+
+// Complete sets the options that depend on whether a flag was given at all.
+func (o *ListInterfacesOptions) Complete(fs *pflag.FlagSet) {
+	o.FilterByVNI = fs.Changed("vni")
+	o.FilterByRange = fs.Changed("vni-min") || fs.Changed("vni-max")
+}
+
+// Filter returns the interfaces matching the filters of the options.
+func (o *ListInterfacesOptions) Filter(ifaces []api.Interface) []api.Interface {
+	filtered := make([]api.Interface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		if o.FilterByVNI && iface.Spec.VNI != o.VNI {
+			continue
+		}
+		if o.FilterByRange && !o.Contains(iface.Spec.VNI) {
+			continue
+		}
+		filtered = append(filtered, iface)
+	}
+	return filtered
 }
 
 func (o *ListInterfacesOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -71,6 +116,8 @@ func RunListInterfaces(
 	if err != nil {
 		return fmt.Errorf("error listing interfaces: %w", err)
 	}
+	all := interfaceList.Items
+	interfaceList.Items = opts.Filter(interfaceList.Items)
 
 	if rendererFactory.GetWide() {
 		for i, iface := range interfaceList.Items {
@@ -88,30 +135,35 @@ func RunListInterfaces(
 	}
 	// sort items in list
 	interfaces := interfaceList.Items
-	sort.SliceStable(interfaces, func(i, j int) bool {
-		mi, mj := interfaces[i], interfaces[j]
-		switch strings.ToLower(opts.SortBy) {
-		case "vni":
-			if mi.Spec.VNI != mj.Spec.VNI {
-				return mi.Spec.VNI < mj.Spec.VNI
-			}
-			return mi.Spec.IPv4.String() < mj.Spec.IPv4.String()
-		case "device":
-			return mi.Spec.Device < mj.Spec.Device
-		case "ipv4":
-			if mi.Spec.IPv4.String() != mj.Spec.IPv4.String() {
-				return mi.Spec.IPv4.String() < mj.Spec.IPv4.String()
-			}
-			return mi.Spec.VNI < mj.Spec.VNI
-		case "ipv6":
-			return mi.Spec.IPv6.String() < mj.Spec.IPv6.String()
-		case "underlayroute":
-			return mi.Spec.UnderlayRoute.String() < mj.Spec.UnderlayRoute.String()
-		default:
-			return mi.ID < mj.ID
+	if err := SortItems(interfaceList, interfaces, rendererFactory.GetPreferIPv6(), opts.SortBy, "ID"); err != nil {
+		return err
+	}
+	interfaceList.Items = Paginate(os.Stderr, interfaces, opts.PageOptions)
+
+	if opts.WithFWCount {
+		counted, err := CountFirewallRules(ctx, client, interfaceList.Items)
+		if err != nil {
+			return fmt.Errorf("error counting firewall rules: %w", err)
+		}
+		if err := rendererFactory.RenderList("", os.Stdout, counted); err != nil {
+			return err
+		}
+		return opts.CheckEmpty(counted)
+	}
+
+	if opts.Audit {
+		audited, err := AuditInterfaces(ctx, client, interfaceList.Items, all)
+		if err != nil {
+			return fmt.Errorf("error auditing interfaces: %w", err)
+		}
+		if err := rendererFactory.RenderList("", os.Stdout, audited); err != nil {
+			return err
 		}
-	})
-	interfaceList.Items = interfaces
+		return opts.CheckEmpty(audited)
+	}
 
-	return rendererFactory.RenderList("", os.Stdout, interfaceList)
+	if err := rendererFactory.RenderList("", os.Stdout, interfaceList); err != nil {
+		return err
+	}
+	return opts.CheckEmpty(interfaceList)
 }
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("ListInterfacesOptions", func() {
+	var (
+		opts   ListInterfacesOptions
+		fs     *pflag.FlagSet
+		ifaces []api.Interface
+	)
+	BeforeEach(func() {
+		opts = ListInterfacesOptions{}
+		fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		opts.AddFlags(fs)
+		ifaces = []api.Interface{
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm0"}, Spec: api.InterfaceSpec{VNI: 0}},
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 100}},
+		}
+	})
+
+	It("should not filter if --vni is unset", func() {
+		Expect(fs.Parse(nil)).To(Succeed())
+		opts.Complete(fs)
+
+		Expect(opts.Filter(ifaces)).To(HaveLen(2))
+	})
+
+	It("should not filter with zero-value options", func() {
+		opts = ListInterfacesOptions{}
+
+		Expect(opts.Filter(ifaces)).To(HaveLen(2))
+	})
+
+	It("should filter for VNI 0 if --vni=0 is given explicitly", func() {
+		Expect(fs.Parse([]string{"--vni=0"})).To(Succeed())
+		opts.Complete(fs)
+
+		filtered := opts.Filter(ifaces)
+		Expect(filtered).To(HaveLen(1))
+		Expect(filtered[0].ID).To(Equal("vm0"))
+	})
+
+	It("should filter for a non-zero VNI", func() {
+		Expect(fs.Parse([]string{"--vni=100"})).To(Succeed())
+		opts.Complete(fs)
+
+		filtered := opts.Filter(ifaces)
+		Expect(filtered).To(HaveLen(1))
+		Expect(filtered[0].ID).To(Equal("vm1"))
+	})
+
+	It("should filter for an inclusive VNI range", func() {
+		Expect(fs.Parse([]string{"--vni-min=1000", "--vni-max=1099"})).To(Succeed())
+		opts.Complete(fs)
+		Expect(opts.Validate()).To(Succeed())
+
+		ifaces = []api.Interface{
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm0"}, Spec: api.InterfaceSpec{VNI: 999}},
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 1000}},
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm2"}, Spec: api.InterfaceSpec{VNI: 1050}},
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm3"}, Spec: api.InterfaceSpec{VNI: 1099}},
+			{InterfaceMeta: api.InterfaceMeta{ID: "vm4"}, Spec: api.InterfaceSpec{VNI: 1100}},
+		}
+		var ids []string
+		for _, iface := range opts.Filter(ifaces) {
+			ids = append(ids, iface.ID)
+		}
+		Expect(ids).To(Equal([]string{"vm1", "vm2", "vm3"}))
+	})
+
+	It("should reject a VNI range with min greater than max", func() {
+		Expect(fs.Parse([]string{"--vni-min=1100", "--vni-max=1099"})).To(Succeed())
+		opts.Complete(fs)
+		Expect(opts.Validate()).To(MatchError("--vni-min 1100 must not be greater than --vni-max 1099"))
+	})
+})
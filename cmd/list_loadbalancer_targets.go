@@ -46,11 +46,15 @@ func ListLoadBalancerTargets(dpdkClientFactory DPDKClientFactory, rendererFactor
 type ListLoadBalancerTargetOptions struct {
 	LoadBalancerID string
 	SortBy         string
+	PageOptions
+	EmptyListOptions
 }
 
 func (o *ListLoadBalancerTargetOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.LoadBalancerID, "lb-id", o.LoadBalancerID, "ID of the loadbalancer to get the targets for.")
-	fs.StringVar(&o.SortBy, "sort-by", "", "Column to sort by.")
+	fs.StringVar(&o.SortBy, "sort-by", "", "Table column to sort by, addresses and prefixes are sorted in address order.")
+	o.PageOptions.AddFlags(fs)
+	o.EmptyListOptions.AddFlags(fs)
 }
 
 func (o *ListLoadBalancerTargetOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -83,9 +87,15 @@ func RunListLoadBalancerTargets(
 	targets := lbtargets.Items
 	sort.SliceStable(targets, func(i, j int) bool {
 		mi, mj := targets[i], targets[j]
-		return mi.Spec.TargetIP.String() < mj.Spec.TargetIP.String()
+		return LessAddr(*mi.Spec.TargetIP, *mj.Spec.TargetIP, rendererFactory.GetPreferIPv6())
 	})
-	lbtargets.Items = targets
+	if err := SortItems(lbtargets, targets, rendererFactory.GetPreferIPv6(), opts.SortBy); err != nil {
+		return err
+	}
+	lbtargets.Items = Paginate(os.Stderr, targets, opts.PageOptions)
 
-	return rendererFactory.RenderList("", os.Stdout, lbtargets)
+	if err := rendererFactory.RenderList("", os.Stdout, lbtargets); err != nil {
+		return err
+	}
+	return opts.CheckEmpty(lbtargets)
 }
@@ -8,9 +8,9 @@ import (
 	"fmt"
 	"net/netip"
 	"os"
-	"sort"
-	"strings"
+	"strconv"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
 	"github.com/ironcore-dev/dpservice-cli/flag"
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/spf13/cobra"
@@ -50,12 +50,16 @@ type ListNatsOptions struct {
 	NatIP   netip.Addr
 	NatType string
 	SortBy  string
+	PageOptions
+	EmptyListOptions
 }
 
 func (o *ListNatsOptions) AddFlags(fs *pflag.FlagSet) {
 	flag.AddrVar(fs, &o.NatIP, "nat-ip", o.NatIP, "NAT IP to get info for")
 	fs.StringVar(&o.NatType, "nat-type", "0", "NAT type: Any = 0/Local = 1/Neigh(bor) = 2")
-	fs.StringVar(&o.SortBy, "sort-by", "", "Column to sort by.")
+	fs.StringVar(&o.SortBy, "sort-by", "", "Table column to sort by, addresses and prefixes are sorted in address order.")
+	o.PageOptions.AddFlags(fs)
+	o.EmptyListOptions.AddFlags(fs)
 }
 
 func (o *ListNatsOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -79,35 +83,27 @@ func RunListNats(
 	}
 	defer DpdkClose(cleanup)
 
-	natList, err := client.ListNats(ctx, &opts.NatIP, opts.NatType)
+	natType := conversion.NatTypeAny
+	if opts.NatType != "" {
+		if natType, err = conversion.NatTypes.Parse(opts.NatType); err != nil {
+			return fmt.Errorf("invalid --nat-type: %w", err)
+		}
+	}
+
+	natList, err := client.ListNats(ctx, &opts.NatIP, strconv.Itoa(int(natType)))
 	if err != nil {
 		return fmt.Errorf("error listing nats: %w", err)
 	}
 
 	// sort items in list
 	nats := natList.Items
-	sort.SliceStable(nats, func(i, j int) bool {
-		mi, mj := nats[i], nats[j]
-		switch strings.ToLower(opts.SortBy) {
-		case "ip":
-			if mi.Spec.NatIP != nil && mj.Spec.NatIP != nil {
-				return mi.Spec.NatIP.String() < mj.Spec.NatIP.String()
-			}
-			return true
-		case "minport":
-			return mi.Spec.MinPort < mj.Spec.MinPort
-		case "maxport":
-			return mi.Spec.MaxPort < mj.Spec.MaxPort
-		case "underlayroute":
-			if mi.Spec.UnderlayRoute != nil && mj.Spec.UnderlayRoute != nil {
-				return mi.Spec.UnderlayRoute.String() < mj.Spec.UnderlayRoute.String()
-			}
-			return true
-		default:
-			return mi.Spec.Vni < mj.Spec.Vni
-		}
-	})
-	natList.Items = nats
+	if err := SortItems(natList, nats, rendererFactory.GetPreferIPv6(), opts.SortBy, "VNI"); err != nil {
+		return err
+	}
+	natList.Items = Paginate(os.Stderr, nats, opts.PageOptions)
 
-	return rendererFactory.RenderList("", os.Stdout, natList)
+	if err := rendererFactory.RenderList("", os.Stdout, natList); err != nil {
+		return err
+	}
+	return opts.CheckEmpty(natList)
 }
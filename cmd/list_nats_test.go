@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"net/netip"
+	"os"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type listNatsClient struct {
+	client.Client
+	nats    []api.Nat
+	natType string
+}
+
+func (c *listNatsClient) ListNats(ctx context.Context, natIP *netip.Addr, natType string, ignoredErrors ...[]uint32) (*api.NatList, error) {
+	c.natType = natType
+	return &api.NatList{TypeMeta: api.TypeMeta{Kind: api.NatListKind}, Items: c.nats}, nil
+}
+
+var _ = Describe("ListNats", func() {
+	var c *listNatsClient
+
+	BeforeEach(func() {
+		nat := func(natIP, underlayRoute string) api.Nat {
+			ip, route := netip.MustParseAddr(natIP), netip.MustParseAddr(underlayRoute)
+			return api.Nat{Spec: api.NatSpec{NatIP: &ip, UnderlayRoute: &route}}
+		}
+		c = &listNatsClient{nats: []api.Nat{
+			nat("10.0.0.10", "fc00::10"),
+			nat("2001:db8::1", "fc00::9"),
+			nat("10.0.0.2", "fc00::a"),
+			nat("9.0.0.1", "10.0.0.1"),
+		}}
+	})
+
+	// list renders the given fields of the listed nats with jsonpath
+	list := func(ctx context.Context, sortBy, field string) string {
+		stdout := captureOutput(&os.Stdout)
+
+		rendererOptions := &RendererOptions{Output: "jsonpath={.items[*].spec." + field + "}"}
+		Expect(RunListNats(ctx, fakeClientFactory{c}, rendererOptions, ListNatsOptions{SortBy: sortBy})).To(Succeed())
+		out, err := os.ReadFile(stdout)
+		Expect(err).NotTo(HaveOccurred())
+		return string(out)
+	}
+
+	It("should sort by ip in address order, IPv4 before IPv6", func(ctx SpecContext) {
+		Expect(list(ctx, "ip", "nat_ip")).To(Equal("9.0.0.1 10.0.0.2 10.0.0.10 2001:db8::1\n"))
+	})
+
+	It("should sort by underlayroute in address order", func(ctx SpecContext) {
+		Expect(list(ctx, "underlayroute", "underlay_route")).To(Equal("10.0.0.1 fc00::9 fc00::a fc00::10\n"))
+	})
+
+	It("should pass the parsed --nat-type and reject unknown ones", func(ctx SpecContext) {
+		rendererOptions := &RendererOptions{Output: "name"}
+		Expect(RunListNats(ctx, fakeClientFactory{c}, rendererOptions, ListNatsOptions{NatType: "Neighbor"})).To(Succeed())
+		Expect(c.natType).To(Equal("2"))
+
+		Expect(RunListNats(ctx, fakeClientFactory{c}, rendererOptions, ListNatsOptions{NatType: "remote"})).To(MatchError(ContainSubstring("invalid --nat-type")))
+	})
+})
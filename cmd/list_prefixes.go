@@ -7,8 +7,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"sort"
-	"strings"
 
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/ironcore-dev/dpservice-go/api"
@@ -47,11 +45,17 @@ func ListPrefixes(dpdkClientFactory DPDKClientFactory, rendererFactory RendererF
 type ListPrefixesOptions struct {
 	InterfaceID string
 	SortBy      string
+	SkipErrors  bool
+	PageOptions
+	EmptyListOptions
 }
 
 func (o *ListPrefixesOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.InterfaceID, "interface-id", o.InterfaceID, "Interface ID of the prefix.")
-	fs.StringVar(&o.SortBy, "sort-by", "", "Column to sort by.")
+	fs.StringVar(&o.SortBy, "sort-by", "", "Table column to sort by, addresses and prefixes are sorted in address order.")
+	fs.BoolVar(&o.SkipErrors, "skip-errors", o.SkipErrors, "Continue listing other interfaces if one of them fails and print a summary of failures.")
+	o.PageOptions.AddFlags(fs)
+	o.EmptyListOptions.AddFlags(fs)
 }
 
 func (o *ListPrefixesOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -73,6 +77,7 @@ func RunListPrefixes(
 	prefixList := &api.PrefixList{
 		TypeMeta: api.TypeMeta{Kind: api.PrefixListKind},
 	}
+	sourceErrs := &SourceErrors{}
 	if opts.InterfaceID == "" {
 		ifaces, err := client.ListInterfaces(ctx)
 		if err != nil && ifaces.Status.Code == 0 {
@@ -81,6 +86,10 @@ func RunListPrefixes(
 
 		for _, iface := range ifaces.Items {
 			prefix, err := client.ListPrefixes(ctx, iface.ID)
+			if err != nil && opts.SkipErrors {
+				sourceErrs.Add("interface/"+iface.ID, err)
+				continue
+			}
 			if err != nil && prefix.Status.Code == 0 {
 				return fmt.Errorf("error getting prefixes: %w", err)
 			}
@@ -94,16 +103,16 @@ func RunListPrefixes(
 	}
 	// sort items in list
 	prefixes := prefixList.Items
-	sort.SliceStable(prefixes, func(i, j int) bool {
-		mi, mj := prefixes[i], prefixes[j]
-		switch strings.ToLower(opts.SortBy) {
-		case "underlayroute":
-			return mi.Spec.UnderlayRoute.String() < mj.Spec.UnderlayRoute.String()
-		default:
-			return mi.Spec.Prefix.String() < mj.Spec.Prefix.String()
-		}
-	})
-	prefixList.Items = prefixes
+	if err := SortItems(prefixList, prefixes, rendererFactory.GetPreferIPv6(), opts.SortBy, "Prefix"); err != nil {
+		return err
+	}
+	prefixList.Items = Paginate(os.Stderr, prefixes, opts.PageOptions)
 
-	return rendererFactory.RenderList("", os.Stdout, prefixList)
+	if err := rendererFactory.RenderList("", os.Stdout, prefixList); err != nil {
+		return err
+	}
+	if err := sourceErrs.Summarize(os.Stderr); err != nil {
+		return err
+	}
+	return opts.CheckEmpty(prefixList)
 }
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+	"strconv"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// prefixClient has one prefix per interface and fails to list the prefixes of vm2.
+type prefixClient struct {
+	client.Client
+}
+
+func (c *prefixClient) ListInterfaces(ctx context.Context, ignoredErrors ...[]uint32) (*api.InterfaceList, error) {
+	list := &api.InterfaceList{TypeMeta: api.TypeMeta{Kind: api.InterfaceListKind}}
+	for _, id := range []string{"vm1", "vm2", "vm3"} {
+		list.Items = append(list.Items, api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}})
+	}
+	return list, nil
+}
+
+func (c *prefixClient) ListPrefixes(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error) {
+	if interfaceID == "vm2" {
+		return &api.PrefixList{}, errors.New("connection reset")
+	}
+	prefix := netip.MustParsePrefix("10.0.0.0/24")
+	if interfaceID == "vm3" {
+		prefix = netip.MustParsePrefix("10.0.3.0/24")
+	}
+	return &api.PrefixList{
+		TypeMeta: api.TypeMeta{Kind: api.PrefixListKind},
+		Items: []api.Prefix{{
+			TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+			PrefixMeta: api.PrefixMeta{InterfaceID: interfaceID},
+			Spec:       api.PrefixSpec{Prefix: prefix},
+		}},
+	}, nil
+}
+
+var _ = Describe("RunListPrefixes", func() {
+	var stdout, stderr string
+
+	BeforeEach(func() {
+		stdout = captureOutput(&os.Stdout)
+		stderr = captureOutput(&os.Stderr)
+	})
+
+	It("should stop at the first failing interface without --skip-errors", func(ctx SpecContext) {
+		err := RunListPrefixes(ctx, fakeClientFactory{&prefixClient{}}, &RendererOptions{Output: "name"}, ListPrefixesOptions{})
+		Expect(err).To(MatchError(ContainSubstring("error getting prefixes: connection reset")))
+		Expect(os.ReadFile(stdout)).To(BeEmpty())
+	})
+
+	It("should render the remaining interfaces and summarize the failures with --skip-errors", func(ctx SpecContext) {
+		err := RunListPrefixes(ctx, fakeClientFactory{&prefixClient{}}, &RendererOptions{Output: "name"}, ListPrefixesOptions{SkipErrors: true})
+		Expect(err).To(MatchError(strconv.Itoa(apierrors.SERVER_ERROR)))
+
+		out, err := os.ReadFile(stdout)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("10.0.0.0/24"))
+		Expect(string(out)).To(ContainSubstring("10.0.3.0/24"))
+		Expect(os.ReadFile(stderr)).To(Equal([]byte("1 source(s) failed:\n  interface/vm2: connection reset\n")))
+	})
+})
@@ -7,8 +7,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"sort"
-	"strings"
 
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/spf13/cobra"
@@ -47,11 +45,15 @@ func ListRoutes(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFac
 type ListRoutesOptions struct {
 	VNI    uint32
 	SortBy string
+	PageOptions
+	EmptyListOptions
 }
 
 func (o *ListRoutesOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.Uint32Var(&o.VNI, "vni", o.VNI, "VNI to get the routes from.")
-	fs.StringVar(&o.SortBy, "sort-by", "", "Column to sort by.")
+	fs.StringVar(&o.SortBy, "sort-by", "", "Table column to sort by, addresses and prefixes are sorted in address order.")
+	o.PageOptions.AddFlags(fs)
+	o.EmptyListOptions.AddFlags(fs)
 }
 
 func (o *ListRoutesOptions) MarkRequiredFlags(cmd *cobra.Command) error {
@@ -82,18 +84,13 @@ func RunGetRoute(
 
 	// sort items in list
 	routes := routeList.Items
-	sort.SliceStable(routes, func(i, j int) bool {
-		mi, mj := routes[i], routes[j]
-		switch strings.ToLower(opts.SortBy) {
-		case "nexthopvni":
-			return mi.Spec.NextHop.VNI < mj.Spec.NextHop.VNI
-		case "nexthopip":
-			return mi.Spec.NextHop.IP.String() < mj.Spec.NextHop.IP.String()
-		default:
-			return mi.Spec.Prefix.String() < mj.Spec.Prefix.String()
-		}
-	})
-	routeList.Items = routes
+	if err := SortItems(routeList, routes, rendererFactory.GetPreferIPv6(), opts.SortBy, "Prefix"); err != nil {
+		return err
+	}
+	routeList.Items = Paginate(os.Stderr, routes, opts.PageOptions)
 
-	return rendererFactory.RenderList("", os.Stdout, routeList)
+	if err := rendererFactory.RenderList("", os.Stdout, routeList); err != nil {
+		return err
+	}
+	return opts.CheckEmpty(routeList)
 }
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+type ListWatchOptions struct {
+	Watch    bool
+	Interval time.Duration
+}
+
+func (o *ListWatchOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Watch, "watch", o.Watch, "List again every --watch-interval until interrupted. Table output is redrawn, json and yaml output get one document per list.")
+	fs.DurationVar(&o.Interval, "watch-interval", 2*time.Second, "Interval to list at with --watch.")
+}
+
+func (o *ListWatchOptions) Validate() error {
+	if o.Watch && o.Interval <= 0 {
+		return fmt.Errorf("--watch-interval must be positive, got %s", o.Interval)
+	}
+	return nil
+}
+
+// watchListCommands makes the list commands re-run with --watch, sharing a single client.
+func watchListCommands(cmds []*cobra.Command, rendererOptions *RendererOptions, factory *listWatchClientFactory) {
+	for _, cmd := range cmds {
+		runE := cmd.RunE
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			opts := factory.opts
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+			if !opts.Watch {
+				return runE(cmd, args)
+			}
+			defer factory.Close()
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			return RunListWatch(ctx, os.Stdout, rendererOptions, *opts, func(ctx context.Context) error {
+				cmd.SetContext(ctx)
+				return runE(cmd, args)
+			})
+		}
+	}
+}
+
+// RunListWatch calls list every interval until ctx is done. Errors of a single list are
+// printed to stderr and do not stop the watch.
+func RunListWatch(ctx context.Context, w io.Writer, rendererOptions *RendererOptions, opts ListWatchOptions, list func(ctx context.Context) error) error {
+	listed := false
+	for {
+		switch {
+		case rendererOptions.Output == "" || rendererOptions.isOutput("table"):
+			fmt.Fprint(w, clearScreen)
+		case rendererOptions.isOutput("yaml") && listed:
+			fmt.Fprintln(w, "---")
+		}
+
+		err := list(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		listed = err == nil
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Error: %v\n", watchTimestamp(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// listWatchClientFactory keeps the client of the first list of a watch for all further
+// lists, instead of connecting again for every list.
+type listWatchClientFactory struct {
+	DPDKClientFactory
+	opts *ListWatchOptions
+
+	client  client.Client
+	cleanup func() error
+}
+
+func (f *listWatchClientFactory) NewClient(ctx context.Context) (client.Client, func() error, error) {
+	if !f.opts.Watch {
+		return f.DPDKClientFactory.NewClient(ctx)
+	}
+	if f.client == nil {
+		c, cleanup, err := f.DPDKClientFactory.NewClient(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		f.client, f.cleanup = c, cleanup
+	}
+	return f.client, func() error { return nil }, nil
+}
+
+// Close closes the kept client, if any.
+func (f *listWatchClientFactory) Close() {
+	if f.cleanup != nil {
+		DpdkClose(f.cleanup)
+		f.client, f.cleanup = nil, nil
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunListWatch", func() {
+	opts := ListWatchOptions{Watch: true, Interval: time.Millisecond}
+
+	// lister writes the results to w and cancels the watch after the last one
+	lister := func(w io.Writer, cancel context.CancelFunc, results ...string) func(context.Context) error {
+		return func(context.Context) error {
+			result := results[0]
+			results = results[1:]
+			if len(results) == 0 {
+				defer cancel()
+			}
+			if result == "" {
+				return errors.New("unavailable")
+			}
+			_, err := fmt.Fprintln(w, result)
+			return err
+		}
+	}
+
+	It("should separate yaml documents and keep watching after errors", func(ctx SpecContext) {
+		ctx2, cancel := context.WithCancel(ctx)
+		var w bytes.Buffer
+		list := lister(&w, cancel, "items: []", "", "items: [a]", "items: [b]")
+		Expect(RunListWatch(ctx2, &w, &RendererOptions{Output: "yaml"}, opts, list)).To(Succeed())
+		Expect(w.String()).To(Equal("items: []\n---\nitems: [a]\n---\nitems: [b]\n"))
+	})
+
+	It("should clear the screen before each table", func(ctx SpecContext) {
+		ctx2, cancel := context.WithCancel(ctx)
+		var w bytes.Buffer
+		list := lister(&w, cancel, "a", "b")
+		Expect(RunListWatch(ctx2, &w, &RendererOptions{Output: "table"}, opts, list)).To(Succeed())
+		Expect(w.String()).To(Equal("\033[H\033[2Ja\n\033[H\033[2Jb\n"))
+	})
+
+	It("should reject a non-positive interval", func() {
+		opts := ListWatchOptions{Watch: true}
+		Expect(opts.Validate()).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// LogOptions configure the logger of log/slog that diagnostics are written to on stderr.
+type LogOptions struct {
+	Level  string
+	Format string
+}
+
+func (o *LogOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Level, "log-level", "warn", "Minimum level of log messages on stderr: debug, info, warn or error. debug logs every gRPC request and response.")
+	fs.StringVar(&o.Format, "log-format", LogFormatText, fmt.Sprintf("Format of log messages: %s or %s. With %s, command errors are logged as json as well.", LogFormatText, LogFormatJSON, LogFormatJSON))
+}
+
+// NewLogger returns a logger writing to w as configured by the options.
+func (o *LogOptions) NewLogger(w io.Writer) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(o.Level)); err != nil {
+		return nil, fmt.Errorf("invalid --log-level %q, must be debug, info, warn or error", o.Level)
+	}
+	handlerOpts := &slog.HandlerOptions{Level: level}
+
+	switch o.Format {
+	case LogFormatText:
+		return slog.New(slog.NewTextHandler(w, handlerOpts)), nil
+	case LogFormatJSON:
+		return slog.New(slog.NewJSONHandler(w, handlerOpts)), nil
+	default:
+		return nil, fmt.Errorf("invalid --log-format %q, must be %s or %s", o.Format, LogFormatText, LogFormatJSON)
+	}
+}
+
+// Setup makes the configured logger the default logger.
+func (o *LogOptions) Setup() error {
+	logger, err := o.NewLogger(os.Stderr)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	return nil
+}
+
+// reportError writes the error a command failed with to stderr, as a log message if
+// logs are json. Other messages on stderr, e.g. warnings and notices, stay plain text.
+func reportError(msg string, err error, exitCode int) {
+	if _, ok := slog.Default().Handler().(*slog.JSONHandler); ok {
+		slog.Error(msg, "error", err, "exitCode", exitCode)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s: %v\n", msg, err)
+}
+
+// logCall logs a call with its request and response at debug level.
+func logCall(ctx context.Context, method string, req, reply any, d time.Duration, err error) {
+	attrs := []any{"method", method, "duration", d, "request", protoLogValue{req}}
+	if err != nil {
+		slog.DebugContext(ctx, "call failed", append(attrs, "error", err)...)
+		return
+	}
+	slog.DebugContext(ctx, "call", append(attrs, "response", protoLogValue{reply})...)
+}
+
+// protoLogValue logs protobuf messages in their json representation.
+type protoLogValue struct {
+	v any
+}
+
+func (p protoLogValue) LogValue() slog.Value {
+	if m, ok := p.v.(proto.Message); ok {
+		return slog.StringValue(protojson.MarshalOptions{}.Format(m))
+	}
+	return slog.AnyValue(p.v)
+}
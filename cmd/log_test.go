@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+var _ = Describe("Log", func() {
+	It("should reject unknown levels and formats", func() {
+		_, err := (&LogOptions{Level: "verbose", Format: LogFormatText}).NewLogger(&bytes.Buffer{})
+		Expect(err).To(MatchError(ContainSubstring("invalid --log-level")))
+		_, err = (&LogOptions{Level: "info", Format: "xml"}).NewLogger(&bytes.Buffer{})
+		Expect(err).To(MatchError(ContainSubstring("invalid --log-format")))
+	})
+
+	It("should log calls with request and response at debug level", func(ctx SpecContext) {
+		var buf bytes.Buffer
+		logger, err := (&LogOptions{Level: "debug", Format: LogFormatJSON}).NewLogger(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		defaultLogger := slog.Default()
+		slog.SetDefault(logger)
+		DeferCleanup(func() { slog.SetDefault(defaultLogger) })
+
+		req := &dpdkproto.GetVersionRequest{ClientName: "dpservice-cli"}
+		reply := &dpdkproto.GetVersionResponse{}
+		invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			reply.(*dpdkproto.GetVersionResponse).ServiceVersion = "v0.3.1"
+			return nil
+		}
+		Expect(NewCallTracer().UnaryClientInterceptor(ctx, "/dpdkironcore.v1.DPDKironcore/GetVersion", req, reply, nil, invoker)).To(Succeed())
+
+		var record map[string]any
+		Expect(json.Unmarshal(buf.Bytes(), &record)).To(Succeed())
+		Expect(record).To(HaveKeyWithValue("level", "DEBUG"))
+		Expect(record).To(HaveKeyWithValue("method", "/dpdkironcore.v1.DPDKironcore/GetVersion"))
+		Expect(record["request"]).To(MatchJSON(`{"clientName":"dpservice-cli"}`))
+		Expect(record["response"]).To(MatchJSON(`{"serviceVersion":"v0.3.1"}`))
+	})
+
+	It("should not log calls above debug level", func(ctx SpecContext) {
+		var buf bytes.Buffer
+		logger, err := (&LogOptions{Level: "info", Format: LogFormatText}).NewLogger(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		defaultLogger := slog.Default()
+		slog.SetDefault(logger)
+		DeferCleanup(func() { slog.SetDefault(defaultLogger) })
+
+		invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return nil
+		}
+		Expect(NewCallTracer().UnaryClientInterceptor(ctx, "/dpdkironcore.v1.DPDKironcore/GetVersion", &dpdkproto.GetVersionRequest{}, &dpdkproto.GetVersionResponse{}, nil, invoker)).To(Succeed())
+		Expect(buf.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+)
+
+type MetricsOptions struct {
+	File string
+
+	recorder *MetricsRecorder
+}
+
+func (o *MetricsOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.File, "metrics-file", o.File, "Write a json summary of the run (command, duration, RPCs, retries, errors, exit code) to this file.")
+}
+
+// Recorder returns the recorder that collects the metrics of the run.
+func (o *MetricsOptions) Recorder() *MetricsRecorder {
+	if o.recorder == nil {
+		o.recorder = NewMetricsRecorder()
+	}
+	return o.recorder
+}
+
+// Write writes the summary of the run to the metrics file, if one was given.
+func (o *MetricsOptions) Write(command string, duration time.Duration, runErr error, exitCode int) error {
+	if o.File == "" {
+		return nil
+	}
+
+	metrics := o.Recorder().Summary()
+	metrics.Command = command
+	metrics.DurationSeconds = duration.Seconds()
+	metrics.ExitCode = exitCode
+	if runErr != nil {
+		metrics.Error = runErr.Error()
+	}
+
+	data, err := json.MarshalIndent(metrics, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling metrics: %w", err)
+	}
+	if err := os.WriteFile(o.File, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("error writing metrics file: %w", err)
+	}
+	return nil
+}
+
+type RPCMetrics struct {
+	Count  int `json:"count"`
+	Errors int `json:"errors"`
+}
+
+type RunMetrics struct {
+	Command         string                `json:"command"`
+	DurationSeconds float64               `json:"durationSeconds"`
+	RPCs            map[string]RPCMetrics `json:"rpcs"`
+	Retries         int                   `json:"retries"`
+	Errors          int                   `json:"errors"`
+	Error           string                `json:"error,omitempty"`
+	ExitCode        int                   `json:"exitCode"`
+}
+
+// RPCFailure is an RPC that failed or that dpservice answered with an error status.
+type RPCFailure struct {
+	Method string `json:"method"`
+	Error  string `json:"error"`
+}
+
+// MetricsRecorder counts the RPCs of a run by method. Every attempt of a retried call counts as an RPC.
+type MetricsRecorder struct {
+	mu       sync.Mutex
+	rpcs     map[string]RPCMetrics
+	retries  int
+	failures []RPCFailure
+}
+
+func NewMetricsRecorder() *MetricsRecorder {
+	return &MetricsRecorder{rpcs: make(map[string]RPCMetrics)}
+}
+
+func (m *MetricsRecorder) RecordRetry(string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries++
+}
+
+func (m *MetricsRecorder) UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rpc := m.rpcs[path.Base(method)]
+	rpc.Count++
+	if err != nil {
+		rpc.Errors++
+		m.failures = append(m.failures, RPCFailure{Method: path.Base(method), Error: err.Error()})
+	} else if res, ok := reply.(interface{ GetStatus() *dpdkproto.Status }); ok && res.GetStatus().GetCode() != 0 {
+		m.failures = append(m.failures, RPCFailure{
+			Method: path.Base(method),
+			Error:  fmt.Sprintf("status %d: %s", res.GetStatus().GetCode(), res.GetStatus().GetMessage()),
+		})
+	}
+	m.rpcs[path.Base(method)] = rpc
+	return err
+}
+
+// Failures returns the failed RPCs in the order they were issued.
+func (m *MetricsRecorder) Failures() []RPCFailure {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]RPCFailure(nil), m.failures...)
+}
+
+// Summary returns the recorded RPCs and retries, Errors is the number of failed RPCs.
+func (m *MetricsRecorder) Summary() RunMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics := RunMetrics{RPCs: make(map[string]RPCMetrics, len(m.rpcs)), Retries: m.retries}
+	for method, rpc := range m.rpcs {
+		metrics.RPCs[method] = rpc
+		metrics.Errors += rpc.Errors
+	}
+	return metrics
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("Metrics", func() {
+	It("should write a summary of the run", func() {
+		opts := &MetricsOptions{File: filepath.Join(GinkgoT().TempDir(), "metrics.json")}
+
+		retry := NewRetryInterceptor(RetryPolicySafe)
+		retry.Backoff = 0
+		retry.OnRetry = opts.Recorder().RecordRetry
+
+		attempts := 0
+		invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			attempts++
+			if attempts == 1 {
+				return status.Error(codes.Unavailable, "failed")
+			}
+			return nil
+		}
+		// the metrics interceptor runs inside the retry interceptor, as in the client
+		metricsInvoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, callOpts ...grpc.CallOption) error {
+			return opts.Recorder().UnaryClientInterceptor(ctx, method, req, reply, cc, invoker, callOpts...)
+		}
+		Expect(retry.UnaryClientInterceptor(context.Background(), "/dpdkironcore.v1.DPDKironcore/ListInterfaces", nil, nil, nil, metricsInvoker)).To(Succeed())
+
+		Expect(opts.Write("dpservice-cli list interfaces", 1500*time.Millisecond, errors.New("failed"), 1)).To(Succeed())
+
+		data, err := os.ReadFile(opts.File)
+		Expect(err).NotTo(HaveOccurred())
+		var metrics RunMetrics
+		Expect(json.Unmarshal(data, &metrics)).To(Succeed())
+		Expect(metrics).To(Equal(RunMetrics{
+			Command:         "dpservice-cli list interfaces",
+			DurationSeconds: 1.5,
+			RPCs:            map[string]RPCMetrics{"ListInterfaces": {Count: 2, Errors: 1}},
+			Retries:         1,
+			Errors:          1,
+			Error:           "failed",
+			ExitCode:        1,
+		}))
+	})
+
+	It("should not write anything without a metrics file", func() {
+		Expect((&MetricsOptions{}).Write("dpservice-cli", time.Second, nil, 0)).To(Succeed())
+	})
+})
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/runtime"
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-cli/sources"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func Normalize() *cobra.Command {
+	var (
+		opts NormalizeOptions
+	)
+	sourcesOptions := &SourcesOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "normalize <-f> [--write]",
+		Short:   "Canonicalize the objects of apply files",
+		Example: "dpservice-cli normalize -f objects.yaml --write",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunNormalize(os.Stdout, sourcesOptions.Filename, opts)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+	sourcesOptions.AddFlags(cmd.Flags())
+
+	util.Must(cmd.MarkFlagRequired("filename"))
+
+	return cmd
+}
+
+type NormalizeOptions struct {
+	Write bool
+}
+
+func (o *NormalizeOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Write, "write", o.Write, "Write the result back to the files instead of to stdout.")
+}
+
+// RunNormalize decodes all files before writing anything, so that an invalid document
+// does not lead to partial output.
+func RunNormalize(w io.Writer, filenames []string, opts NormalizeOptions) error {
+	if opts.Write && slices.Contains(filenames, sources.StdinSource) {
+		return fmt.Errorf("--write cannot write back to stdin")
+	}
+
+	normalized := make([][]byte, len(filenames))
+	for i, filename := range filenames {
+		data, err := NormalizeFile(filename)
+		if err != nil {
+			return fmt.Errorf("error normalizing %s: %w", filename, err)
+		}
+		normalized[i] = data
+	}
+
+	for i, filename := range filenames {
+		if !opts.Write {
+			if _, err := w.Write(normalized[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		stat, err := os.Stat(filename)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filename, normalized[i], stat.Mode().Perm()); err != nil {
+			return fmt.Errorf("error writing %s: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+// NormalizeFile returns the canonical form of the objects in filename, or stdin if it
+// is "-", encoded in the format of the source.
+func NormalizeFile(filename string) ([]byte, error) {
+	src, err := sources.NewSource(filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := src.(*sources.DirSource); ok {
+		return nil, fmt.Errorf("directories are not supported, specify the files")
+	}
+	rce, err := src.Next()
+	if err != nil {
+		return nil, err
+	}
+	defer rce.Close()
+
+	objs, err := sources.DecodeObjects(rce, runtime.DefaultScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for i, obj := range objs {
+		doc, err := NormalizeObject(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		switch rce.Ext() {
+		case ".json":
+			err = json.NewEncoder(&buf).Encode(doc)
+		default:
+			if i > 0 {
+				buf.WriteString("---\n")
+			}
+			err = renderer.NewYAML(&buf).Render(doc)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error encoding object: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// NormalizeObject canonicalizes obj and returns it as a generic document with sorted keys.
+// Prefixes are masked, loadbalancer ports are sorted and their protocols lowercased, firewall
+// actions and directions are spelled like dpservice reports them and the status is dropped.
+func NormalizeObject(obj any) (map[string]any, error) {
+	var ports []api.LBPort
+	switch obj := obj.(type) {
+	case *api.Prefix:
+		obj.Spec.Prefix = obj.Spec.Prefix.Masked()
+	case *api.LoadBalancerPrefix:
+		obj.Spec.Prefix = obj.Spec.Prefix.Masked()
+	case *api.Route:
+		maskPrefix(obj.Spec.Prefix)
+	case *api.FirewallRule:
+		maskPrefix(obj.Spec.SourcePrefix)
+		maskPrefix(obj.Spec.DestinationPrefix)
+		if err := normalizeFirewallRule(&obj.Spec); err != nil {
+			return nil, err
+		}
+	case *api.LoadBalancer:
+		sort.SliceStable(obj.Spec.Lbports, func(i, j int) bool {
+			pi, pj := obj.Spec.Lbports[i], obj.Spec.Lbports[j]
+			if pi.Protocol != pj.Protocol {
+				return pi.Protocol < pj.Protocol
+			}
+			return pi.Port < pj.Port
+		})
+		ports = obj.Spec.Lbports
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding object: %w", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("error decoding object: %w", err)
+	}
+	delete(doc, "status")
+	lowercaseProtocols(doc, ports)
+	return doc, nil
+}
+
+// lowercaseProtocols replaces the protocol numbers of the loadbalancer ports of doc by
+// their lowercase names, which are decoded like the numbers.
+func lowercaseProtocols(doc map[string]any, ports []api.LBPort) {
+	spec, _ := doc["spec"].(map[string]any)
+	docPorts, _ := spec["loadbalanced_ports"].([]any)
+	for i, docPort := range docPorts {
+		port, ok := docPort.(map[string]any)
+		if !ok || i >= len(ports) {
+			continue
+		}
+		if protocol, err := conversion.ProtocolToProto(ports[i].Protocol); err == nil {
+			port["protocol"] = strings.ToLower(conversion.Protocols.String(protocol))
+		}
+	}
+}
+
+func maskPrefix(prefix *netip.Prefix) {
+	if prefix != nil {
+		*prefix = prefix.Masked()
+	}
+}
+
+// normalizeFirewallRule spells the action and direction like dpservice-go does on create.
+func normalizeFirewallRule(spec *api.FirewallRuleSpec) error {
+	if spec.FirewallAction != "" {
+		action, err := conversion.FirewallActions.Parse(spec.FirewallAction)
+		if err != nil {
+			return fmt.Errorf("invalid firewall action: %w", err)
+		}
+		spec.FirewallAction = dpserviceSpelling(conversion.FirewallActions.String(action))
+	}
+	if spec.TrafficDirection != "" {
+		direction, err := conversion.TrafficDirections.Parse(spec.TrafficDirection)
+		if err != nil {
+			return fmt.Errorf("invalid traffic direction: %w", err)
+		}
+		spec.TrafficDirection = dpserviceSpelling(conversion.TrafficDirections.String(direction))
+	}
+	return nil
+}
+
+// dpserviceSpelling spells an enum name the way dpservice-go does, e.g. ACCEPT as Accept.
+func dpserviceSpelling(name string) string {
+	return name[:1] + strings.ToLower(name[1:])
+}
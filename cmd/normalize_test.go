@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Normalize", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(content), 0o644)).To(Succeed())
+		return path
+	}
+
+	It("should canonicalize the objects of a file", func() {
+		path := writeFile("objects.yaml", `kind: LoadBalancer
+spec:
+  loadbalanced_ports:
+  - port: 53
+    protocol: UDP
+  - port: 443
+    protocol: 6
+  vni: 100
+metadata:
+  id: lb1
+---
+kind: Route
+metadata:
+  vni: 100
+spec:
+  prefix: 10.0.0.5/24
+`)
+
+		var out bytes.Buffer
+		Expect(RunNormalize(&out, []string{path}, NormalizeOptions{})).To(Succeed())
+		Expect(out.String()).To(Equal(`kind: LoadBalancer
+metadata:
+  id: lb1
+spec:
+  loadbalanced_ports:
+  - port: 443
+    protocol: tcp
+  - port: 53
+    protocol: udp
+  vni: 100
+---
+kind: Route
+metadata:
+  vni: 100
+spec:
+  prefix: 10.0.0.0/24
+`))
+	})
+
+	It("should read stdin for -", func() {
+		setStdin(`{"kind":"LoadBalancer","metadata":{"id":"lb1"},"spec":{"vni":100,"loadbalanced_ports":[{"protocol":"TCP","port":443}]}}`)
+
+		var out bytes.Buffer
+		Expect(RunNormalize(&out, []string{"-"}, NormalizeOptions{})).To(Succeed())
+		Expect(out.String()).To(MatchJSON(`{"kind":"LoadBalancer","metadata":{"id":"lb1"},"spec":{"vni":100,"loadbalanced_ports":[{"protocol":"tcp","port":443}]}}`))
+	})
+
+	It("should not write back to stdin", func() {
+		Expect(RunNormalize(&bytes.Buffer{}, []string{"-"}, NormalizeOptions{Write: true})).To(MatchError("--write cannot write back to stdin"))
+	})
+
+	It("should write the result back with --write", func() {
+		path := writeFile("objects.json", `{"spec":{"direction":"egress","action":"allow","id":"fw1","priority":1000},"kind":"FirewallRule","metadata":{"interface_id":"vm1"}}`)
+
+		var out bytes.Buffer
+		Expect(RunNormalize(&out, []string{path}, NormalizeOptions{Write: true})).To(Succeed())
+		Expect(out.String()).To(BeEmpty())
+		Expect(os.ReadFile(path)).To(MatchJSON(`{"kind":"FirewallRule","metadata":{"interface_id":"vm1"},"spec":{"action":"Accept","direction":"Egress","id":"fw1","priority":1000}}`))
+	})
+
+	It("should not write anything if a document is invalid", func() {
+		valid := writeFile("valid.yaml", "kind: Route\nmetadata:\n  vni: 100\nspec:\n  prefix: 10.0.0.5/24\n")
+		invalid := writeFile("invalid.yaml", "kind: Unknown\n")
+
+		var out bytes.Buffer
+		Expect(RunNormalize(&out, []string{valid, invalid}, NormalizeOptions{Write: true})).NotTo(Succeed())
+		Expect(out.String()).To(BeEmpty())
+		Expect(os.ReadFile(valid)).To(ContainSubstring("10.0.0.5/24"))
+	})
+})
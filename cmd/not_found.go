@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// ExitCodeNotFound is the exit code of get commands if the requested object does not exist.
+const ExitCodeNotFound = 4
+
+type NotFoundError struct {
+	Kind string
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Kind, e.Name)
+}
+
+type NotFoundOptions struct {
+	IgnoreNotFound bool
+}
+
+func (o *NotFoundOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.IgnoreNotFound, "ignore-not-found", o.IgnoreNotFound, fmt.Sprintf("Succeed with empty output if the object does not exist, instead of exiting with %d.", ExitCodeNotFound))
+}
+
+// NotFound returns the error for a missing object, or nil if missing objects are ignored.
+func (o *NotFoundOptions) NotFound(kind, name string) error {
+	if o.IgnoreNotFound {
+		return nil
+	}
+	return &NotFoundError{Kind: kind, Name: name}
+}
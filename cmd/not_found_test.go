@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type loadBalancerClient struct {
+	client.Client
+	err error
+}
+
+func (c *loadBalancerClient) GetLoadBalancer(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.LoadBalancer, error) {
+	if c.err != nil {
+		return &api.LoadBalancer{Status: api.Status{Code: apierrors.NO_LB, Message: "NO_LB"}}, c.err
+	}
+	return &api.LoadBalancer{LoadBalancerMeta: api.LoadBalancerMeta{ID: id}}, nil
+}
+
+type fakeClientFactory struct {
+	client client.Client
+}
+
+func (f fakeClientFactory) NewClient(context.Context) (client.Client, func() error, error) {
+	return f.client, func() error { return nil }, nil
+}
+
+var _ = Describe("NotFound", func() {
+	notFound := fakeClientFactory{&loadBalancerClient{err: apierrors.NewStatusError(apierrors.NO_LB, "NO_LB")}}
+
+	It("should fail with a not found error and exit code", func(ctx SpecContext) {
+		err := RunGetLoadBalancer(ctx, notFound, &RendererOptions{Output: "name"}, GetLoadBalancerOptions{ID: "lb1"})
+		Expect(err).To(MatchError("LoadBalancer lb1 not found"))
+		Expect(ExitCode(err)).To(Equal(ExitCodeNotFound))
+	})
+
+	It("should succeed with --ignore-not-found", func(ctx SpecContext) {
+		opts := GetLoadBalancerOptions{ID: "lb1", NotFoundOptions: NotFoundOptions{IgnoreNotFound: true}}
+		Expect(RunGetLoadBalancer(ctx, notFound, &RendererOptions{Output: "name"}, opts)).To(Succeed())
+	})
+
+	It("should keep other server errors distinct", func() {
+		err := apierrors.NewStatusError(apierrors.OUT_OF_MEMORY, "OUT_OF_MEMORY")
+		Expect(ExitCode(err)).To(Equal(apierrors.SERVER_ERROR))
+		Expect(ExitCode(errors.New("connection refused"))).To(Equal(apierrors.CLIENT_ERROR))
+	})
+})
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/spf13/pflag"
+)
+
+// PrintUnderlayRoute makes create commands print only the underlay route of the created object.
+const PrintUnderlayRoute = "underlay-route"
+
+type PrintOptions struct {
+	Print string
+}
+
+func (o *PrintOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Print, "print", o.Print, fmt.Sprintf("Print only this field of the created object instead of rendering it: [%s].", PrintUnderlayRoute))
+}
+
+func (o *PrintOptions) Validate() error {
+	switch o.Print {
+	case "", PrintUnderlayRoute:
+		return nil
+	default:
+		return fmt.Errorf("unsupported --print %q, supported: %s", o.Print, PrintUnderlayRoute)
+	}
+}
+
+// Render renders the created object to w, or only its underlay route if --print=underlay-route is set.
+// Server errors are rendered to stderr then, so that w only ever contains the route.
+func (o *PrintOptions) Render(w io.Writer, rendererFactory RendererFactory, operation string, obj api.Object, underlayRoute *netip.Addr) error {
+	if o.Print == "" {
+		return rendererFactory.RenderObject(operation, w, obj)
+	}
+	if obj.GetStatus().Code != 0 {
+		return rendererFactory.RenderObject(operation, os.Stderr, obj)
+	}
+	if underlayRoute == nil || !underlayRoute.IsValid() {
+		return fmt.Errorf("no underlay route returned for %s %s", obj.GetKind(), obj.GetName())
+	}
+	_, err := fmt.Fprintln(w, underlayRoute)
+	return err
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ErrFlagsPrinted is returned instead of running a command when --print-flags is set.
+var ErrFlagsPrinted = errors.New("flags printed")
+
+type PrintFlagsOptions struct {
+	PrintFlags bool
+}
+
+func (o *PrintFlagsOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.PrintFlags, "print-flags", o.PrintFlags, "Print the effective configuration to stderr and exit without contacting dpservice.")
+	_ = fs.MarkHidden("print-flags")
+}
+
+// PreRun prints the effective flags of cmd and stops its execution if --print-flags is set.
+func (o *PrintFlagsOptions) PreRun(cmd *cobra.Command, args []string) error {
+	if !o.PrintFlags {
+		return nil
+	}
+	PrintFlags(cmd.ErrOrStderr(), cmd.Flags())
+	return ErrFlagsPrinted
+}
+
+// PrintFlags writes all flags of fs with their effective value and where that value came from,
+// followed by the target dialed for --address.
+func PrintFlags(w io.Writer, fs *pflag.FlagSet) {
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Name == "help" || f.Name == "print-flags" {
+			return
+		}
+		source := "default"
+		if f.Changed {
+			source = "flag"
+		}
+		fmt.Fprintf(w, "%s=%s (%s)\n", f.Name, f.Value.String(), source)
+	})
+	if f := fs.Lookup("address"); f != nil {
+		fmt.Fprintf(w, "dial-target=%s (resolved from address)\n", DialTarget(f.Value.String()))
+	}
+}
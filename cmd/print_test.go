@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"net/netip"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrintOptions", func() {
+	underlay := netip.MustParseAddr("fc00::1")
+	nat := &api.Nat{
+		TypeMeta: api.TypeMeta{Kind: api.NatKind},
+		NatMeta:  api.NatMeta{InterfaceID: "vm1"},
+		Spec:     api.NatSpec{UnderlayRoute: &underlay},
+	}
+
+	It("should print only the underlay route", func() {
+		var out bytes.Buffer
+		opts := PrintOptions{Print: PrintUnderlayRoute}
+		Expect(opts.Render(&out, &RendererOptions{Output: "table"}, "created", nat, nat.Spec.UnderlayRoute)).To(Succeed())
+		Expect(out.String()).To(Equal("fc00::1\n"))
+	})
+
+	It("should render the object without --print", func() {
+		var out bytes.Buffer
+		opts := PrintOptions{}
+		Expect(opts.Render(&out, &RendererOptions{Output: "name"}, "created", nat, nat.Spec.UnderlayRoute)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("created"))
+	})
+
+	It("should fail if there is no underlay route", func() {
+		var out bytes.Buffer
+		opts := PrintOptions{Print: PrintUnderlayRoute}
+		Expect(opts.Render(&out, &RendererOptions{Output: "table"}, "created", nat, nil)).NotTo(Succeed())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("should reject unsupported fields", func() {
+		opts := PrintOptions{Print: "vni"}
+		Expect(opts.Validate()).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RedirectMetadataKey is the trailing metadata key in which a clustered dpservice
+// announces the address of the node that should be used instead.
+const RedirectMetadataKey = "dpservice-redirect"
+
+// RedirectFollower reconnects to the address announced in the trailing metadata of
+// an Unavailable error and retries the call there once. Once redirected, all
+// further calls go to the new address.
+type RedirectFollower struct {
+	dialOpts       []grpc.DialOption
+	connectTimeout time.Duration
+
+	mu   sync.Mutex
+	conn *grpc.ClientConn
+}
+
+func NewRedirectFollower(connectTimeout time.Duration, dialOpts ...grpc.DialOption) *RedirectFollower {
+	return &RedirectFollower{
+		dialOpts:       dialOpts,
+		connectTimeout: connectTimeout,
+	}
+}
+
+func (r *RedirectFollower) UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if conn := r.redirected(); conn != nil {
+		return conn.Invoke(ctx, method, req, reply, opts...)
+	}
+
+	var trailer metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
+	if status.Code(err) != codes.Unavailable {
+		return err
+	}
+	addresses := trailer.Get(RedirectMetadataKey)
+	if len(addresses) == 0 {
+		return err
+	}
+
+	conn, dialErr := r.dial(ctx, addresses[0])
+	if dialErr != nil {
+		return fmt.Errorf("error following redirect to %s: %w (original error: %v)", addresses[0], dialErr, err)
+	}
+	return conn.Invoke(ctx, method, req, reply, opts...)
+}
+
+func (r *RedirectFollower) redirected() *grpc.ClientConn {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.conn
+}
+
+func (r *RedirectFollower) dial(ctx context.Context, address string) (*grpc.ClientConn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn != nil {
+		return r.conn, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.connectTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address, r.dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	r.conn = conn
+	return conn, nil
+}
+
+// Close closes the connection to the redirected address, if any.
+func (r *RedirectFollower) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	return err
+}
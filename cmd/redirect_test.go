@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// redirectServer redirects every call to redirectTo if set, and otherwise answers
+// GetVersion and records the authority and intent the calls were sent with.
+type redirectServer struct {
+	dpdkproto.UnimplementedDPDKironcoreServer
+	redirectTo string
+
+	mu          sync.Mutex
+	calls       int
+	authorities []string
+	intents     []string
+}
+
+func (s *redirectServer) GetVersion(ctx context.Context, req *dpdkproto.GetVersionRequest) (*dpdkproto.GetVersionResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+
+	if s.redirectTo != "" {
+		if err := grpc.SetTrailer(ctx, metadata.Pairs(RedirectMetadataKey, s.redirectTo)); err != nil {
+			return nil, err
+		}
+		return nil, status.Error(codes.Unavailable, "not the leader")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.authorities = append(s.authorities, md.Get(":authority")...)
+	s.intents = append(s.intents, md.Get(IntentMetadataKey)...)
+	return &dpdkproto.GetVersionResponse{Status: &dpdkproto.Status{}, ServiceVersion: "v0.3.1"}, nil
+}
+
+func serveRedirect(srv *redirectServer) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	server := grpc.NewServer()
+	dpdkproto.RegisterDPDKironcoreServer(server, srv)
+	go func() { _ = server.Serve(lis) }()
+	DeferCleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+var _ = Describe("RedirectFollower", func() {
+	It("should send redirected calls with the same dial options and interceptors", func(ctx SpecContext) {
+		leader := &redirectServer{}
+		follower := &redirectServer{redirectTo: serveRedirect(leader)}
+		address := serveRedirect(follower)
+
+		opts := &DPDKClientOptions{
+			Address:        address,
+			ConnectTimeout: time.Second,
+			RetryPolicy:    string(RetryPolicyNone),
+			FollowRedirect: true,
+			Intent:         IntentRead,
+			Authority:      "dpservice.test",
+		}
+		c, cleanup, err := opts.NewClient(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(DpdkClose, cleanup)
+
+		for i := 0; i < 2; i++ {
+			version, err := c.GetVersion(ctx, &api.Version{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version.Spec.ServiceVersion).To(Equal("v0.3.1"))
+		}
+		Expect(follower.calls).To(Equal(1), "calls after the redirect should go to the redirected address")
+		Expect(leader.authorities).To(Equal([]string{"dpservice.test", "dpservice.test"}))
+		Expect(leader.intents).To(Equal([]string{IntentRead, IntentRead}))
+	})
+})
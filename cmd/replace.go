@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/runtime"
+	"github.com/ironcore-dev/dpservice-cli/sources"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func Replace(factory DPDKClientFactory) *cobra.Command {
+	var (
+		opts ReplaceOptions
+	)
+	sourcesOptions := &SourcesOptions{}
+	rendererOptions := &RendererOptions{Output: "name"}
+
+	cmd := &cobra.Command{
+		Use:         "replace <-f> [--fail-fast]",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Short:       "Delete the objects of a file if they exist and create them again as specified",
+		Long: "Delete the objects of a file if they exist and create them again as specified, so that they match the file exactly.\n" +
+			"The virtual IP, NAT, prefixes, loadbalancer prefixes and firewall rules of replaced interfaces and the targets of replaced\n" +
+			"loadbalancers are restored, unless the file specifies them itself.",
+		Example: "dpservice-cli replace -f objects.yaml --fail-fast",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunReplace(cmd.Context(), factory, rendererOptions, sourcesOptions, opts)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+	rendererOptions.AddFlags(cmd.Flags())
+	sourcesOptions.AddFlags(cmd.Flags())
+
+	util.Must(cmd.MarkFlagRequired("filename"))
+
+	return cmd
+}
+
+type ReplaceOptions struct {
+	FailFast bool
+}
+
+func (o *ReplaceOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.FailFast, "fail-fast", o.FailFast, "Stop at the first object that cannot be deleted, created or restored.")
+}
+
+// dependencyRank orders objects after the objects they depend on: interfaces and
+// loadbalancers come first, everything attached to them or to their VNI after.
+func dependencyRank(obj any) int {
+	switch obj.(type) {
+	case *api.Interface, *api.LoadBalancer:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// SortByDependency sorts objs stably so that every object comes after the objects it depends on.
+func SortByDependency(objs []any) {
+	sort.SliceStable(objs, func(i, j int) bool {
+		return dependencyRank(objs[i]) < dependencyRank(objs[j])
+	})
+}
+
+func replaceKey(obj any) string {
+	return fmt.Sprintf("%s/%s", batchKind(obj), dynamic.ObjectKeyFromObject(obj))
+}
+
+func RunReplace(
+	ctx context.Context,
+	dpdkClientFactory DPDKClientFactory,
+	rendererFactory RendererFactory,
+	sourcesReaderFactory SourcesReaderFactory,
+	opts ReplaceOptions,
+) error {
+	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dpdk client: %w", err)
+	}
+	defer DpdkClose(cleanup)
+
+	dc := dynamic.NewFromStructured(client)
+
+	iterator, err := sourcesReaderFactory.NewIterator()
+	if err != nil {
+		return fmt.Errorf("error creating sources iterator: %w", err)
+	}
+
+	objs, err := sources.CollectObjects(iterator, runtime.DefaultScheme)
+	if err != nil {
+		return fmt.Errorf("error collecting objects: %w", err)
+	}
+	SortByDependency(objs)
+
+	specified := make(map[string]bool, len(objs))
+	for _, obj := range objs {
+		specified[replaceKey(obj)] = true
+	}
+
+	b, err := newBatchRenderer(os.Stdout, rendererFactory, "replace")
+	if err != nil {
+		return err
+	}
+	// objects are replaced one at a time, so that a failure leaves at most the failed
+	// object changed and its previous version is created again
+	failed := 0
+	for _, obj := range objs {
+		res, err := replaceObject(ctx, client, dc, obj, specified)
+		if err != nil {
+			if opts.FailFast {
+				return fmt.Errorf("error replacing %s: %w", planName(obj), err)
+			}
+			failed++
+		}
+		if err := b.add(obj, res, err); err != nil {
+			return err
+		}
+	}
+	if err := b.flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d objects failed to replace", failed, len(objs))
+	}
+	return nil
+}
+
+// replaceObject deletes the live object of obj, if any, creates obj and restores the dependents
+// of the live object that are not specified themselves. If obj cannot be created, the live
+// object and all of its dependents are created again instead.
+func replaceObject(ctx context.Context, c client.Client, dc dynamic.Client, obj any, specified map[string]bool) (any, error) {
+	live, err := dc.Get(ctx, obj)
+	if dynamic.IsNotFound(err) {
+		return dc.Create(ctx, obj)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting: %w", err)
+	}
+
+	var deps []any
+	switch obj := obj.(type) {
+	case *api.Interface:
+		deps, err = interfaceDependents(ctx, c, obj.ID)
+	case *api.LoadBalancer:
+		deps, err = loadBalancerDependents(ctx, c, obj.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting dependents: %w", err)
+	}
+
+	if _, err := dc.Delete(ctx, obj); err != nil && !dynamic.IsNotFound(err) {
+		return nil, fmt.Errorf("error deleting: %w", err)
+	}
+
+	res, err := dc.Create(ctx, obj)
+	if err != nil {
+		if _, rollbackErr := dc.Create(ctx, live); rollbackErr != nil {
+			return nil, fmt.Errorf("error creating: %w, error restoring previous %s: %w", err, planName(obj), rollbackErr)
+		}
+		if restoreErr := restoreDependents(ctx, c, deps); restoreErr != nil {
+			return nil, fmt.Errorf("error creating, restored previous %s: %w, %w", planName(obj), err, restoreErr)
+		}
+		return nil, fmt.Errorf("error creating, restored previous %s: %w", planName(obj), err)
+	}
+
+	var restore []any
+	for _, dep := range deps {
+		if !specified[replaceKey(dep)] {
+			restore = append(restore, dep)
+		}
+	}
+	if err := restoreDependents(ctx, c, restore); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// interfaceDependents returns the objects that are deleted together with an interface.
+func interfaceDependents(ctx context.Context, c client.Client, interfaceID string) ([]any, error) {
+	var deps []any
+	vip, err := c.GetVirtualIP(ctx, interfaceID)
+	switch {
+	case err == nil:
+		deps = append(deps, &api.VirtualIP{
+			TypeMeta:      api.TypeMeta{Kind: api.VirtualIPKind},
+			VirtualIPMeta: api.VirtualIPMeta{InterfaceID: interfaceID},
+			Spec:          api.VirtualIPSpec{IP: vip.Spec.IP},
+		})
+	case !dynamic.IsNotFound(err):
+		return nil, fmt.Errorf("error getting virtual ip: %w", err)
+	}
+
+	nat, err := c.GetNat(ctx, interfaceID)
+	switch {
+	case err == nil:
+		deps = append(deps, &api.Nat{
+			TypeMeta: api.TypeMeta{Kind: api.NatKind},
+			NatMeta:  api.NatMeta{InterfaceID: interfaceID},
+			Spec:     api.NatSpec{NatIP: nat.Spec.NatIP, MinPort: nat.Spec.MinPort, MaxPort: nat.Spec.MaxPort},
+		})
+	case !dynamic.IsNotFound(err):
+		return nil, fmt.Errorf("error getting nat: %w", err)
+	}
+
+	prefixes, err := c.ListPrefixes(ctx, interfaceID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing prefixes: %w", err)
+	}
+	for _, prefix := range prefixes.Items {
+		deps = append(deps, &api.Prefix{
+			TypeMeta:   api.TypeMeta{Kind: api.PrefixKind},
+			PrefixMeta: api.PrefixMeta{InterfaceID: interfaceID},
+			Spec:       api.PrefixSpec{Prefix: prefix.Spec.Prefix},
+		})
+	}
+
+	lbPrefixes, err := c.ListLoadBalancerPrefixes(ctx, interfaceID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing loadbalancer prefixes: %w", err)
+	}
+	for _, prefix := range lbPrefixes.Items {
+		deps = append(deps, &api.LoadBalancerPrefix{
+			TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerPrefixKind},
+			LoadBalancerPrefixMeta: api.LoadBalancerPrefixMeta{InterfaceID: interfaceID},
+			Spec:                   api.LoadBalancerPrefixSpec{Prefix: prefix.Spec.Prefix},
+		})
+	}
+
+	rules, err := c.ListFirewallRules(ctx, interfaceID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing firewall rules: %w", err)
+	}
+	for i := range rules.Items {
+		rule := rules.Items[i]
+		rule.TypeMeta = api.TypeMeta{Kind: api.FirewallRuleKind}
+		rule.InterfaceID = interfaceID
+		rule.Status = api.Status{}
+		deps = append(deps, &rule)
+	}
+	return deps, nil
+}
+
+// loadBalancerDependents returns the objects that are deleted together with a loadbalancer.
+func loadBalancerDependents(ctx context.Context, c client.Client, loadBalancerID string) ([]any, error) {
+	targets, err := c.ListLoadBalancerTargets(ctx, loadBalancerID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing loadbalancer targets: %w", err)
+	}
+	deps := make([]any, 0, len(targets.Items))
+	for _, target := range targets.Items {
+		deps = append(deps, &api.LoadBalancerTarget{
+			TypeMeta:               api.TypeMeta{Kind: api.LoadBalancerTargetKind},
+			LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: loadBalancerID},
+			Spec:                   api.LoadBalancerTargetSpec{TargetIP: target.Spec.TargetIP},
+		})
+	}
+	return deps, nil
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// replaceClient has an interface vm1 with a prefix and a virtual IP and records all mutating calls.
+type replaceClient struct {
+	client.Client
+	iface    bool
+	prefixes []netip.Prefix
+	vip      *netip.Addr
+	calls    []string
+	// createErrs is the number of CreateInterface calls to fail
+	createErrs int
+}
+
+func (c *replaceClient) GetInterface(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	if !c.iface {
+		return &api.Interface{Status: api.Status{Code: apierrors.NO_VM}}, apierrors.NewStatusError(apierrors.NO_VM, "NO_VM")
+	}
+	return &api.Interface{InterfaceMeta: api.InterfaceMeta{ID: id}}, nil
+}
+
+func (c *replaceClient) DeleteInterface(ctx context.Context, id string, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	c.calls = append(c.calls, "delete interface/"+id)
+	c.iface, c.prefixes, c.vip = false, nil, nil
+	return &api.Interface{}, nil
+}
+
+func (c *replaceClient) CreateInterface(ctx context.Context, iface *api.Interface, ignoredErrors ...[]uint32) (*api.Interface, error) {
+	c.calls = append(c.calls, "create interface/"+iface.ID)
+	if c.createErrs > 0 {
+		c.createErrs--
+		return &api.Interface{}, apierrors.NewStatusError(apierrors.VNF_INSERT, "VNF_INSERT")
+	}
+	c.iface = true
+	return iface, nil
+}
+
+func (c *replaceClient) GetVirtualIP(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	if c.vip == nil {
+		return &api.VirtualIP{}, apierrors.NewStatusError(apierrors.SNAT_NO_DATA, "SNAT_NO_DATA")
+	}
+	return &api.VirtualIP{Spec: api.VirtualIPSpec{IP: c.vip}}, nil
+}
+
+func (c *replaceClient) CreateVirtualIP(ctx context.Context, vip *api.VirtualIP, ignoredErrors ...[]uint32) (*api.VirtualIP, error) {
+	c.calls = append(c.calls, "create virtualip/"+vip.Spec.IP.String())
+	return vip, nil
+}
+
+func (c *replaceClient) GetNat(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.Nat, error) {
+	return &api.Nat{}, apierrors.NewStatusError(apierrors.SNAT_NO_DATA, "SNAT_NO_DATA")
+}
+
+func (c *replaceClient) ListPrefixes(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error) {
+	list := &api.PrefixList{}
+	for _, prefix := range c.prefixes {
+		list.Items = append(list.Items, api.Prefix{Spec: api.PrefixSpec{Prefix: prefix}})
+	}
+	return list, nil
+}
+
+func (c *replaceClient) DeletePrefix(ctx context.Context, interfaceID string, prefix *netip.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error) {
+	c.calls = append(c.calls, "delete prefix/"+prefix.String())
+	c.prefixes = nil
+	return &api.Prefix{}, nil
+}
+
+func (c *replaceClient) CreatePrefix(ctx context.Context, prefix *api.Prefix, ignoredErrors ...[]uint32) (*api.Prefix, error) {
+	c.calls = append(c.calls, "create prefix/"+prefix.Spec.Prefix.String())
+	return prefix, nil
+}
+
+func (c *replaceClient) ListLoadBalancerPrefixes(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.PrefixList, error) {
+	return &api.PrefixList{}, nil
+}
+
+func (c *replaceClient) ListFirewallRules(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.FirewallRuleList, error) {
+	return &api.FirewallRuleList{}, nil
+}
+
+var _ = Describe("Replace", func() {
+	It("should order objects after the objects they depend on", func() {
+		prefix := &api.Prefix{}
+		iface := &api.Interface{}
+		lb := &api.LoadBalancer{}
+		objs := []any{prefix, iface, lb}
+		SortByDependency(objs)
+		Expect(objs).To(Equal([]any{iface, lb, prefix}))
+	})
+
+	It("should delete and recreate the objects and restore unspecified dependents", func(ctx SpecContext) {
+		vip := netip.MustParseAddr("20.0.0.1")
+		c := &replaceClient{iface: true, prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")}, vip: &vip}
+
+		filename := filepath.Join(GinkgoT().TempDir(), "objects.yaml")
+		Expect(os.WriteFile(filename, []byte(
+			"kind: Prefix\nmetadata:\n  interface_id: vm1\nspec:\n  prefix: 10.0.0.0/24\n"+
+				"---\n"+
+				"kind: Interface\nmetadata:\n  id: vm1\nspec:\n  vni: 100\n  device: net_tap5\n",
+		), 0o644)).To(Succeed())
+
+		Expect(RunReplace(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, &SourcesOptions{Filename: []string{filename}}, ReplaceOptions{})).To(Succeed())
+		Expect(c.calls).To(Equal([]string{
+			"delete interface/vm1",
+			"create interface/vm1",
+			"create virtualip/20.0.0.1",
+			"create prefix/10.0.0.0/24",
+		}))
+	})
+
+	It("should restore the previous object and its dependents if create fails with --fail-fast", func(ctx SpecContext) {
+		vip := netip.MustParseAddr("20.0.0.1")
+		c := &replaceClient{iface: true, prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")}, vip: &vip, createErrs: 1}
+
+		filename := filepath.Join(GinkgoT().TempDir(), "objects.yaml")
+		Expect(os.WriteFile(filename, []byte(
+			"kind: Interface\nmetadata:\n  id: vm1\nspec:\n  vni: 100\n  device: net_tap5\n"+
+				"---\n"+
+				"kind: Prefix\nmetadata:\n  interface_id: vm1\nspec:\n  prefix: 10.1.0.0/24\n",
+		), 0o644)).To(Succeed())
+
+		err := RunReplace(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, &SourcesOptions{Filename: []string{filename}}, ReplaceOptions{FailFast: true})
+		Expect(err).To(MatchError(ContainSubstring("restored previous interface/vm1")))
+		Expect(c.calls).To(Equal([]string{
+			"delete interface/vm1",
+			"create interface/vm1",
+			"create interface/vm1",
+			"create virtualip/20.0.0.1",
+			"create prefix/10.0.0.0/24",
+		}))
+		Expect(c.iface).To(BeTrue())
+	})
+
+	It("should continue after a failed object without --fail-fast and fail in the end", func(ctx SpecContext) {
+		c := &replaceClient{createErrs: 1}
+
+		filename := filepath.Join(GinkgoT().TempDir(), "objects.yaml")
+		Expect(os.WriteFile(filename, []byte(
+			"kind: Interface\nmetadata:\n  id: vm1\nspec:\n  vni: 100\n  device: net_tap5\n"+
+				"---\n"+
+				"kind: Interface\nmetadata:\n  id: vm2\nspec:\n  vni: 100\n  device: net_tap6\n",
+		), 0o644)).To(Succeed())
+
+		err := RunReplace(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, &SourcesOptions{Filename: []string{filename}}, ReplaceOptions{})
+		Expect(err).To(MatchError("1 of 2 objects failed to replace"))
+		Expect(c.calls).To(Equal([]string{"create interface/vm1", "create interface/vm2"}))
+	})
+
+	It("should create objects that do not exist yet", func(ctx SpecContext) {
+		c := &replaceClient{}
+
+		filename := filepath.Join(GinkgoT().TempDir(), "objects.yaml")
+		Expect(os.WriteFile(filename, []byte("kind: Interface\nmetadata:\n  id: vm1\nspec:\n  vni: 100\n  device: net_tap5\n"), 0o644)).To(Succeed())
+
+		Expect(RunReplace(ctx, fakeClientFactory{c}, &RendererOptions{Output: "name"}, &SourcesOptions{Filename: []string{filename}}, ReplaceOptions{FailFast: true})).To(Succeed())
+		Expect(c.calls).To(Equal([]string{"create interface/vm1"}))
+	})
+})
@@ -13,9 +13,10 @@ func Reset(factory DPDKClientFactory) *cobra.Command {
 	rendererOptions := &RendererOptions{Output: "name"}
 
 	cmd := &cobra.Command{
-		Use:  "reset [command]",
-		Args: cobra.NoArgs,
-		RunE: SubcommandRequired,
+		Use:         "reset [command]",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Args:        cobra.NoArgs,
+		RunE:        SubcommandRequired,
 	}
 
 	rendererOptions.AddFlags(cmd.PersistentFlags())
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
 	"github.com/ironcore-dev/dpservice-cli/util"
 	"github.com/ironcore-dev/dpservice-go/errors"
 	"github.com/spf13/cobra"
@@ -75,19 +76,12 @@ func RunResetVni(
 	}
 	defer DpdkClose(cleanup)
 
-	var vniType uint8
-	switch strings.ToLower(opts.VniType) {
-	case "ipv4", "0":
-		vniType = 0
-	case "ipv6", "1":
-		vniType = 1
-	case "both", "2":
-		vniType = 2
-	default:
-		return fmt.Errorf("VNI type can be only: ipv4 = 0/ipv6 = 1/both = 2")
+	vniType, err := conversion.VniTypes.Parse(opts.VniType)
+	if err != nil {
+		return fmt.Errorf("error parsing vni type: %w", err)
 	}
 
-	vni, err := client.ResetVni(ctx, opts.VNI, vniType)
+	vni, err := client.ResetVni(ctx, opts.VNI, uint8(vniType))
 	if err != nil && !strings.Contains(err.Error(), errors.StatusErrorString) {
 		return fmt.Errorf("error resetting vni: %w", err)
 	}
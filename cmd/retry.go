@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RetryPolicy string
+
+const (
+	// RetryPolicySafe retries only calls that do not change the state of dpservice.
+	RetryPolicySafe RetryPolicy = "safe"
+	// RetryPolicyAll also retries mutations. dpservice has no idempotency keys, so a
+	// mutation whose response got lost is sent again, which usually fails with an
+	// "already exists" or "not found" status.
+	RetryPolicyAll  RetryPolicy = "all"
+	RetryPolicyNone RetryPolicy = "none"
+)
+
+var RetryPolicies = []RetryPolicy{RetryPolicySafe, RetryPolicyAll, RetryPolicyNone}
+
+func ParseRetryPolicy(s string) (RetryPolicy, error) {
+	for _, policy := range RetryPolicies {
+		if RetryPolicy(s) == policy {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("invalid retry policy %q, must be one of safe, all, none", s)
+}
+
+// ParseRetryCodes converts the status codes of --retry-on-codes.
+func ParseRetryCodes(codes []uint) ([]uint32, error) {
+	res := make([]uint32, 0, len(codes))
+	for _, code := range codes {
+		if code == 0 || code > math.MaxUint32 {
+			return nil, fmt.Errorf("invalid status code %d in --retry-on-codes", code)
+		}
+		res = append(res, uint32(code))
+	}
+	return res, nil
+}
+
+const (
+	DefaultMaxRetries   = 2
+	DefaultRetryBackoff = 200 * time.Millisecond
+)
+
+// IsReadMethod reports whether the gRPC method only reads the state of dpservice.
+func IsReadMethod(fullMethod string) bool {
+	name := path.Base(fullMethod)
+	for _, prefix := range []string{"Get", "List", "Check"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return name == "CaptureStatus"
+}
+
+// isTransient reports whether a call failed in a way that may succeed when retried.
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// statusCode returns the application status code dpservice reported in reply, 0 if none.
+func statusCode(reply any) uint32 {
+	if r, ok := reply.(interface{ GetStatus() *dpdkproto.Status }); ok {
+		return r.GetStatus().GetCode()
+	}
+	return 0
+}
+
+// RetryInterceptor retries calls that failed with a transient error, if the policy allows
+// retrying the method.
+type RetryInterceptor struct {
+	Policy     RetryPolicy
+	MaxRetries int
+	// Backoff is the delay before the first retry, it doubles with every further retry.
+	Backoff time.Duration
+	// Codes are application status codes (see dpservice-go/errors) that are retried like
+	// transient errors. dpservice reports them in the reply, not as gRPC errors.
+	Codes []uint32
+	// OnRetry is called with the method before every retry.
+	OnRetry func(method string)
+}
+
+func NewRetryInterceptor(policy RetryPolicy) *RetryInterceptor {
+	return &RetryInterceptor{Policy: policy, MaxRetries: DefaultMaxRetries, Backoff: DefaultRetryBackoff}
+}
+
+// jitter returns a random delay between half and one and a half times backoff, so that
+// clients failing at the same time do not retry in lockstep.
+func jitter(backoff time.Duration) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff)))
+}
+
+func (r *RetryInterceptor) retryable(method string) bool {
+	switch r.Policy {
+	case RetryPolicyAll:
+		return true
+	case RetryPolicySafe:
+		return IsReadMethod(method)
+	default:
+		return false
+	}
+}
+
+func (r *RetryInterceptor) shouldRetry(reply any, err error) bool {
+	if err != nil {
+		return isTransient(err)
+	}
+	return slices.Contains(r.Codes, statusCode(reply))
+}
+
+func (r *RetryInterceptor) UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if !r.retryable(method) {
+		return err
+	}
+
+	backoff := r.Backoff
+	for retries := 0; retries < r.MaxRetries && r.shouldRetry(reply, err); retries++ {
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(jitter(backoff)):
+		}
+		backoff *= 2
+		if r.OnRetry != nil {
+			r.OnRetry(method)
+		}
+		err = invoker(ctx, method, req, reply, cc, opts...)
+	}
+	return err
+}
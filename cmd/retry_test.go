@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Describe("RetryInterceptor", func() {
+	const (
+		listMethod   = "/dpdkironcore.v1.DPDKironcore/ListInterfaces"
+		createMethod = "/dpdkironcore.v1.DPDKironcore/CreateInterface"
+	)
+
+	DescribeTable("attempts per policy",
+		func(policy RetryPolicy, method string, code codes.Code, expectedAttempts int) {
+			retry := NewRetryInterceptor(policy)
+			retry.Backoff = 0
+
+			attempts := 0
+			invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				attempts++
+				return status.Error(code, "failed")
+			}
+			err := retry.UnaryClientInterceptor(context.Background(), method, nil, nil, nil, invoker)
+			Expect(status.Code(err)).To(Equal(code))
+			Expect(attempts).To(Equal(expectedAttempts))
+		},
+		Entry("safe retries reads", RetryPolicySafe, listMethod, codes.Unavailable, 3),
+		Entry("safe does not retry mutations", RetryPolicySafe, createMethod, codes.Unavailable, 1),
+		Entry("all retries mutations", RetryPolicyAll, createMethod, codes.Unavailable, 3),
+		Entry("none does not retry", RetryPolicyNone, listMethod, codes.Unavailable, 1),
+		Entry("permanent errors are not retried", RetryPolicyAll, listMethod, codes.InvalidArgument, 1),
+	)
+
+	It("should stop retrying once a call succeeds", func() {
+		retry := NewRetryInterceptor(RetryPolicySafe)
+		retry.Backoff = 0
+
+		attempts := 0
+		invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			attempts++
+			if attempts == 1 {
+				return status.Error(codes.Unavailable, "failed")
+			}
+			return nil
+		}
+		Expect(retry.UnaryClientInterceptor(context.Background(), listMethod, nil, nil, nil, invoker)).To(Succeed())
+		Expect(attempts).To(Equal(2))
+	})
+
+	It("should retry configured application status codes", func() {
+		retry := NewRetryInterceptor(RetryPolicySafe)
+		retry.Backoff = 0
+		retry.Codes = []uint32{apierrors.LIMIT_REACHED}
+
+		attempts := 0
+		invoker := func(_ context.Context, _ string, _, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			attempts++
+			res := reply.(*dpdkproto.ListInterfacesResponse)
+			res.Status = &dpdkproto.Status{}
+			if attempts == 1 {
+				res.Status.Code = apierrors.LIMIT_REACHED
+			}
+			return nil
+		}
+		reply := &dpdkproto.ListInterfacesResponse{}
+		Expect(retry.UnaryClientInterceptor(context.Background(), listMethod, nil, reply, nil, invoker)).To(Succeed())
+		Expect(attempts).To(Equal(2))
+		Expect(reply.GetStatus().GetCode()).To(BeZero())
+	})
+
+	It("should retry at most MaxRetries times", func() {
+		retry := NewRetryInterceptor(RetryPolicySafe)
+		retry.Backoff = 0
+		retry.MaxRetries = 4
+
+		attempts := 0
+		invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			attempts++
+			return status.Error(codes.DeadlineExceeded, "failed")
+		}
+		Expect(retry.UnaryClientInterceptor(context.Background(), listMethod, nil, nil, nil, invoker)).NotTo(Succeed())
+		Expect(attempts).To(Equal(5))
+	})
+
+	It("should not retry application status codes by default", func() {
+		retry := NewRetryInterceptor(RetryPolicyAll)
+		retry.Backoff = 0
+
+		attempts := 0
+		invoker := func(_ context.Context, _ string, _, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+			attempts++
+			reply.(*dpdkproto.ListInterfacesResponse).Status = &dpdkproto.Status{Code: apierrors.LIMIT_REACHED}
+			return nil
+		}
+		Expect(retry.UnaryClientInterceptor(context.Background(), listMethod, nil, &dpdkproto.ListInterfacesResponse{}, nil, invoker)).To(Succeed())
+		Expect(attempts).To(Equal(1))
+	})
+
+	It("should reject invalid status codes", func() {
+		Expect(ParseRetryCodes([]uint{209, 0})).Error().To(HaveOccurred())
+		Expect(ParseRetryCodes([]uint{209})).To(Equal([]uint32{209}))
+	})
+
+	It("should classify read methods", func() {
+		Expect(IsReadMethod("/dpdkironcore.v1.DPDKironcore/GetVip")).To(BeTrue())
+		Expect(IsReadMethod("/dpdkironcore.v1.DPDKironcore/CheckVniInUse")).To(BeTrue())
+		Expect(IsReadMethod("/dpdkironcore.v1.DPDKironcore/CaptureStatus")).To(BeTrue())
+		Expect(IsReadMethod("/dpdkironcore.v1.DPDKironcore/ResetVni")).To(BeFalse())
+		Expect(IsReadMethod("/dpdkironcore.v1.DPDKironcore/Initialize")).To(BeFalse())
+	})
+})
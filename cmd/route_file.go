@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// RoutesFormatBird is the routing-table format accepted by 'create route --routes-file'.
+const RoutesFormatBird = "bird"
+
+// ReadBirdRoutes reads routes in a subset of the BIRD static route syntax, one route per line:
+//
+//	[route] <prefix> via <next-hop-ip> [vni <vni>] [next-hop-vni <vni>] [;]
+//
+// Everything after a # is a comment, empty lines are skipped. Routes without an inline
+// vni or next-hop-vni use vni and nextHopVNI, a nil vni makes the inline vni mandatory.
+func ReadBirdRoutes(r io.Reader, vni *uint32, nextHopVNI uint32) ([]api.Route, error) {
+	var routes []api.Route
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), ";"))
+		if text == "" {
+			continue
+		}
+		route, err := parseBirdRoute(strings.Fields(text), vni, nextHopVNI)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		routes = append(routes, *route)
+	}
+	return routes, scanner.Err()
+}
+
+func parseBirdRoute(fields []string, vni *uint32, nextHopVNI uint32) (*api.Route, error) {
+	if len(fields) > 0 && fields[0] == "route" {
+		fields = fields[1:]
+	}
+	if len(fields) < 3 || fields[1] != "via" {
+		return nil, fmt.Errorf("expected '<prefix> via <next-hop-ip>'")
+	}
+
+	prefix, err := netip.ParsePrefix(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix: %w", err)
+	}
+	nextHopIP, err := netip.ParseAddr(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid next hop ip: %w", err)
+	}
+
+	attrs := fields[3:]
+	for ; len(attrs) > 0; attrs = attrs[2:] {
+		if len(attrs) < 2 {
+			return nil, fmt.Errorf("missing value for %q", attrs[0])
+		}
+		value, err := strconv.ParseUint(attrs[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", attrs[0], attrs[1], err)
+		}
+		v := uint32(value)
+		switch attrs[0] {
+		case "vni":
+			vni = &v
+		case "next-hop-vni":
+			nextHopVNI = v
+		default:
+			return nil, fmt.Errorf("unknown attribute %q, supported: vni, next-hop-vni", attrs[0])
+		}
+	}
+	if vni == nil {
+		return nil, fmt.Errorf("no vni given inline or with --vni")
+	}
+
+	return &api.Route{
+		TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+		RouteMeta: api.RouteMeta{VNI: *vni},
+		Spec: api.RouteSpec{
+			Prefix: &prefix,
+			NextHop: &api.RouteNextHop{
+				VNI: nextHopVNI,
+				IP:  &nextHopIP,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"strings"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type routeClient struct {
+	client.Client
+	routes  []api.Route
+	created []string
+}
+
+// CreateRoute returns the route with its prefix masked, like dpservice stores it.
+func (c *routeClient) CreateRoute(ctx context.Context, route *api.Route, ignoredErrors ...[]uint32) (*api.Route, error) {
+	c.created = append(c.created, route.Spec.Prefix.String())
+	res := *route
+	prefix := route.Spec.Prefix.Masked()
+	res.Spec.Prefix = &prefix
+	return &res, nil
+}
+
+var _ = Describe("ReadBirdRoutes", func() {
+	It("should read routes with inline attributes overriding the defaults", func() {
+		vni := uint32(100)
+		routes, err := ReadBirdRoutes(strings.NewReader(
+			"# static routes\n"+
+				"route 10.0.1.5/24 via fc00::1;\n"+
+				"\n"+
+				"10.0.2.0/24 via fc00::2 vni 200 next-hop-vni 7 # peer\n",
+		), &vni, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(HaveLen(2))
+		Expect(routes[0].VNI).To(Equal(uint32(100)))
+		Expect(routes[0].Spec.Prefix.String()).To(Equal("10.0.1.5/24"))
+		Expect(routes[0].Spec.NextHop.IP.String()).To(Equal("fc00::1"))
+		Expect(routes[1].VNI).To(Equal(uint32(200)))
+		Expect(routes[1].Spec.NextHop.VNI).To(Equal(uint32(7)))
+	})
+
+	It("should report parse errors with their line", func() {
+		vni := uint32(100)
+		for input, msg := range map[string]string{
+			"10.0.1.0/24 via fc00::1\n10.0.2.0/24 fc00::2\n":  "line 2: expected",
+			"10.0.1.0/33 via fc00::1\n":                       "line 1: invalid prefix",
+			"\n10.0.1.0/24 via fc00::1 vni\n":                 "line 2: missing value",
+			"10.0.1.0/24 via fc00::1 metric 5\n":              "line 1: unknown attribute",
+			"10.0.1.0/24 via fc00::1 next-hop-vni -1\n":       "line 1: invalid next-hop-vni",
+			"# header\n# more\n10.0.1.0/24 via 10.0.0.300;\n": "line 3: invalid next hop ip",
+		} {
+			_, err := ReadBirdRoutes(strings.NewReader(input), &vni, 0)
+			Expect(err).To(MatchError(ContainSubstring(msg)), input)
+		}
+
+		_, err := ReadBirdRoutes(strings.NewReader("10.0.1.0/24 via fc00::1\n"), nil, 0)
+		Expect(err).To(MatchError(ContainSubstring("line 1: no vni")))
+	})
+
+	It("should create the routes of --routes-file", func(ctx SpecContext) {
+		c := &routeClient{}
+		cmd := CreateRoute(fakeClientFactory{c}, &RendererOptions{Output: "name"})
+		cmd.SetIn(strings.NewReader("10.0.1.0/24 via fc00::1\n10.0.2.0/24 via fc00::2 vni 200\n"))
+		cmd.SetArgs([]string{"--routes-file=-", "--vni=100"})
+
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+		Expect(c.created).To(Equal([]string{"10.0.1.0/24", "10.0.2.0/24"}))
+	})
+
+	It("should render the routes as returned by dpservice", func(ctx SpecContext) {
+		stdout := captureOutput(&os.Stdout)
+
+		cmd := CreateRoute(fakeClientFactory{&routeClient{}}, &RendererOptions{Output: "jsonpath={.spec.prefix}"})
+		cmd.SetIn(strings.NewReader("10.0.1.5/24 via fc00::1\n"))
+		cmd.SetArgs([]string{"--routes-file=-", "--vni=100"})
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+		Expect(os.ReadFile(stdout)).To(BeEquivalentTo("10.0.1.0/24\n"))
+	})
+
+	It("should reject inline vnis above --max-vni", func(ctx SpecContext) {
+		c := &routeClient{}
+		cmd := CreateRoute(fakeClientFactory{c}, &RendererOptions{Output: "name"})
+		(&VNIOptions{}).AddFlags(cmd.PersistentFlags())
+		cmd.SetIn(strings.NewReader("10.0.1.0/24 via fc00::1\n10.0.2.0/24 via fc00::2 next-hop-vni 200\n"))
+		cmd.SetArgs([]string{"--routes-file=-", "--vni=100", "--max-vni=100"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		Expect(cmd.ExecuteContext(ctx)).To(MatchError("invalid route 10.0.2.0/24: next hop vni 200 is out of range, must be between 0 and 100"))
+		Expect(c.created).To(BeEmpty())
+	})
+
+	It("should reject --routes-file combined with --prefix", func(ctx SpecContext) {
+		cmd := CreateRoute(fakeClientFactory{&routeClient{}}, &RendererOptions{Output: "name"})
+		cmd.SetIn(strings.NewReader("10.0.1.0/24 via fc00::1\n"))
+		cmd.SetArgs([]string{"--routes-file=-", "--vni=100", "--prefix=10.0.0.0/24"})
+		cmd.SilenceUsage = true
+
+		Expect(cmd.ExecuteContext(ctx)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("CreateRoute --read-after-write", func() {
+	It("should read back the route with the masked prefix and the same next hop", func(ctx SpecContext) {
+		route := func(nextHopIP string) api.Route {
+			p := netip.MustParsePrefix("10.0.1.0/24")
+			ip := netip.MustParseAddr(nextHopIP)
+			return api.Route{
+				TypeMeta:  api.TypeMeta{Kind: api.RouteKind},
+				RouteMeta: api.RouteMeta{VNI: 100},
+				Spec:      api.RouteSpec{Prefix: &p, NextHop: &api.RouteNextHop{VNI: 7, IP: &ip}},
+			}
+		}
+		c := &routeClient{routes: []api.Route{route("fc00::1"), route("fc00::2")}}
+
+		stdout := captureOutput(&os.Stdout)
+
+		cmd := CreateRoute(fakeClientFactory{c}, &RendererOptions{Output: "jsonpath={.spec.next_hop.address}"})
+		cmd.SetArgs([]string{"--vni=100", "--prefix=10.0.1.5/24", "--next-hop-vni=7", "--next-hop-ip=fc00::2", "--read-after-write"})
+		cmd.SilenceUsage = true
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+		Expect(os.ReadFile(stdout)).To(BeEquivalentTo("fc00::2\n"))
+	})
+})
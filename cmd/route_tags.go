@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/pflag"
+)
+
+// TaggedRoute identifies a route recorded under a tag.
+type TaggedRoute struct {
+	VNI        uint32       `json:"vni"`
+	Prefix     netip.Prefix `json:"prefix"`
+	NextHopVNI uint32       `json:"nextHopVni"`
+	NextHopIP  netip.Addr   `json:"nextHopIp"`
+}
+
+// RouteTagStore associates tags with routes created through the CLI.
+//
+// dpservice has no labels for routes, so the store is a file on the machine running
+// the CLI. It is not synchronized with dpservice and drifts if tagged routes are
+// created, changed or deleted by other means (other machines, untagged deletes).
+type RouteTagStore struct {
+	Path string
+}
+
+func DefaultRouteTagStorePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting config dir: %w", err)
+	}
+	return filepath.Join(dir, "dpservice-cli", "route-tags.json"), nil
+}
+
+type RouteTagOptions struct {
+	Tag      string
+	TagStore string
+}
+
+func (o *RouteTagOptions) AddFlags(fs *pflag.FlagSet, usage string) {
+	fs.StringVar(&o.Tag, "tag", o.Tag, usage+" The tag store is local to this machine and can drift if routes are changed by other means.")
+	fs.StringVar(&o.TagStore, "tag-store", o.TagStore, "Path of the local route tag store (defaults to route-tags.json in the user config dir).")
+}
+
+func (o *RouteTagOptions) Store() (*RouteTagStore, error) {
+	if o.TagStore != "" {
+		return &RouteTagStore{Path: o.TagStore}, nil
+	}
+	path, err := DefaultRouteTagStorePath()
+	if err != nil {
+		return nil, err
+	}
+	return &RouteTagStore{Path: path}, nil
+}
+
+func (s *RouteTagStore) load() (map[string][]TaggedRoute, error) {
+	tags := make(map[string][]TaggedRoute)
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return tags, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading route tag store: %w", err)
+	}
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil, fmt.Errorf("error unmarshaling route tag store %s: %w", s.Path, err)
+	}
+	return tags, nil
+}
+
+func (s *RouteTagStore) save(tags map[string][]TaggedRoute) error {
+	data, err := json.MarshalIndent(tags, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling route tag store: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o755); err != nil {
+		return fmt.Errorf("error creating route tag store dir: %w", err)
+	}
+
+	// write to a temporary file first, so that a failed write does not lose all tags
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("error writing route tag store: %w", err)
+	}
+	if err := os.Rename(tmp, s.Path); err != nil {
+		return fmt.Errorf("error writing route tag store: %w", err)
+	}
+	return nil
+}
+
+// Add records route under tag.
+func (s *RouteTagStore) Add(tag string, route TaggedRoute) error {
+	tags, err := s.load()
+	if err != nil {
+		return err
+	}
+	for _, r := range tags[tag] {
+		if r == route {
+			return nil
+		}
+	}
+	tags[tag] = append(tags[tag], route)
+	sort.Slice(tags[tag], func(i, j int) bool {
+		ri, rj := tags[tag][i], tags[tag][j]
+		if ri.VNI != rj.VNI {
+			return ri.VNI < rj.VNI
+		}
+		return ri.Prefix.String() < rj.Prefix.String()
+	})
+	return s.save(tags)
+}
+
+// Get returns the routes recorded under tag.
+func (s *RouteTagStore) Get(tag string) ([]TaggedRoute, error) {
+	tags, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return tags[tag], nil
+}
+
+// Remove forgets route under tag and drops the tag once it has no routes left.
+func (s *RouteTagStore) Remove(tag string, route TaggedRoute) error {
+	tags, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	routes := tags[tag][:0]
+	for _, r := range tags[tag] {
+		if r != route {
+			routes = append(routes, r)
+		}
+	}
+	if len(routes) == 0 {
+		delete(tags, tag)
+	} else {
+		tags[tag] = routes
+	}
+	return s.save(tags)
+}
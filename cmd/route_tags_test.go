@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"net/netip"
+	"path/filepath"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouteTagStore", func() {
+	var (
+		store  *RouteTagStore
+		route1 = TaggedRoute{VNI: 100, Prefix: netip.MustParsePrefix("10.0.1.0/24"), NextHopIP: netip.MustParseAddr("fc00::1")}
+		route2 = TaggedRoute{VNI: 100, Prefix: netip.MustParsePrefix("10.0.2.0/24"), NextHopIP: netip.MustParseAddr("fc00::2")}
+	)
+
+	BeforeEach(func() {
+		store = &RouteTagStore{Path: filepath.Join(GinkgoT().TempDir(), "sub", "route-tags.json")}
+	})
+
+	It("should treat a missing store as empty", func() {
+		routes, err := store.Get("mytag")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(routes).To(BeEmpty())
+	})
+
+	It("should return exactly the routes added under a tag", func() {
+		Expect(store.Add("mytag", route2)).To(Succeed())
+		Expect(store.Add("mytag", route1)).To(Succeed())
+		Expect(store.Add("mytag", route1)).To(Succeed())
+		Expect(store.Add("other", route2)).To(Succeed())
+
+		Expect(store.Get("mytag")).To(Equal([]TaggedRoute{route1, route2}))
+		Expect(store.Get("other")).To(Equal([]TaggedRoute{route2}))
+	})
+
+	It("should drop a tag once its last route is removed", func() {
+		Expect(store.Add("mytag", route1)).To(Succeed())
+		Expect(store.Add("mytag", route2)).To(Succeed())
+
+		Expect(store.Remove("mytag", route1)).To(Succeed())
+		Expect(store.Get("mytag")).To(Equal([]TaggedRoute{route2}))
+
+		Expect(store.Remove("mytag", route2)).To(Succeed())
+		Expect(store.Get("mytag")).To(BeEmpty())
+	})
+})
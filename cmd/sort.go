@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+)
+
+// SortItems sorts items, the items of list, stably by the table columns of list, see
+// renderer.SortOrder. Items equal in the first column are sorted by the next one, empty
+// column names are skipped. Addresses of IPv6 come first if preferIPv6 is set.
+func SortItems[T any](list any, items []T, preferIPv6 bool, columns ...string) error {
+	converter := renderer.DefaultTableConverter
+	// wide, so that every column can be sorted by
+	converter.SetWide(true)
+
+	for i := len(columns) - 1; i >= 0; i-- {
+		if columns[i] == "" {
+			continue
+		}
+		data, err := converter.ConvertToTable(list)
+		if err != nil {
+			return fmt.Errorf("error sorting by %s: %w", columns[i], err)
+		}
+		if len(data.Columns) != len(items) {
+			return fmt.Errorf("error sorting by %s: %d rows for %d items", columns[i], len(data.Columns), len(items))
+		}
+		order, err := renderer.SortOrder(data, columns[i], preferIPv6)
+		if err != nil {
+			return fmt.Errorf("error sorting by %s: %w", columns[i], err)
+		}
+		sorted := make([]T, len(items))
+		for j, k := range order {
+			sorted[j] = items[k]
+		}
+		copy(items, sorted)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"io"
+	"net/netip"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SortItems", func() {
+	route := func(prefix string, nextHopVNI uint32) api.Route {
+		p := netip.MustParsePrefix(prefix)
+		return api.Route{Spec: api.RouteSpec{
+			Prefix:  &p,
+			NextHop: &api.RouteNextHop{VNI: nextHopVNI},
+		}}
+	}
+	prefixes := func(routes []api.Route) []string {
+		var res []string
+		for _, route := range routes {
+			res = append(res, route.Spec.Prefix.String())
+		}
+		return res
+	}
+
+	It("should sort by a column and then by the next one", func() {
+		list := &api.RouteList{Items: []api.Route{
+			route("10.0.0.10/32", 200), route("10.0.0.2/32", 200), route("10.0.0.3/32", 100),
+		}}
+		Expect(SortItems(list, list.Items, false, "NextHopVNI", "Prefix")).To(Succeed())
+		Expect(prefixes(list.Items)).To(Equal([]string{"10.0.0.3/32", "10.0.0.2/32", "10.0.0.10/32"}))
+	})
+
+	It("should skip empty columns", func() {
+		list := &api.RouteList{Items: []api.Route{route("10.0.0.10/32", 0), route("10.0.0.2/32", 0)}}
+		Expect(SortItems(list, list.Items, false, "", "Prefix")).To(Succeed())
+		Expect(prefixes(list.Items)).To(Equal([]string{"10.0.0.2/32", "10.0.0.10/32"}))
+	})
+
+	It("should sort IPv6 prefixes first if preferred", func() {
+		list := &api.RouteList{Items: []api.Route{route("10.0.0.0/24", 0), route("fc00::/64", 0), route("10.0.1.0/24", 0)}}
+		Expect(SortItems(list, list.Items, true, "Prefix")).To(Succeed())
+		Expect(prefixes(list.Items)).To(Equal([]string{"fc00::/64", "10.0.0.0/24", "10.0.1.0/24"}))
+	})
+})
+
+// firewallRuleClient lists the firewall rules of one interface.
+type firewallRuleClient struct {
+	client.Client
+	rules []api.FirewallRule
+}
+
+func (c *firewallRuleClient) ListFirewallRules(ctx context.Context, interfaceID string, ignoredErrors ...[]uint32) (*api.FirewallRuleList, error) {
+	return &api.FirewallRuleList{TypeMeta: api.TypeMeta{Kind: api.FirewallRuleListKind}, Items: c.rules}, nil
+}
+
+// listRecorder records the lists it is asked to render instead of rendering them.
+type listRecorder struct {
+	*RendererOptions
+	lists []api.List
+}
+
+func (r *listRecorder) RenderList(operation string, w io.Writer, list api.List) error {
+	r.lists = append(r.lists, list)
+	return nil
+}
+
+var _ = Describe("RunListFirewallRules", func() {
+	It("should keep source and destination as --sort-by aliases of src and dst", func(ctx SpecContext) {
+		rule := func(id, src, dst string) api.FirewallRule {
+			srcPrefix, dstPrefix := netip.MustParsePrefix(src), netip.MustParsePrefix(dst)
+			return api.FirewallRule{Spec: api.FirewallRuleSpec{RuleID: id, SourcePrefix: &srcPrefix, DestinationPrefix: &dstPrefix}}
+		}
+		c := &firewallRuleClient{rules: []api.FirewallRule{
+			rule("fr1", "10.0.0.3/32", "10.0.1.1/32"),
+			rule("fr2", "10.0.0.1/32", "10.0.1.3/32"),
+			rule("fr3", "10.0.0.2/32", "10.0.1.2/32"),
+		}}
+		ruleIDs := func(sortBy string) []string {
+			recorder := &listRecorder{RendererOptions: &RendererOptions{Output: "name"}}
+			Expect(RunListFirewallRules(ctx, fakeClientFactory{c}, recorder, ListFirewallRulesOptions{InterfaceID: "vm1", SortBy: sortBy})).To(Succeed())
+			var ids []string
+			for _, rule := range recorder.lists[0].(*api.FirewallRuleList).Items {
+				ids = append(ids, rule.Spec.RuleID)
+			}
+			return ids
+		}
+
+		Expect(ruleIDs("source")).To(Equal([]string{"fr2", "fr3", "fr1"}))
+		Expect(ruleIDs("source")).To(Equal(ruleIDs("src")))
+		Expect(ruleIDs("Destination")).To(Equal([]string{"fr1", "fr3", "fr2"}))
+		Expect(ruleIDs("destination")).To(Equal(ruleIDs("dst")))
+	})
+})
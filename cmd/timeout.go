@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// DefaultTimeout is the default deadline of a call to dpservice.
+const DefaultTimeout = 10 * time.Second
+
+// TimeoutInterceptor bounds every call, including its retries, by Timeout. A zero Timeout
+// leaves the deadline of the context as it is.
+type TimeoutInterceptor struct {
+	Timeout time.Duration
+}
+
+func (t TimeoutInterceptor) UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if t.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.Timeout)
+		defer cancel()
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+var _ = Describe("TimeoutInterceptor", func() {
+	deadline := func(deadline *time.Time, ok *bool) grpc.UnaryInvoker {
+		return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			*deadline, *ok = ctx.Deadline()
+			return nil
+		}
+	}
+
+	It("should set the deadline of calls", func() {
+		var d time.Time
+		var ok bool
+		interceptor := TimeoutInterceptor{Timeout: 5 * time.Second}
+		Expect(interceptor.UnaryClientInterceptor(context.Background(), "/dpdkironcore.v1.DPDKironcore/ListInterfaces", nil, nil, nil, deadline(&d, &ok))).To(Succeed())
+		Expect(ok).To(BeTrue())
+		Expect(d).To(BeTemporally("~", time.Now().Add(5*time.Second), time.Second))
+	})
+
+	It("should not set a deadline with a zero timeout", func() {
+		var d time.Time
+		var ok bool
+		Expect(TimeoutInterceptor{}.UnaryClientInterceptor(context.Background(), "/dpdkironcore.v1.DPDKironcore/ListInterfaces", nil, nil, nil, deadline(&d, &ok))).To(Succeed())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should be a global flag that also bounds doctor", func() {
+		root := Command()
+		doctor, _, err := root.Find([]string{"doctor"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(doctor.LocalFlags().Lookup("timeout")).To(BeNil())
+		Expect(root.PersistentFlags().Lookup("timeout").DefValue).To(Equal("10s"))
+	})
+})
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// TLSOptions configure TLS for the connection to dpservice. Without a client certificate
+// only the server is authenticated, with --tls-cert and --tls-key the connection uses mutual TLS.
+type TLSOptions struct {
+	TLS        bool
+	CA         string
+	Cert       string
+	Key        string
+	ServerName string
+}
+
+func (o *TLSOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.TLS, "tls", o.TLS, "Connect to dpservice with TLS instead of plaintext.")
+	fs.StringVar(&o.CA, "tls-ca", o.CA, "PEM file with the CA certificates to verify dpservice with, instead of the system roots. Implies --tls.")
+	fs.StringVar(&o.Cert, "tls-cert", o.Cert, "PEM file with the client certificate for mutual TLS, requires --tls-key. Implies --tls.")
+	fs.StringVar(&o.Key, "tls-key", o.Key, "PEM file with the key of the client certificate, requires --tls-cert. Implies --tls.")
+	fs.StringVar(&o.ServerName, "tls-server-name", o.ServerName, "Name to verify the certificate of dpservice against, instead of the host of --address. Implies --tls.")
+}
+
+// Enabled reports whether TLS was requested, explicitly or by any of the other TLS flags.
+func (o *TLSOptions) Enabled() bool {
+	return o.TLS || o.CA != "" || o.Cert != "" || o.Key != "" || o.ServerName != ""
+}
+
+func (o *TLSOptions) Validate() error {
+	if (o.Cert == "") != (o.Key == "") {
+		return fmt.Errorf("--tls-cert and --tls-key must be given together")
+	}
+	return nil
+}
+
+// Config returns the TLS configuration of the flags.
+func (o *TLSOptions) Config() (*tls.Config, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: o.ServerName,
+	}
+	if o.CA != "" {
+		data, err := os.ReadFile(o.CA)
+		if err != nil {
+			return nil, fmt.Errorf("error reading --tls-ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no PEM certificates found in --tls-ca %s", o.CA)
+		}
+		config.RootCAs = pool
+	}
+	if o.Cert != "" {
+		cert, err := tls.LoadX509KeyPair(o.Cert, o.Key)
+		if err != nil {
+			return nil, fmt.Errorf("error loading --tls-cert and --tls-key: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// TransportCredentials returns TLS credentials if TLS is enabled and insecure ones otherwise.
+func (o *TLSOptions) TransportCredentials() (credentials.TransportCredentials, error) {
+	if !o.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	config, err := o.Config()
+	if err != nil {
+		return nil, err
+	}
+	return serverNameCredentials{credentials.NewTLS(config)}, nil
+}
+
+// serverNameCredentials replace the error of a server name mismatch with one naming the
+// expected name and the names presented in the certificate of dpservice.
+type serverNameCredentials struct {
+	credentials.TransportCredentials
+}
+
+func (c serverNameCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn, authInfo, err := c.TransportCredentials.ClientHandshake(ctx, authority, rawConn)
+	var hostErr x509.HostnameError
+	if errors.As(err, &hostErr) {
+		return nil, nil, fmt.Errorf("server name mismatch: expected %s, dpservice presented %s, use --tls-server-name to set the expected name",
+			hostErr.Host, strings.Join(certificateNames(hostErr.Certificate), ", "))
+	}
+	return conn, authInfo, err
+}
+
+func (c serverNameCredentials) Clone() credentials.TransportCredentials {
+	return serverNameCredentials{c.TransportCredentials.Clone()}
+}
+
+// certificateNames returns the DNS and IP SANs of cert, or its common name if it has none.
+func certificateNames(cert *x509.Certificate) []string {
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	if len(names) == 0 && cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+	if len(names) == 0 {
+		names = append(names, "no names")
+	}
+	return names
+}
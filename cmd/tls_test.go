@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// issueCert returns a certificate for name signed by parent, or a self-signed CA if parent is nil.
+func issueCert(name string, parent *tls.Certificate, usage x509.ExtKeyUsage) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	signer, signerKey := template, any(key)
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	Expect(err).NotTo(HaveOccurred())
+	leaf, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// writeCert writes cert and its key as PEM files to dir and returns their names.
+func writeCert(dir, name string, cert tls.Certificate) (string, string) {
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600)).To(Succeed())
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600)).To(Succeed())
+	return certFile, keyFile
+}
+
+var _ = Describe("TLSOptions", func() {
+	var (
+		address          string
+		caFile           string
+		clientCertFile   string
+		clientKeyFile    string
+		requireClientTLS bool
+	)
+
+	JustBeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		ca := issueCert("dpservice-ca", nil, x509.ExtKeyUsageAny)
+		caFile, _ = writeCert(dir, "ca", ca)
+		clientCertFile, clientKeyFile = writeCert(dir, "client", issueCert("dpservice-cli", &ca, x509.ExtKeyUsageClientAuth))
+
+		config := &tls.Config{Certificates: []tls.Certificate{issueCert("dpservice.test", &ca, x509.ExtKeyUsageServerAuth)}}
+		if requireClientTLS {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+			config.ClientCAs = x509.NewCertPool()
+			config.ClientCAs.AddCert(ca.Leaf)
+		}
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		server := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+		go func() { _ = server.Serve(lis) }()
+		DeferCleanup(server.Stop)
+		address = lis.Addr().String()
+	})
+
+	connect := func(ctx SpecContext, opts TLSOptions) error {
+		clientOpts := &DPDKClientOptions{Address: address, ConnectTimeout: time.Second, RetryPolicy: string(RetryPolicyNone), TLSOptions: opts}
+		_, cleanup, err := clientOpts.NewClient(ctx)
+		if err == nil {
+			DpdkClose(cleanup)
+		}
+		return err
+	}
+
+	It("should require the client certificate and key together", func() {
+		opts := TLSOptions{Cert: "client.crt"}
+		Expect(opts.Validate()).To(MatchError("--tls-cert and --tls-key must be given together"))
+		_, err := opts.TransportCredentials()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should connect with server-only TLS", func(ctx SpecContext) {
+		Expect(connect(ctx, TLSOptions{CA: caFile, ServerName: "dpservice.test"})).To(Succeed())
+	})
+
+	It("should name both server names on a mismatch", func(ctx SpecContext) {
+		err := connect(ctx, TLSOptions{CA: caFile, ServerName: "other.test"})
+		Expect(err).To(MatchError(ContainSubstring("server name mismatch: expected other.test, dpservice presented dpservice.test, use --tls-server-name to set the expected name")))
+	})
+
+	It("should name the certificate names when connecting by IP without a server name", func(ctx SpecContext) {
+		err := connect(ctx, TLSOptions{CA: caFile})
+		Expect(err).To(MatchError(ContainSubstring("server name mismatch: expected 127.0.0.1, dpservice presented dpservice.test")))
+	})
+
+	Context("with a server requiring client certificates", func() {
+		BeforeEach(func() {
+			requireClientTLS = true
+			DeferCleanup(func() { requireClientTLS = false })
+		})
+
+		It("should connect with mutual TLS", func(ctx SpecContext) {
+			Expect(connect(ctx, TLSOptions{CA: caFile, ServerName: "dpservice.test", Cert: clientCertFile, Key: clientKeyFile})).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type callStats struct {
+	count int
+	total time.Duration
+	max   time.Duration
+}
+
+// CallTracer records the latency of the RPCs issued by a command, keyed by method.
+type CallTracer struct {
+	mu    sync.Mutex
+	stats map[string]*callStats
+}
+
+func NewCallTracer() *CallTracer {
+	return &CallTracer{stats: make(map[string]*callStats)}
+}
+
+func (t *CallTracer) Record(method string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.stats[method]
+	if !ok {
+		s = &callStats{}
+		t.stats[method] = s
+	}
+	s.count++
+	s.total += d
+	s.max = max(s.max, d)
+}
+
+// UnaryClientInterceptor records the latency of every call and logs it with its request
+// and response at debug level.
+func (t *CallTracer) UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	d := time.Since(start)
+	t.Record(method, d)
+	logCall(ctx, method, req, reply, d, err)
+	return err
+}
+
+// Print writes the recorded calls to w, slowest total latency first.
+func (t *CallTracer) Print(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	methods := make([]string, 0, len(t.stats))
+	for method := range t.stats {
+		methods = append(methods, method)
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		si, sj := t.stats[methods[i]], t.stats[methods[j]]
+		if si.total != sj.total {
+			return si.total > sj.total
+		}
+		return methods[i] < methods[j]
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tCOUNT\tTOTAL\tAVG\tMAX")
+	for _, method := range methods {
+		s := t.stats[method]
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", method, s.count, s.total, s.total/time.Duration(s.count), s.max)
+	}
+	return tw.Flush()
+}
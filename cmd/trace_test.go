@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+var _ = Describe("CallTracer", func() {
+	// rows returns the fields of the lines printed by the tracer.
+	rows := func(t *CallTracer) [][]string {
+		var buf bytes.Buffer
+		Expect(t.Print(&buf)).To(Succeed())
+		var rows [][]string
+		for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+			rows = append(rows, strings.Fields(line))
+		}
+		return rows
+	}
+
+	It("should only print the header if no call was made", func() {
+		Expect(rows(NewCallTracer())).To(Equal([][]string{{"METHOD", "COUNT", "TOTAL", "AVG", "MAX"}}))
+	})
+
+	It("should print the calls per method, slowest total latency first", func() {
+		t := NewCallTracer()
+		t.Record("/dpdkironcore.v1.DPDKironcore/GetVersion", 10*time.Millisecond)
+		t.Record("/dpdkironcore.v1.DPDKironcore/ListInterfaces", 30*time.Millisecond)
+		t.Record("/dpdkironcore.v1.DPDKironcore/CheckInitialized", 10*time.Millisecond)
+		t.Record("/dpdkironcore.v1.DPDKironcore/ListInterfaces", 10*time.Millisecond)
+
+		Expect(rows(t)).To(Equal([][]string{
+			{"METHOD", "COUNT", "TOTAL", "AVG", "MAX"},
+			{"/dpdkironcore.v1.DPDKironcore/ListInterfaces", "2", "40ms", "20ms", "30ms"},
+			{"/dpdkironcore.v1.DPDKironcore/CheckInitialized", "1", "10ms", "10ms", "10ms"},
+			{"/dpdkironcore.v1.DPDKironcore/GetVersion", "1", "10ms", "10ms", "10ms"},
+		}))
+	})
+
+	It("should record successful and failed calls and return the error of the call", func(ctx SpecContext) {
+		t := NewCallTracer()
+		callErr := errors.New("connection reset")
+		invoke := func(err error) error {
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return err
+			}
+			return t.UnaryClientInterceptor(ctx, "/dpdkironcore.v1.DPDKironcore/GetVersion", &dpdkproto.GetVersionRequest{}, &dpdkproto.GetVersionResponse{}, nil, invoker)
+		}
+		Expect(invoke(nil)).To(Succeed())
+		Expect(invoke(callErr)).To(MatchError(callErr))
+
+		printed := rows(t)
+		Expect(printed).To(HaveLen(2))
+		Expect(printed[1][:2]).To(Equal([]string{"/dpdkironcore.v1.DPDKironcore/GetVersion", "2"}))
+	})
+})
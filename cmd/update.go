@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func Update(factory DPDKClientFactory) *cobra.Command {
+	rendererOptions := &RendererOptions{Output: "name"}
+
+	cmd := &cobra.Command{
+		Use:         "update [command]",
+		Annotations: map[string]string{AnnotationMutating: "true"},
+		Args:        cobra.NoArgs,
+		RunE:        SubcommandRequired,
+	}
+
+	rendererOptions.AddFlags(cmd.PersistentFlags())
+
+	subcommands := []*cobra.Command{
+		UpdateInterface(factory, rendererOptions),
+	}
+
+	cmd.Short = fmt.Sprintf("Updates one of %v", CommandNames(subcommands))
+	cmd.Long = fmt.Sprintf("Updates one of %v", CommandNames(subcommands))
+
+	cmd.AddCommand(
+		subcommands...,
+	)
+
+	return cmd
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/spf13/cobra"
+)
+
+func UpdateInterface(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory) *cobra.Command {
+	var (
+		opts CreateInterfaceOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "interface <--id> [<--ip>] <--vni> <--device> [<--total-meter-rate>] [<--public-meter-rate>]",
+		Short: "Update an interface by creating it again with the same ID",
+		Long: "Update an interface by creating it again with the same ID, as dpservice cannot change interfaces in place.\n" +
+			"The virtual IP, NAT, prefixes, loadbalancer prefixes and firewall rules of the interface are restored.\n" +
+			"The underlay route is NOT preserved, dpservice assigns a new one when the interface is created again.",
+		Example: "dpservice-cli update interface --id=vm4 --ipv4=10.200.1.5 --ipv6=2000:200:1::5 --vni=200 --device=net_tap5",
+		Aliases: InterfaceAliases,
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(cmd); err != nil {
+				return err
+			}
+
+			return RunUpdateInterface(
+				cmd.Context(),
+				dpdkClientFactory,
+				rendererFactory,
+				opts,
+			)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	util.Must(opts.MarkRequiredFlags(cmd))
+
+	return cmd
+}
+
+// RunUpdateInterface deletes the interface of opts.ID and creates it again as specified by opts,
+// restoring its dependents. If the interface cannot be created as specified, the previous one is
+// created again instead.
+func RunUpdateInterface(ctx context.Context, dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory, opts CreateInterfaceOptions) error {
+	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dpdk client: %w", err)
+	}
+	defer DpdkClose(cleanup)
+
+	old, err := client.GetInterface(ctx, opts.ID)
+	if dynamic.IsNotFound(err) {
+		return &NotFoundError{Kind: api.InterfaceKind, Name: opts.ID}
+	}
+	if err != nil {
+		return fmt.Errorf("error getting interface: %w", err)
+	}
+
+	deps, err := interfaceDependents(ctx, client, opts.ID)
+	if err != nil {
+		return fmt.Errorf("error getting dependents: %w", err)
+	}
+
+	if _, err := client.DeleteInterface(ctx, opts.ID); err != nil {
+		return fmt.Errorf("error deleting interface: %w", err)
+	}
+
+	iface, err := client.CreateInterface(ctx, opts.Interface())
+	if err != nil {
+		// roll back to the previous interface, so that it does not stay deleted
+		previous := &api.Interface{
+			InterfaceMeta: api.InterfaceMeta{ID: opts.ID},
+			Spec:          old.Spec,
+		}
+		if _, rollbackErr := client.CreateInterface(ctx, previous); rollbackErr != nil {
+			return fmt.Errorf("error creating interface: %w, error restoring previous interface: %w", err, rollbackErr)
+		}
+		if restoreErr := restoreDependents(ctx, client, deps); restoreErr != nil {
+			return fmt.Errorf("error creating interface, restored previous interface: %w, %w", err, restoreErr)
+		}
+		return fmt.Errorf("error creating interface, restored previous interface: %w", err)
+	}
+
+	if err := restoreDependents(ctx, client, deps); err != nil {
+		return err
+	}
+
+	return opts.Render(os.Stdout, rendererFactory, fmt.Sprintf("updated, underlay route: %s (was %s)", iface.Spec.UnderlayRoute, old.Spec.UnderlayRoute), iface, iface.Spec.UnderlayRoute)
+}
+
+// restoreDependents creates deps again, reporting each on stderr.
+func restoreDependents(ctx context.Context, c client.Client, deps []any) error {
+	dc := dynamic.NewFromStructured(c)
+	failed := 0
+	for _, dep := range deps {
+		if _, err := dc.Create(ctx, dep); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to restore %s: %s\n", planName(dep), err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Restored %s\n", planName(dep))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d dependents failed to restore", failed, len(deps))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"net/netip"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpdateInterface", func() {
+	run := func(ctx context.Context, c *replaceClient) error {
+		cmd := UpdateInterface(fakeClientFactory{c}, &RendererOptions{Output: "name"})
+		cmd.SetArgs([]string{"--id=vm1", "--vni=100", "--ipv4=10.200.1.5", "--device=net_tap5"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return cmd.ExecuteContext(ctx)
+	}
+
+	It("should create the interface again and restore its dependents", func(ctx SpecContext) {
+		vip := netip.MustParseAddr("20.0.0.1")
+		c := &replaceClient{iface: true, prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")}, vip: &vip}
+		Expect(run(ctx, c)).To(Succeed())
+		Expect(c.calls).To(Equal([]string{
+			"delete interface/vm1",
+			"create interface/vm1",
+			"create virtualip/20.0.0.1",
+			"create prefix/10.0.0.0/24",
+		}))
+	})
+
+	It("should restore the previous interface and its dependents if create fails", func(ctx SpecContext) {
+		vip := netip.MustParseAddr("20.0.0.1")
+		c := &replaceClient{iface: true, prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")}, vip: &vip, createErrs: 1}
+		err := run(ctx, c)
+		Expect(err).To(MatchError(ContainSubstring("error creating interface, restored previous interface: ")))
+		Expect(err).To(MatchError(ContainSubstring("VNF_INSERT")))
+		Expect(c.iface).To(BeTrue())
+		Expect(c.calls).To(Equal([]string{
+			"delete interface/vm1",
+			"create interface/vm1",
+			"create interface/vm1",
+			"create virtualip/20.0.0.1",
+			"create prefix/10.0.0.0/24",
+		}))
+	})
+
+	It("should report both errors if restoring the previous interface fails too", func(ctx SpecContext) {
+		vip := netip.MustParseAddr("20.0.0.1")
+		c := &replaceClient{iface: true, prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/24")}, vip: &vip, createErrs: 2}
+		err := run(ctx, c)
+		Expect(err).To(MatchError(And(
+			ContainSubstring("error creating interface: "),
+			ContainSubstring("error restoring previous interface: "),
+		)))
+		Expect(c.iface).To(BeFalse())
+		Expect(c.calls).To(Equal([]string{
+			"delete interface/vm1",
+			"create interface/vm1",
+			"create interface/vm1",
+		}))
+	})
+
+	It("should report a missing interface as not found", func(ctx SpecContext) {
+		c := &replaceClient{}
+		err := run(ctx, c)
+		Expect(err).To(MatchError("Interface vm1 not found"))
+		Expect(ExitCode(err)).To(Equal(ExitCodeNotFound))
+		Expect(c.calls).To(BeEmpty())
+	})
+})
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func Version(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory) *cobra.Command {
+	var (
+		opts VersionOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:     "version [--client-only]",
+		Short:   "Print the version of dpservice-cli and of dpservice",
+		Long:    "Print the version of dpservice-cli and, if it is reachable, of dpservice. If dpservice is not reachable, a warning is printed to stderr.",
+		Example: "dpservice-cli version -o json",
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			return RunVersion(
+				cmd.Context(),
+				os.Stderr,
+				dpdkClientFactory,
+				rendererFactory,
+				opts,
+			)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+type VersionOptions struct {
+	ClientOnly bool
+}
+
+func (o *VersionOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.ClientOnly, "client-only", o.ClientOnly, "Print only the version of dpservice-cli, without connecting to dpservice.")
+}
+
+// RunVersion renders the versions of dpservice-cli and dpservice. An unreachable dpservice
+// is reported as a warning to errW.
+func RunVersion(
+	ctx context.Context,
+	errW io.Writer,
+	dpdkClientFactory DPDKClientFactory,
+	rendererFactory RendererFactory,
+	opts VersionOptions,
+) error {
+	versions := &renderer.Versions{
+		TypeMeta:      api.TypeMeta{Kind: renderer.VersionsKind},
+		ClientVersion: util.BuildVersion,
+	}
+
+	if !opts.ClientOnly {
+		if version, err := getServerVersion(ctx, dpdkClientFactory); err != nil {
+			fmt.Fprintf(errW, "Warning: could not get the version of dpservice: %v\n", err)
+		} else {
+			versions.ServerVersion = version.Spec.ServiceVersion
+			versions.ServerProtocol = version.Spec.ServiceProtocol
+		}
+	}
+
+	return rendererFactory.RenderObject("", os.Stdout, versions)
+}
+
+func getServerVersion(ctx context.Context, dpdkClientFactory DPDKClientFactory) (*api.Version, error) {
+	client, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating dpdk client: %w", err)
+	}
+	defer DpdkClose(cleanup)
+
+	version, err := client.GetVersion(ctx, &api.Version{
+		TypeMeta: api.TypeMeta{Kind: api.VersionKind},
+		VersionMeta: api.VersionMeta{
+			ClientName:    "dpservice-cli",
+			ClientVersion: util.BuildVersion,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting version: %w", err)
+	}
+	return version, nil
+}
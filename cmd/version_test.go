@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type versionClient struct {
+	client.Client
+}
+
+func (versionClient) GetVersion(ctx context.Context, version *api.Version, ignoredErrors ...[]uint32) (*api.Version, error) {
+	version.Spec = api.VersionSpec{ServiceProtocol: "v1.2.0", ServiceVersion: "v0.3.1"}
+	return version, nil
+}
+
+var _ = Describe("Version", func() {
+	var out string
+
+	BeforeEach(func() {
+		version := util.BuildVersion
+		util.BuildVersion = "v1.0.0"
+		DeferCleanup(func() { util.BuildVersion = version })
+
+		out = captureOutput(&os.Stdout)
+	})
+
+	It("should render the client and the server version", func(ctx SpecContext) {
+		var errW bytes.Buffer
+		Expect(RunVersion(ctx, &errW, fakeClientFactory{versionClient{}}, &RendererOptions{Output: "json"}, VersionOptions{})).To(Succeed())
+		Expect(os.ReadFile(out)).To(MatchJSON(`{"kind":"Versions","clientVersion":"v1.0.0","serverVersion":"v0.3.1","serverProtocol":"v1.2.0"}`))
+		Expect(errW.String()).To(BeEmpty())
+	})
+
+	It("should warn and render the client version if dpservice is unreachable", func(ctx SpecContext) {
+		var errW bytes.Buffer
+		Expect(RunVersion(ctx, &errW, unreachableClientFactory{}, &RendererOptions{Output: "json"}, VersionOptions{})).To(Succeed())
+		Expect(os.ReadFile(out)).To(MatchJSON(`{"kind":"Versions","clientVersion":"v1.0.0"}`))
+		Expect(errW.String()).To(HavePrefix("Warning: could not get the version of dpservice"))
+	})
+
+	It("should not connect to dpservice with --client-only", func(ctx SpecContext) {
+		var errW bytes.Buffer
+		Expect(RunVersion(ctx, &errW, unreachableClientFactory{}, &RendererOptions{Output: "json"}, VersionOptions{ClientOnly: true})).To(Succeed())
+		Expect(os.ReadFile(out)).To(MatchJSON(`{"kind":"Versions","clientVersion":"v1.0.0"}`))
+		Expect(errW.String()).To(BeEmpty())
+	})
+})
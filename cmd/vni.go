@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/pflag"
+)
+
+// DefaultMaxVNI is the largest VNI accepted by dpservice. VNIs are 24 bit wide, as in VXLAN.
+const DefaultMaxVNI uint32 = 1<<24 - 1
+
+// vniFlagNames are the flags of all commands that take a VNI.
+var vniFlagNames = []string{"vni", "next-hop-vni", "vni-min", "vni-max"}
+
+type VNIOptions struct {
+	MaxVNI uint32
+}
+
+func (o *VNIOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.Uint32Var(&o.MaxVNI, "max-vni", DefaultMaxVNI, "Largest VNI supported by the dpservice.")
+}
+
+// Validate checks the VNI flags set in fs against the supported range.
+func (o *VNIOptions) Validate(fs *pflag.FlagSet) error {
+	for _, name := range vniFlagNames {
+		f := fs.Lookup(name)
+		if f == nil || !f.Changed {
+			continue
+		}
+
+		vni, err := strconv.ParseUint(f.Value.String(), 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", name, err)
+		}
+		if err := ValidateVNI(uint32(vni), o.MaxVNI); err != nil {
+			return fmt.Errorf("invalid --%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func ValidateVNI(vni, maxVNI uint32) error {
+	if vni > maxVNI {
+		return fmt.Errorf("vni %d is out of range, must be between 0 and %d", vni, maxVNI)
+	}
+	return nil
+}
+
+// VNIRangeOptions select the VNIs of the inclusive range from --vni-min to --vni-max.
+type VNIRangeOptions struct {
+	VNIMin uint32
+	VNIMax uint32
+}
+
+func (o *VNIRangeOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.Uint32Var(&o.VNIMin, "vni-min", 0, "Only include VNIs greater than or equal to this.")
+	fs.Uint32Var(&o.VNIMax, "vni-max", DefaultMaxVNI, "Only include VNIs less than or equal to this.")
+}
+
+func (o *VNIRangeOptions) Validate() error {
+	if o.VNIMin > o.VNIMax {
+		return fmt.Errorf("--vni-min %d must not be greater than --vni-max %d", o.VNIMin, o.VNIMax)
+	}
+	return nil
+}
+
+func (o *VNIRangeOptions) Contains(vni uint32) bool {
+	return vni >= o.VNIMin && vni <= o.VNIMax
+}
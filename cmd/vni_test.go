@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("VNI validation", func() {
+	DescribeTable("ValidateVNI",
+		func(vni uint32, valid bool) {
+			err := ValidateVNI(vni, DefaultMaxVNI)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring("must be between 0 and 16777215")))
+			}
+		},
+		Entry("zero", uint32(0), true),
+		Entry("largest 24 bit value", uint32(1<<24-1), true),
+		Entry("smallest value above 24 bit", uint32(1<<24), false),
+		Entry("largest uint32", uint32(1<<32-1), false),
+	)
+
+	Context("VNIOptions", func() {
+		var (
+			opts VNIOptions
+			fs   *pflag.FlagSet
+			vni  uint32
+			next uint32
+		)
+		BeforeEach(func() {
+			opts = VNIOptions{}
+			fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+			opts.AddFlags(fs)
+			fs.Uint32Var(&vni, "vni", 0, "")
+			fs.Uint32Var(&next, "next-hop-vni", 0, "")
+		})
+
+		It("should accept VNIs within the range", func() {
+			Expect(fs.Parse([]string{"--vni=16777215", "--next-hop-vni=0"})).To(Succeed())
+			Expect(opts.Validate(fs)).To(Succeed())
+		})
+
+		It("should reject a VNI out of range and name the flag", func() {
+			Expect(fs.Parse([]string{"--next-hop-vni=16777216"})).To(Succeed())
+			Expect(opts.Validate(fs)).To(MatchError(ContainSubstring("invalid --next-hop-vni")))
+		})
+
+		It("should honor --max-vni", func() {
+			Expect(fs.Parse([]string{"--max-vni=100", "--vni=101"})).To(Succeed())
+			Expect(opts.Validate(fs)).To(MatchError(ContainSubstring("must be between 0 and 100")))
+		})
+	})
+})
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
+	"github.com/spf13/pflag"
+)
+
+// waitPollInterval is the interval at which --wait polls the object.
+const waitPollInterval = 500 * time.Millisecond
+
+type WaitOptions struct {
+	Wait        bool
+	WaitTimeout time.Duration
+}
+
+func (o *WaitOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Wait, "wait", o.Wait, "Wait until dpservice reports the object as gone after deleting it.")
+	fs.DurationVar(&o.WaitTimeout, "wait-timeout", 30*time.Second, "How long --wait waits for the object to be gone.")
+}
+
+// WaitForDeletion polls get until it reports the object as not found, or the timeout of
+// the options passed. Other errors of get abort waiting.
+func (o *WaitOptions) WaitForDeletion(ctx context.Context, kind, name string, interval time.Duration, get func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, o.WaitTimeout)
+	defer cancel()
+
+	for {
+		err := get(ctx)
+		switch {
+		case dynamic.IsNotFound(err):
+			return nil
+		case ctx.Err() != nil:
+			return fmt.Errorf("%s %s still exists after %s", kind, name, o.WaitTimeout)
+		case err != nil:
+			return fmt.Errorf("error waiting for %s %s to be deleted: %w", kind, name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s %s still exists after %s", kind, name, o.WaitTimeout)
+		case <-time.After(interval):
+		}
+	}
+}
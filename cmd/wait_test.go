@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WaitForDeletion", func() {
+	opts := WaitOptions{Wait: true, WaitTimeout: 50 * time.Millisecond}
+
+	It("should wait until the object is not found", func(ctx SpecContext) {
+		polls := 0
+		get := func(context.Context) error {
+			polls++
+			if polls < 3 {
+				return nil
+			}
+			return apierrors.NewStatusError(apierrors.NO_VM, "NO_VM")
+		}
+		Expect(opts.WaitForDeletion(ctx, "Interface", "vm1", time.Millisecond, get)).To(Succeed())
+		Expect(polls).To(Equal(3))
+	})
+
+	It("should fail if the object still exists after the timeout", func(ctx SpecContext) {
+		get := func(context.Context) error { return nil }
+		Expect(opts.WaitForDeletion(ctx, "Interface", "vm1", time.Millisecond, get)).
+			To(MatchError("Interface vm1 still exists after 50ms"))
+	})
+
+	It("should abort on other errors", func(ctx SpecContext) {
+		get := func(context.Context) error { return errors.New("connection refused") }
+		Expect(opts.WaitForDeletion(ctx, "Interface", "vm1", time.Millisecond, get)).
+			To(MatchError(ContainSubstring("connection refused")))
+	})
+})
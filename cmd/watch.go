@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-cli/diff"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/client/dynamic"
+	"github.com/ironcore-dev/dpservice-cli/util"
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func Watch(factory DPDKClientFactory) *cobra.Command {
+	rendererOptions := &RendererOptions{Output: "table"}
+
+	cmd := &cobra.Command{
+		Use:  "watch [command]",
+		Args: cobra.NoArgs,
+		RunE: SubcommandRequired,
+	}
+
+	rendererOptions.AddFlags(cmd.PersistentFlags())
+
+	subcommands := []*cobra.Command{
+		WatchInterface(factory, rendererOptions),
+		WatchLoadBalancer(factory, rendererOptions),
+	}
+
+	cmd.Short = fmt.Sprintf("Watches one of %v for changes", CommandNames(subcommands))
+	cmd.Long = fmt.Sprintf("Watches one of %v for changes", CommandNames(subcommands))
+
+	cmd.AddCommand(
+		subcommands...,
+	)
+
+	return cmd
+}
+
+type WatchOptions struct {
+	ID       string
+	Interval time.Duration
+}
+
+func (o *WatchOptions) AddFlags(fs *pflag.FlagSet, usage string) {
+	fs.StringVar(&o.ID, "id", o.ID, usage)
+	fs.DurationVar(&o.Interval, "interval", 2*time.Second, "Interval to poll the object at.")
+}
+
+func (o *WatchOptions) MarkRequiredFlags(cmd *cobra.Command) error {
+	return cmd.MarkFlagRequired("id")
+}
+
+func (o *WatchOptions) Validate() error {
+	if o.Interval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", o.Interval)
+	}
+	return nil
+}
+
+func WatchInterface(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory) *cobra.Command {
+	var (
+		opts WatchOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:     "interface <--id> [--interval]",
+		Short:   "Watch an interface and print its changes",
+		Example: "dpservice-cli watch interface --id=vm1 --interval=5s",
+		Aliases: InterfaceAliases,
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+
+			return runWatchCommand(cmd.Context(), dpdkClientFactory, rendererFactory, opts, api.InterfaceKind,
+				func(ctx context.Context, c client.Client) (api.Object, error) {
+					return c.GetInterface(ctx, opts.ID)
+				})
+		},
+	}
+
+	opts.AddFlags(cmd.Flags(), "ID of the interface.")
+
+	util.Must(opts.MarkRequiredFlags(cmd))
+
+	return cmd
+}
+
+func WatchLoadBalancer(dpdkClientFactory DPDKClientFactory, rendererFactory RendererFactory) *cobra.Command {
+	var (
+		opts WatchOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:     "loadbalancer <--id> [--interval]",
+		Short:   "Watch a loadbalancer and print its changes",
+		Example: "dpservice-cli watch loadbalancer --id=lb1 --interval=5s",
+		Aliases: LoadBalancerAliases,
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Validate(); err != nil {
+				return err
+			}
+
+			return runWatchCommand(cmd.Context(), dpdkClientFactory, rendererFactory, opts, api.LoadBalancerKind,
+				func(ctx context.Context, c client.Client) (api.Object, error) {
+					return c.GetLoadBalancer(ctx, opts.ID)
+				})
+		},
+	}
+
+	opts.AddFlags(cmd.Flags(), "ID of the loadbalancer.")
+
+	util.Must(opts.MarkRequiredFlags(cmd))
+
+	return cmd
+}
+
+func runWatchCommand(
+	ctx context.Context,
+	dpdkClientFactory DPDKClientFactory,
+	rendererFactory RendererFactory,
+	opts WatchOptions,
+	kind string,
+	get func(ctx context.Context, c client.Client) (api.Object, error),
+) error {
+	c, cleanup, err := dpdkClientFactory.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating dpdk client: %w", err)
+	}
+	defer DpdkClose(cleanup)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	return RunWatch(ctx, os.Stdout, rendererFactory, opts, kind, func(ctx context.Context) (api.Object, error) {
+		return get(ctx, c)
+	})
+}
+
+// RunWatch polls the object returned by get every interval and renders it whenever it
+// changed, preceded by a timestamped list of the changed fields. Errors of a single poll
+// are printed to stderr. It returns when ctx is done or the object was deleted.
+func RunWatch(
+	ctx context.Context,
+	w io.Writer,
+	rendererFactory RendererFactory,
+	opts WatchOptions,
+	kind string,
+	get func(ctx context.Context) (api.Object, error),
+) error {
+	name := fmt.Sprintf("%s/%s", strings.ToLower(kind), opts.ID)
+
+	var last api.Object
+	for {
+		obj, err := get(ctx)
+		switch {
+		case ctx.Err() != nil:
+			fmt.Fprintf(w, "%s stopped watching %s\n", watchTimestamp(), name)
+			return nil
+		case dynamic.IsNotFound(err) && last == nil:
+			return &NotFoundError{Kind: kind, Name: opts.ID}
+		case dynamic.IsNotFound(err):
+			fmt.Fprintf(w, "%s %s was deleted, stopped watching\n", watchTimestamp(), name)
+			return nil
+		case err != nil:
+			// keep polling, the next call may succeed again
+			fmt.Fprintf(os.Stderr, "%s Error: error getting %s: %v\n", watchTimestamp(), name, err)
+		case last == nil:
+			if err := rendererFactory.RenderObject("", w, obj); err != nil {
+				return err
+			}
+			last = obj
+		default:
+			changes, err := diff.Objects(last, obj)
+			if err != nil {
+				return fmt.Errorf("error comparing %s: %w", name, err)
+			}
+			if len(changes) > 0 {
+				fmt.Fprintf(w, "%s %s changed:\n", watchTimestamp(), name)
+				for _, change := range changes {
+					fmt.Fprintf(w, "    %s\n", change)
+				}
+				if err := rendererFactory.RenderObject("", w, obj); err != nil {
+					return err
+				}
+			}
+			last = obj
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Fprintf(w, "%s stopped watching %s\n", watchTimestamp(), name)
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+func watchTimestamp() string {
+	return time.Now().Format(time.RFC3339)
+}
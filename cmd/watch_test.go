@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+	"time"
+
+	. "github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/ironcore-dev/dpservice-go/api"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunWatch", func() {
+	iface := func(underlay string) *api.Interface {
+		route := netip.MustParseAddr(underlay)
+		return &api.Interface{
+			TypeMeta:      api.TypeMeta{Kind: api.InterfaceKind},
+			InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+			Spec:          api.InterfaceSpec{VNI: 100, UnderlayRoute: &route},
+		}
+	}
+	opts := WatchOptions{ID: "vm1", Interval: time.Millisecond}
+
+	It("should print changes only and stop when the object is deleted", func(ctx SpecContext) {
+		polls := []api.Object{iface("fc00::1"), iface("fc00::1"), iface("fc00::2")}
+		get := func(context.Context) (api.Object, error) {
+			if len(polls) == 0 {
+				return &api.Interface{}, apierrors.NewStatusError(apierrors.NO_VM, "NO_VM")
+			}
+			obj := polls[0]
+			polls = polls[1:]
+			return obj, nil
+		}
+
+		var w bytes.Buffer
+		Expect(RunWatch(ctx, &w, &RendererOptions{Output: "name"}, opts, api.InterfaceKind, get)).To(Succeed())
+		Expect(w.String()).To(MatchRegexp(`^interface/vm1\n` +
+			`\S+ interface/vm1 changed:\n` +
+			`    ~ spec.underlay_route: fc00::1 -> fc00::2\n` +
+			`interface/vm1\n` +
+			`\S+ interface/vm1 was deleted, stopped watching\n$`))
+	})
+
+	It("should print errors of a single poll to stderr and keep watching", func(ctx SpecContext) {
+		stderr := captureOutput(&os.Stderr)
+		type poll struct {
+			obj api.Object
+			err error
+		}
+		polls := []poll{{obj: iface("fc00::1")}, {err: errors.New("connection reset")}, {obj: iface("fc00::2")}}
+		get := func(context.Context) (api.Object, error) {
+			if len(polls) == 0 {
+				return &api.Interface{}, apierrors.NewStatusError(apierrors.NO_VM, "NO_VM")
+			}
+			p := polls[0]
+			polls = polls[1:]
+			return p.obj, p.err
+		}
+
+		var w bytes.Buffer
+		Expect(RunWatch(ctx, &w, &RendererOptions{Output: "name"}, opts, api.InterfaceKind, get)).To(Succeed())
+		Expect(w.String()).To(ContainSubstring("    ~ spec.underlay_route: fc00::1 -> fc00::2\n"))
+		Expect(w.String()).To(ContainSubstring("interface/vm1 was deleted, stopped watching"))
+		Expect(os.ReadFile(stderr)).To(MatchRegexp(`^\S+ Error: error getting interface/vm1: connection reset\n$`))
+	})
+
+	It("should fail if the object does not exist", func(ctx SpecContext) {
+		get := func(context.Context) (api.Object, error) {
+			return &api.Interface{}, apierrors.NewStatusError(apierrors.NO_VM, "NO_VM")
+		}
+		err := RunWatch(ctx, &bytes.Buffer{}, &RendererOptions{Output: "name"}, opts, api.InterfaceKind, get)
+		Expect(ExitCode(err)).To(Equal(ExitCodeNotFound))
+	})
+
+	It("should stop when the context is done", func(ctx SpecContext) {
+		ctx2, cancel := context.WithCancel(ctx)
+		get := func(context.Context) (api.Object, error) {
+			cancel()
+			return iface("fc00::1"), nil
+		}
+		var w bytes.Buffer
+		Expect(RunWatch(ctx2, &w, &RendererOptions{Output: "name"}, opts, api.InterfaceKind, get)).To(Succeed())
+		Expect(w.String()).To(ContainSubstring("stopped watching interface/vm1"))
+	})
+
+	It("should reject an interval that is not positive", func() {
+		Expect((&WatchOptions{ID: "vm1", Interval: 0}).Validate()).To(MatchError("--interval must be positive, got 0s"))
+		Expect((&WatchOptions{ID: "vm1", Interval: -time.Second}).Validate()).To(MatchError("--interval must be positive, got -1s"))
+		Expect(opts.Validate()).To(Succeed())
+	})
+})
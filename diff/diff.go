@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+type ChangeType string
+
+const (
+	Added    ChangeType = "added"
+	Removed  ChangeType = "removed"
+	Modified ChangeType = "modified"
+)
+
+// Change is a single difference between two objects, addressed by a dotted field path.
+type Change struct {
+	Path string
+	Type ChangeType
+	Old  any
+	New  any
+}
+
+func (c Change) String() string {
+	switch c.Type {
+	case Added:
+		return fmt.Sprintf("+ %s: %v", c.Path, c.New)
+	case Removed:
+		return fmt.Sprintf("- %s: %v", c.Path, c.Old)
+	default:
+		return fmt.Sprintf("~ %s: %v -> %v", c.Path, c.Old, c.New)
+	}
+}
+
+// Objects compares the JSON representation of old and new and returns the changed fields sorted by path.
+func Objects(old, new any) ([]Change, error) {
+	oldFields, err := Flatten(old)
+	if err != nil {
+		return nil, fmt.Errorf("error flattening old object: %w", err)
+	}
+	newFields, err := Flatten(new)
+	if err != nil {
+		return nil, fmt.Errorf("error flattening new object: %w", err)
+	}
+
+	var changes []Change
+	for path, oldValue := range oldFields {
+		newValue, ok := newFields[path]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Path: path, Type: Removed, Old: oldValue})
+		case !reflect.DeepEqual(oldValue, newValue):
+			changes = append(changes, Change{Path: path, Type: Modified, Old: oldValue, New: newValue})
+		}
+	}
+	for path, newValue := range newFields {
+		if _, ok := oldFields[path]; !ok {
+			changes = append(changes, Change{Path: path, Type: Added, New: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// Flatten returns the leaf values of the JSON representation of v keyed by their dotted path.
+func Flatten(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]any)
+	flatten("", generic, fields)
+	return fields, nil
+}
+
+func flatten(prefix string, v any, fields map[string]any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flatten(path, value, fields)
+		}
+	case []any:
+		for i, value := range v {
+			flatten(prefix+"["+strconv.Itoa(i)+"]", value, fields)
+		}
+	default:
+		fields[prefix] = v
+	}
+}
@@ -5,11 +5,13 @@ package dynamic
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/netip"
 
 	"github.com/ironcore-dev/dpservice-go/api"
 	structured "github.com/ironcore-dev/dpservice-go/client"
+	apierrors "github.com/ironcore-dev/dpservice-go/errors"
 )
 
 type ObjectKey interface {
@@ -170,10 +172,17 @@ func ObjectKeyFromObject(obj any) ObjectKey {
 			Prefix:      obj.Spec.Prefix,
 		}
 	case *api.Route:
-		return RouteKey{
+		key := RouteKey{
 			VNI:    obj.VNI,
 			Prefix: *obj.Spec.Prefix,
 		}
+		if obj.Spec.NextHop != nil {
+			key.NextHopVNI = obj.Spec.NextHop.VNI
+			if obj.Spec.NextHop.IP != nil {
+				key.NextHopIP = *obj.Spec.NextHop.IP
+			}
+		}
+		return key
 	case *api.VirtualIP:
 		return VirtualIPKey{
 			InterfaceID: obj.InterfaceID,
@@ -214,14 +223,159 @@ func ObjectKeyFromObject(obj any) ObjectKey {
 }
 
 type Client interface {
+	Get(ctx context.Context, obj any) (any, error)
 	Create(ctx context.Context, obj any) (any, error)
 	Delete(ctx context.Context, obj any) (any, error)
 }
 
+// ErrNotFound is returned by Get if the object does not exist.
+var ErrNotFound = errors.New("not found")
+
+// notFoundCodes are the status codes with which dpservice reports that an object does not exist.
+var notFoundCodes = []uint32{
+	apierrors.NOT_FOUND,
+	apierrors.NO_VM,
+	apierrors.NO_VNI,
+	apierrors.ROUTE_NOT_FOUND,
+	apierrors.DNAT_NO_DATA,
+	apierrors.SNAT_NO_DATA,
+	apierrors.NO_BACKIP,
+	apierrors.NO_LB,
+}
+
+// IsNotFound reports whether err tells that an object does not exist, either
+// by ErrNotFound or a not found status of dpservice.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || apierrors.IsStatusErrorCode(err, notFoundCodes...)
+}
+
+func notFound(err error) error {
+	if apierrors.IsStatusErrorCode(err, notFoundCodes...) {
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return err
+}
+
 type client struct {
 	structured structured.Client
 }
 
+// Get returns the live state of the object identified by the key of obj. Kinds without
+// a get call in dpservice are looked up in the list of their parent.
+func (c *client) Get(ctx context.Context, obj any) (any, error) {
+	switch obj := obj.(type) {
+	case *api.Interface:
+		res, err := c.structured.GetInterface(ctx, obj.ID)
+		return res, notFound(err)
+	case *api.Prefix:
+		list, err := c.structured.ListPrefixes(ctx, obj.InterfaceID)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		for _, prefix := range list.Items {
+			if prefix.Spec.Prefix == obj.Spec.Prefix {
+				return &prefix, nil
+			}
+		}
+		return nil, ErrNotFound
+	case *api.Route:
+		if obj.Spec.Prefix == nil {
+			return nil, fmt.Errorf("route has no prefix")
+		}
+		list, err := c.structured.ListRoutes(ctx, obj.VNI)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		for _, route := range list.Items {
+			if route.Spec.Prefix != nil && route.Spec.Prefix.Masked() == obj.Spec.Prefix.Masked() && matchesNextHop(route.Spec.NextHop, obj.Spec.NextHop) {
+				return &route, nil
+			}
+		}
+		return nil, ErrNotFound
+	case *api.VirtualIP:
+		res, err := c.structured.GetVirtualIP(ctx, obj.InterfaceID)
+		return res, notFound(err)
+	case *api.LoadBalancer:
+		res, err := c.structured.GetLoadBalancer(ctx, obj.ID)
+		return res, notFound(err)
+	case *api.LoadBalancerPrefix:
+		list, err := c.structured.ListLoadBalancerPrefixes(ctx, obj.InterfaceID)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		for _, prefix := range list.Items {
+			if prefix.Spec.Prefix == obj.Spec.Prefix {
+				return &api.LoadBalancerPrefix{
+					TypeMeta:               obj.TypeMeta,
+					LoadBalancerPrefixMeta: obj.LoadBalancerPrefixMeta,
+					Spec:                   api.LoadBalancerPrefixSpec{Prefix: prefix.Spec.Prefix, UnderlayRoute: prefix.Spec.UnderlayRoute},
+				}, nil
+			}
+		}
+		return nil, ErrNotFound
+	case *api.LoadBalancerTarget:
+		if obj.Spec.TargetIP == nil {
+			return nil, fmt.Errorf("loadbalancer target has no target ip")
+		}
+		list, err := c.structured.ListLoadBalancerTargets(ctx, obj.LoadbalancerID)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		for _, target := range list.Items {
+			if target.Spec.TargetIP != nil && *target.Spec.TargetIP == *obj.Spec.TargetIP {
+				return &target, nil
+			}
+		}
+		return nil, ErrNotFound
+	case *api.Nat:
+		res, err := c.structured.GetNat(ctx, obj.InterfaceID)
+		return res, notFound(err)
+	case *api.NeighborNat:
+		if obj.NatIP == nil {
+			return nil, fmt.Errorf("neighbor nat has no nat ip")
+		}
+		list, err := c.structured.ListNeighborNats(ctx, obj.NatIP)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		for _, nat := range list.Items {
+			if nat.Spec.Vni == obj.Spec.Vni && nat.Spec.MinPort == obj.Spec.MinPort && nat.Spec.MaxPort == obj.Spec.MaxPort {
+				return &api.NeighborNat{
+					TypeMeta:        obj.TypeMeta,
+					NeighborNatMeta: obj.NeighborNatMeta,
+					Spec: api.NeighborNatSpec{
+						Vni:           nat.Spec.Vni,
+						MinPort:       nat.Spec.MinPort,
+						MaxPort:       nat.Spec.MaxPort,
+						UnderlayRoute: nat.Spec.UnderlayRoute,
+					},
+				}, nil
+			}
+		}
+		return nil, ErrNotFound
+	case *api.FirewallRule:
+		res, err := c.structured.GetFirewallRule(ctx, obj.InterfaceID, obj.Spec.RuleID)
+		return res, notFound(err)
+	default:
+		return obj, fmt.Errorf("unsupported object %T", obj)
+	}
+}
+
+// matchesNextHop reports whether the next hop of a live route is want. Routes with the same
+// prefix and different next hops are different (ECMP) routes, if want is nil any matches.
+func matchesNextHop(live, want *api.RouteNextHop) bool {
+	if want == nil {
+		return true
+	}
+	if live == nil || live.VNI != want.VNI {
+		return false
+	}
+	if want.IP == nil || live.IP == nil {
+		return want.IP == live.IP
+	}
+	return *live.IP == *want.IP
+}
+
 func (c *client) Create(ctx context.Context, obj any) (any, error) {
 	switch obj := obj.(type) {
 	case *api.Interface:
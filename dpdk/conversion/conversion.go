@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package conversion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	"github.com/ironcore-dev/dpservice-go/client"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	"google.golang.org/grpc"
+)
+
+var errRequestRecorded = errors.New("request recorded")
+
+// requestRecorder records the create request the dpservice-go client sends instead of
+// sending it to dpservice.
+type requestRecorder struct {
+	dpdkproto.DPDKironcoreClient
+	req any
+}
+
+func (r *requestRecorder) CreateInterface(ctx context.Context, in *dpdkproto.CreateInterfaceRequest, opts ...grpc.CallOption) (*dpdkproto.CreateInterfaceResponse, error) {
+	r.req = in
+	return nil, errRequestRecorded
+}
+
+func (r *requestRecorder) CreatePrefix(ctx context.Context, in *dpdkproto.CreatePrefixRequest, opts ...grpc.CallOption) (*dpdkproto.CreatePrefixResponse, error) {
+	r.req = in
+	return nil, errRequestRecorded
+}
+
+func (r *requestRecorder) CreateRoute(ctx context.Context, in *dpdkproto.CreateRouteRequest, opts ...grpc.CallOption) (*dpdkproto.CreateRouteResponse, error) {
+	r.req = in
+	return nil, errRequestRecorded
+}
+
+func (r *requestRecorder) CreateVip(ctx context.Context, in *dpdkproto.CreateVipRequest, opts ...grpc.CallOption) (*dpdkproto.CreateVipResponse, error) {
+	r.req = in
+	return nil, errRequestRecorded
+}
+
+func (r *requestRecorder) CreateLoadBalancer(ctx context.Context, in *dpdkproto.CreateLoadBalancerRequest, opts ...grpc.CallOption) (*dpdkproto.CreateLoadBalancerResponse, error) {
+	r.req = in
+	return nil, errRequestRecorded
+}
+
+func (r *requestRecorder) CreateNat(ctx context.Context, in *dpdkproto.CreateNatRequest, opts ...grpc.CallOption) (*dpdkproto.CreateNatResponse, error) {
+	r.req = in
+	return nil, errRequestRecorded
+}
+
+func (r *requestRecorder) CreateFirewallRule(ctx context.Context, in *dpdkproto.CreateFirewallRuleRequest, opts ...grpc.CallOption) (*dpdkproto.CreateFirewallRuleResponse, error) {
+	r.req = in
+	return nil, errRequestRecorded
+}
+
+// record returns the request the dpservice-go client builds to create obj.
+func record(ctx context.Context, obj any) (any, error) {
+	r := &requestRecorder{}
+	c := client.NewClient(r)
+
+	var err error
+	switch obj := obj.(type) {
+	case *api.Interface:
+		iface := *obj
+		_, err = c.CreateInterface(ctx, &iface)
+	case *api.Prefix:
+		prefix := *obj
+		_, err = c.CreatePrefix(ctx, &prefix)
+	case *api.Route:
+		route := *obj
+		_, err = c.CreateRoute(ctx, &route)
+	case *api.VirtualIP:
+		virtualIP := *obj
+		_, err = c.CreateVirtualIP(ctx, &virtualIP)
+	case *api.LoadBalancer:
+		lb := *obj
+		_, err = c.CreateLoadBalancer(ctx, &lb)
+	case *api.Nat:
+		nat := *obj
+		_, err = c.CreateNat(ctx, &nat)
+	case *api.FirewallRule:
+		// the client rewrites the action and direction of the rule it is given
+		fwRule := *obj
+		_, err = c.CreateFirewallRule(ctx, &fwRule)
+	default:
+		return nil, fmt.Errorf("unsupported object %T", obj)
+	}
+	if !errors.Is(err, errRequestRecorded) {
+		return nil, err
+	}
+	return r.req, nil
+}
+
+// underlayRoute returns the underlay route dpservice reports for an object. It is assigned
+// by dpservice and not part of the create request, so it is taken from the object.
+func underlayRoute(addr *netip.Addr) []byte {
+	if addr == nil {
+		return nil
+	}
+	return []byte(addr.String())
+}
+
+// RoundTrip converts obj to the request the dpservice-go client sends to create it and
+// reads it back with the dpservice-go api converters, the way dpservice reports it.
+func RoundTrip(ctx context.Context, obj any) (any, error) {
+	req, err := record(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req := req.(type) {
+	case *dpdkproto.CreateInterfaceRequest:
+		iface := obj.(*api.Interface)
+		return api.ProtoInterfaceToInterface(&dpdkproto.Interface{
+			Id:             req.InterfaceId,
+			Vni:            req.Vni,
+			PrimaryIpv4:    req.GetIpv4Config().GetPrimaryAddress(),
+			PrimaryIpv6:    req.GetIpv6Config().GetPrimaryAddress(),
+			UnderlayRoute:  underlayRoute(iface.Spec.UnderlayRoute),
+			PciName:        req.DeviceName,
+			MeteringParams: req.MeteringParameters,
+		})
+	case *dpdkproto.CreatePrefixRequest:
+		prefix := obj.(*api.Prefix)
+		protoPrefix := &dpdkproto.Prefix{
+			Ip:            req.GetPrefix().GetIp(),
+			Length:        req.GetPrefix().GetLength(),
+			UnderlayRoute: underlayRoute(prefix.Spec.UnderlayRoute),
+		}
+		return api.ProtoPrefixToPrefix(string(req.InterfaceId), protoPrefix)
+	case *dpdkproto.CreateRouteRequest:
+		return api.ProtoRouteToRoute(req.Vni, req.Route)
+	case *dpdkproto.CreateVipRequest:
+		virtualIP := obj.(*api.VirtualIP)
+		return api.ProtoVirtualIPToVirtualIP(string(req.InterfaceId), &dpdkproto.GetVipResponse{
+			VipIp:         req.VipIp,
+			UnderlayRoute: underlayRoute(virtualIP.Spec.UnderlayRoute),
+		})
+	case *dpdkproto.CreateLoadBalancerRequest:
+		lb := obj.(*api.LoadBalancer)
+		return api.ProtoLoadBalancerToLoadBalancer(&dpdkproto.GetLoadBalancerResponse{
+			Status:            &dpdkproto.Status{},
+			Vni:               req.Vni,
+			LoadbalancedIp:    req.LoadbalancedIp,
+			LoadbalancedPorts: req.LoadbalancedPorts,
+			UnderlayRoute:     underlayRoute(lb.Spec.UnderlayRoute),
+		}, string(req.LoadbalancerId))
+	case *dpdkproto.CreateNatRequest:
+		nat := obj.(*api.Nat)
+		return api.ProtoNatToNat(&dpdkproto.GetNatResponse{
+			Status:        &dpdkproto.Status{},
+			NatIp:         req.NatIp,
+			MinPort:       req.MinPort,
+			MaxPort:       req.MaxPort,
+			UnderlayRoute: underlayRoute(nat.Spec.UnderlayRoute),
+		}, string(req.InterfaceId))
+	case *dpdkproto.CreateFirewallRuleRequest:
+		return api.ProtoFwRuleToFwRule(req.Rule, string(req.InterfaceId))
+	default:
+		return nil, fmt.Errorf("unsupported request %T", req)
+	}
+}
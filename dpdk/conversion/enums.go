@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// EnumMapping converts the values of an enum to display strings and parses them back.
+// Parsing is case-insensitive and also accepts aliases and the numeric value.
+type EnumMapping[E ~int32] struct {
+	names  map[E]string
+	values map[string]E
+}
+
+func NewEnumMapping[E ~int32](names map[E]string, aliases map[string]E) *EnumMapping[E] {
+	m := &EnumMapping[E]{
+		names:  names,
+		values: make(map[string]E, len(names)+len(aliases)),
+	}
+	for value, name := range names {
+		m.values[strings.ToLower(name)] = value
+	}
+	for alias, value := range aliases {
+		m.values[strings.ToLower(alias)] = value
+	}
+	return m
+}
+
+// String returns the display string of value, or its number if value has no mapping.
+func (m *EnumMapping[E]) String(value E) string {
+	if name, ok := m.names[value]; ok {
+		return name
+	}
+	return strconv.Itoa(int(value))
+}
+
+func (m *EnumMapping[E]) Parse(s string) (E, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if value, ok := m.values[s]; ok {
+		return value, nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 32); err == nil {
+		if _, ok := m.names[E(i)]; ok {
+			return E(i), nil
+		}
+	}
+
+	expected := make([]string, 0, len(m.names))
+	for _, value := range m.Values() {
+		expected = append(expected, fmt.Sprintf("%s = %d", strings.ToLower(m.names[value]), value))
+	}
+	return 0, fmt.Errorf("invalid value %q, can be only: %s", s, strings.Join(expected, "/"))
+}
+
+// Values returns all mapped values in ascending order.
+func (m *EnumMapping[E]) Values() []E {
+	values := make([]E, 0, len(m.names))
+	for value := range m.names {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+// NatType selects which NATs are listed. dpservice has no enum for it, the
+// dpservice-go client takes it as a string.
+type NatType int32
+
+const (
+	NatTypeAny      NatType = 0
+	NatTypeLocal    NatType = 1
+	NatTypeNeighbor NatType = 2
+)
+
+var (
+	Protocols = NewEnumMapping(map[dpdkproto.Protocol]string{
+		dpdkproto.Protocol_UNDEFINED: "UNDEFINED",
+		dpdkproto.Protocol_ICMP:      "ICMP",
+		dpdkproto.Protocol_TCP:       "TCP",
+		dpdkproto.Protocol_UDP:       "UDP",
+		dpdkproto.Protocol_ICMPV6:    "ICMPV6",
+		dpdkproto.Protocol_SCTP:      "SCTP",
+	}, nil)
+
+	IPVersions = NewEnumMapping(map[dpdkproto.IpVersion]string{
+		dpdkproto.IpVersion_IPV4: "IPV4",
+		dpdkproto.IpVersion_IPV6: "IPV6",
+	}, nil)
+
+	TrafficDirections = NewEnumMapping(map[dpdkproto.TrafficDirection]string{
+		dpdkproto.TrafficDirection_INGRESS: "INGRESS",
+		dpdkproto.TrafficDirection_EGRESS:  "EGRESS",
+	}, nil)
+
+	FirewallActions = NewEnumMapping(map[dpdkproto.FirewallAction]string{
+		dpdkproto.FirewallAction_DROP:   "DROP",
+		dpdkproto.FirewallAction_ACCEPT: "ACCEPT",
+	}, map[string]dpdkproto.FirewallAction{
+		"deny":  dpdkproto.FirewallAction_DROP,
+		"allow": dpdkproto.FirewallAction_ACCEPT,
+	})
+
+	VniTypes = NewEnumMapping(map[dpdkproto.VniType]string{
+		dpdkproto.VniType_VNI_IPV4: "VNI_IPV4",
+		dpdkproto.VniType_VNI_IPV6: "VNI_IPV6",
+		dpdkproto.VniType_VNI_BOTH: "VNI_BOTH",
+	}, map[string]dpdkproto.VniType{
+		"ipv4": dpdkproto.VniType_VNI_IPV4,
+		"ipv6": dpdkproto.VniType_VNI_IPV6,
+		"both": dpdkproto.VniType_VNI_BOTH,
+	})
+
+	CaptureInterfaceTypes = NewEnumMapping(map[dpdkproto.CaptureInterfaceType]string{
+		dpdkproto.CaptureInterfaceType_SINGLE_PF: "SINGLE_PF",
+		dpdkproto.CaptureInterfaceType_SINGLE_VF: "SINGLE_VF",
+	}, map[string]dpdkproto.CaptureInterfaceType{
+		"pf": dpdkproto.CaptureInterfaceType_SINGLE_PF,
+		"vf": dpdkproto.CaptureInterfaceType_SINGLE_VF,
+	})
+
+	NatTypes = NewEnumMapping(map[NatType]string{
+		NatTypeAny:      "ANY",
+		NatTypeLocal:    "LOCAL",
+		NatTypeNeighbor: "NEIGHBOR",
+	}, map[string]NatType{
+		"neigh": NatTypeNeighbor,
+	})
+)
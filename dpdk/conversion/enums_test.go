@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package conversion_test
+
+import (
+	"strconv"
+	"strings"
+
+	. "github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// expectExhaustive fails if a value of the proto enum has no mapping, e.g. after
+// a dpservice-go update added a new enum value.
+func expectExhaustive[E ~int32](mapping *EnumMapping[E], protoNames map[int32]string) {
+	GinkgoHelper()
+	Expect(mapping.Values()).To(HaveLen(len(protoNames)))
+	for value := range protoNames {
+		name := mapping.String(E(value))
+		Expect(name).NotTo(Equal(strconv.Itoa(int(value))), "missing mapping for %s", protoNames[value])
+
+		parsed, err := mapping.Parse(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(E(value)))
+
+		parsed, err = mapping.Parse(strings.ToLower(name))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(E(value)))
+
+		parsed, err = mapping.Parse(strconv.Itoa(int(value)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(E(value)))
+	}
+}
+
+var _ = Describe("Enums", func() {
+	It("should map all proto enum values", func() {
+		expectExhaustive(Protocols, dpdkproto.Protocol_name)
+		expectExhaustive(IPVersions, dpdkproto.IpVersion_name)
+		expectExhaustive(TrafficDirections, dpdkproto.TrafficDirection_name)
+		expectExhaustive(FirewallActions, dpdkproto.FirewallAction_name)
+		expectExhaustive(VniTypes, dpdkproto.VniType_name)
+		expectExhaustive(CaptureInterfaceTypes, dpdkproto.CaptureInterfaceType_name)
+	})
+
+	It("should map all NAT types", func() {
+		expectExhaustive(NatTypes, map[int32]string{0: "any", 1: "local", 2: "neighbor"})
+	})
+
+	It("should parse aliases", func() {
+		Expect(VniTypes.Parse("both")).To(Equal(dpdkproto.VniType_VNI_BOTH))
+		Expect(FirewallActions.Parse("Allow")).To(Equal(dpdkproto.FirewallAction_ACCEPT))
+		Expect(NatTypes.Parse("neigh")).To(Equal(NatTypeNeighbor))
+	})
+
+	It("should reject unknown values", func() {
+		_, err := Protocols.Parse("foo")
+		Expect(err).To(MatchError(ContainSubstring("tcp = 6")))
+
+		_, err = Protocols.Parse("3")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should list values in ascending order", func() {
+		Expect(Protocols.Values()).To(Equal([]dpdkproto.Protocol{
+			dpdkproto.Protocol_UNDEFINED,
+			dpdkproto.Protocol_ICMP,
+			dpdkproto.Protocol_TCP,
+			dpdkproto.Protocol_UDP,
+			dpdkproto.Protocol_ICMPV6,
+			dpdkproto.Protocol_SCTP,
+		}))
+	})
+})
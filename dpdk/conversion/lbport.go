@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+)
+
+// Protocols of api.LBPort. The api stores them as plain numbers (the IANA protocol
+// numbers), they are mapped to the proto enum explicitly instead of by a cast.
+const (
+	ProtocolICMP   uint32 = 1
+	ProtocolTCP    uint32 = 6
+	ProtocolUDP    uint32 = 17
+	ProtocolICMPv6 uint32 = 58
+	ProtocolSCTP   uint32 = 132
+)
+
+// LBPortProtocols are all protocols an api.LBPort can have.
+var LBPortProtocols = []uint32{ProtocolICMP, ProtocolTCP, ProtocolUDP, ProtocolICMPv6, ProtocolSCTP}
+
+func ProtocolToProto(protocol uint32) (dpdkproto.Protocol, error) {
+	switch protocol {
+	case ProtocolICMP:
+		return dpdkproto.Protocol_ICMP, nil
+	case ProtocolTCP:
+		return dpdkproto.Protocol_TCP, nil
+	case ProtocolUDP:
+		return dpdkproto.Protocol_UDP, nil
+	case ProtocolICMPv6:
+		return dpdkproto.Protocol_ICMPV6, nil
+	case ProtocolSCTP:
+		return dpdkproto.Protocol_SCTP, nil
+	default:
+		return dpdkproto.Protocol_UNDEFINED, fmt.Errorf("unsupported loadbalancer port protocol %d", protocol)
+	}
+}
+
+func ProtoToProtocol(protocol dpdkproto.Protocol) (uint32, error) {
+	switch protocol {
+	case dpdkproto.Protocol_ICMP:
+		return ProtocolICMP, nil
+	case dpdkproto.Protocol_TCP:
+		return ProtocolTCP, nil
+	case dpdkproto.Protocol_UDP:
+		return ProtocolUDP, nil
+	case dpdkproto.Protocol_ICMPV6:
+		return ProtocolICMPv6, nil
+	case dpdkproto.Protocol_SCTP:
+		return ProtocolSCTP, nil
+	default:
+		return 0, fmt.Errorf("unsupported loadbalancer port protocol %s", Protocols.String(protocol))
+	}
+}
+
+// ParseLBPort parses a loadbalancer port of the form <protocol>/<port>, e.g. TCP/443.
+func ParseLBPort(s string) (api.LBPort, error) {
+	protocolName, portNumber, ok := strings.Cut(s, "/")
+	if !ok {
+		return api.LBPort{}, fmt.Errorf("invalid loadbalancer port %q, expected <protocol>/<port>", s)
+	}
+	protoProtocol, err := Protocols.Parse(protocolName)
+	if err != nil {
+		return api.LBPort{}, fmt.Errorf("error parsing protocol: %w", err)
+	}
+	protocol, err := ProtoToProtocol(protoProtocol)
+	if err != nil {
+		return api.LBPort{}, err
+	}
+	port, err := strconv.ParseUint(portNumber, 10, 16)
+	if err != nil {
+		return api.LBPort{}, fmt.Errorf("error parsing port number: %w", err)
+	}
+	return api.LBPort{Protocol: protocol, Port: uint32(port)}, nil
+}
+
+// FormatLBPort is the inverse of ParseLBPort.
+func FormatLBPort(port api.LBPort) string {
+	name := strconv.Itoa(int(port.Protocol))
+	if protocol, err := ProtocolToProto(port.Protocol); err == nil {
+		name = Protocols.String(protocol)
+	}
+	return name + "/" + strconv.Itoa(int(port.Port))
+}
+
+// FormatLBPorts formats ports in the syntax of --lbports, e.g. TCP/443,UDP/53.
+func FormatLBPorts(ports []api.LBPort) string {
+	formatted := make([]string, len(ports))
+	for i, port := range ports {
+		formatted[i] = FormatLBPort(port)
+	}
+	return strings.Join(formatted, ",")
+}
+
+// ParseLBPortsJSON parses a json array of loadbalancer ports, e.g. [{"protocol":"TCP","port":443}].
+// Protocols may be given by name or number, as in LBPortJSON.
+func ParseLBPortsJSON(s string) ([]api.LBPort, error) {
+	var jsonPorts []LBPortJSON
+	if err := json.Unmarshal([]byte(s), &jsonPorts); err != nil {
+		return nil, fmt.Errorf("invalid loadbalancer ports json: %w", err)
+	}
+	ports := make([]api.LBPort, 0, len(jsonPorts))
+	for _, port := range jsonPorts {
+		if port.Port > 65535 {
+			return nil, fmt.Errorf("invalid loadbalancer port %d, must be at most 65535", port.Port)
+		}
+		if _, err := ProtocolToProto(port.Protocol); err != nil {
+			return nil, err
+		}
+		ports = append(ports, api.LBPort(port))
+	}
+	return ports, nil
+}
+
+// LBPortJSON is an api.LBPort whose JSON representation carries the protocol both by
+// number and by name, e.g. {"protocol":6,"protocol_name":"TCP","port":443}.
+// It unmarshals from either, the protocol may also be given by name only.
+type LBPortJSON api.LBPort
+
+type lbPortJSON struct {
+	Protocol     json.RawMessage `json:"protocol,omitempty"`
+	ProtocolName string          `json:"protocol_name,omitempty"`
+	Port         uint32          `json:"port"`
+}
+
+func (p LBPortJSON) MarshalJSON() ([]byte, error) {
+	var name string
+	if protocol, err := ProtocolToProto(p.Protocol); err == nil {
+		name = Protocols.String(protocol)
+	}
+	return json.Marshal(lbPortJSON{
+		Protocol:     json.RawMessage(strconv.FormatUint(uint64(p.Protocol), 10)),
+		ProtocolName: name,
+		Port:         p.Port,
+	})
+}
+
+func (p *LBPortJSON) UnmarshalJSON(data []byte) error {
+	var raw lbPortJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	protocolName := raw.ProtocolName
+	var protocol uint32
+	hasNumber := false
+	if len(raw.Protocol) > 0 && raw.Protocol[0] == '"' {
+		if err := json.Unmarshal(raw.Protocol, &protocolName); err != nil {
+			return err
+		}
+	} else if len(raw.Protocol) > 0 {
+		if err := json.Unmarshal(raw.Protocol, &protocol); err != nil {
+			return fmt.Errorf("invalid loadbalancer port protocol %s, expected a number or a name", raw.Protocol)
+		}
+		hasNumber = true
+	}
+
+	switch {
+	case protocolName != "":
+		protoProtocol, err := Protocols.Parse(protocolName)
+		if err != nil {
+			return fmt.Errorf("error parsing protocol: %w", err)
+		}
+		named, err := ProtoToProtocol(protoProtocol)
+		if err != nil {
+			return err
+		}
+		if hasNumber && named != protocol {
+			return fmt.Errorf("loadbalancer port protocol %d does not match protocol name %s", protocol, protocolName)
+		}
+		protocol = named
+	case !hasNumber:
+		return fmt.Errorf("loadbalancer port has no protocol")
+	}
+
+	*p = LBPortJSON{Protocol: protocol, Port: raw.Port}
+	return nil
+}
+
+// LoadBalancerJSON is an api.LoadBalancer whose ports are represented as LBPortJSON.
+type LoadBalancerJSON struct {
+	api.TypeMeta         `json:",inline"`
+	api.LoadBalancerMeta `json:"metadata"`
+	Spec                 LoadBalancerSpecJSON `json:"spec"`
+	Status               api.Status           `json:"status"`
+}
+
+type LoadBalancerSpecJSON struct {
+	VNI           uint32       `json:"vni"`
+	LbVipIP       *netip.Addr  `json:"loadbalanced_ip,omitempty"`
+	Lbports       []LBPortJSON `json:"loadbalanced_ports,omitempty"`
+	UnderlayRoute *netip.Addr  `json:"underlay_route,omitempty"`
+}
+
+func NewLoadBalancerJSON(lb *api.LoadBalancer) *LoadBalancerJSON {
+	res := &LoadBalancerJSON{
+		TypeMeta:         lb.TypeMeta,
+		LoadBalancerMeta: lb.LoadBalancerMeta,
+		Spec: LoadBalancerSpecJSON{
+			VNI:           lb.Spec.VNI,
+			LbVipIP:       lb.Spec.LbVipIP,
+			UnderlayRoute: lb.Spec.UnderlayRoute,
+		},
+		Status: lb.Status,
+	}
+	for _, port := range lb.Spec.Lbports {
+		res.Spec.Lbports = append(res.Spec.Lbports, LBPortJSON(port))
+	}
+	return res
+}
+
+func (lb *LoadBalancerJSON) LoadBalancer() *api.LoadBalancer {
+	res := &api.LoadBalancer{
+		TypeMeta:         lb.TypeMeta,
+		LoadBalancerMeta: lb.LoadBalancerMeta,
+		Spec: api.LoadBalancerSpec{
+			VNI:           lb.Spec.VNI,
+			LbVipIP:       lb.Spec.LbVipIP,
+			UnderlayRoute: lb.Spec.UnderlayRoute,
+		},
+		Status: lb.Status,
+	}
+	for _, port := range lb.Spec.Lbports {
+		res.Spec.Lbports = append(res.Spec.Lbports, api.LBPort(port))
+	}
+	return res
+}
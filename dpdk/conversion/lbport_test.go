@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package conversion_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/netip"
+
+	. "github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/runtime"
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LBPort", func() {
+	DescribeTable("should map every protocol to the proto value of the same name",
+		func(protocol uint32, expected dpdkproto.Protocol) {
+			protoProtocol, err := ProtocolToProto(protocol)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(protoProtocol).To(Equal(expected))
+
+			back, err := ProtoToProtocol(protoProtocol)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(back).To(Equal(protocol))
+		},
+		Entry("ICMP", ProtocolICMP, dpdkproto.Protocol_ICMP),
+		Entry("TCP", ProtocolTCP, dpdkproto.Protocol_TCP),
+		Entry("UDP", ProtocolUDP, dpdkproto.Protocol_UDP),
+		Entry("ICMPv6", ProtocolICMPv6, dpdkproto.Protocol_ICMPV6),
+		Entry("SCTP", ProtocolSCTP, dpdkproto.Protocol_SCTP),
+	)
+
+	It("should map every protocol and every proto protocol except UNDEFINED", func() {
+		for _, protocol := range LBPortProtocols {
+			_, err := ProtocolToProto(protocol)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		for value, name := range dpdkproto.Protocol_name {
+			_, err := ProtoToProtocol(dpdkproto.Protocol(value))
+			if dpdkproto.Protocol(value) == dpdkproto.Protocol_UNDEFINED {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred(), "missing mapping for %s", name)
+			}
+		}
+		Expect(LBPortProtocols).To(HaveLen(len(dpdkproto.Protocol_name) - 1))
+	})
+
+	It("should parse and format ports", func() {
+		port, err := ParseLBPort("tcp/443")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(port).To(Equal(api.LBPort{Protocol: ProtocolTCP, Port: 443}))
+		Expect(FormatLBPort(port)).To(Equal("TCP/443"))
+
+		_, err = ParseLBPort("undefined/443")
+		Expect(err).To(HaveOccurred())
+		_, err = ParseLBPort("tcp/70000")
+		Expect(err).To(HaveOccurred())
+		_, err = ParseLBPort("tcp")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should marshal the protocol by number and name and unmarshal either", func() {
+		data, err := json.Marshal(LBPortJSON{Protocol: ProtocolTCP, Port: 443})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"protocol":6,"protocol_name":"TCP","port":443}`))
+
+		for _, data := range []string{
+			`{"protocol":6,"protocol_name":"TCP","port":443}`,
+			`{"protocol":6,"port":443}`,
+			`{"protocol_name":"tcp","port":443}`,
+			`{"protocol":"TCP","port":443}`,
+		} {
+			var port LBPortJSON
+			Expect(json.Unmarshal([]byte(data), &port)).To(Succeed(), data)
+			Expect(port).To(Equal(LBPortJSON{Protocol: ProtocolTCP, Port: 443}), data)
+		}
+
+		var port LBPortJSON
+		Expect(json.Unmarshal([]byte(`{"protocol":17,"protocol_name":"TCP","port":443}`), &port)).To(MatchError(ContainSubstring("does not match")))
+		Expect(json.Unmarshal([]byte(`{"port":443}`), &port)).To(MatchError(ContainSubstring("no protocol")))
+	})
+
+	It("should round-trip loadbalancers through json and yaml output", func() {
+		vip := netip.MustParseAddr("10.0.0.1")
+		lb := &api.LoadBalancer{
+			TypeMeta:         api.TypeMeta{Kind: api.LoadBalancerKind},
+			LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"},
+			Spec: api.LoadBalancerSpec{
+				VNI:     100,
+				LbVipIP: &vip,
+				Lbports: []api.LBPort{{Protocol: ProtocolTCP, Port: 443}, {Protocol: ProtocolUDP, Port: 53}},
+			},
+		}
+
+		for _, r := range []func(*bytes.Buffer) renderer.Renderer{
+			func(buf *bytes.Buffer) renderer.Renderer { return renderer.NewJSON(buf, false) },
+			func(buf *bytes.Buffer) renderer.Renderer { return renderer.NewYAML(buf) },
+		} {
+			var buf bytes.Buffer
+			Expect(r(&buf).Render(lb)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("protocol_name"))
+
+			factory, err := runtime.NewExtDecoderFactory("yaml")
+			Expect(err).NotTo(HaveOccurred())
+			obj, err := runtime.NewKindDecoder(runtime.DefaultScheme, runtime.NewPeekDecoder(&buf, factory)).Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj).To(Equal(lb))
+		}
+	})
+
+	It("should parse json ports with protocols by name or number", func() {
+		ports, err := ParseLBPortsJSON(`[{"protocol":"TCP","port":443},{"protocol":17,"port":53}]`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ports).To(Equal([]api.LBPort{{Protocol: ProtocolTCP, Port: 443}, {Protocol: ProtocolUDP, Port: 53}}))
+
+		_, err = ParseLBPortsJSON(`[{"protocol":"TCP","port":443}`)
+		Expect(err).To(MatchError(ContainSubstring("invalid loadbalancer ports json")))
+		_, err = ParseLBPortsJSON(`[{"protocol":"undefined","port":443}]`)
+		Expect(err).To(HaveOccurred())
+		_, err = ParseLBPortsJSON(`[{"protocol":99,"port":443}]`)
+		Expect(err).To(MatchError("unsupported loadbalancer port protocol 99"))
+		_, err = ParseLBPortsJSON(`[{"protocol":"TCP","port":70000}]`)
+		Expect(err).To(MatchError(ContainSubstring("must be at most 65535")))
+		_, err = ParseLBPortsJSON(`{"protocol":"TCP","port":443}`)
+		Expect(err).To(HaveOccurred())
+	})
+})
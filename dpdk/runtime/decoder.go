@@ -9,7 +9,9 @@ import (
 	"io"
 
 	yaml2 "github.com/ghodss/yaml"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
 	dpsvcio "github.com/ironcore-dev/dpservice-cli/io"
+	"github.com/ironcore-dev/dpservice-go/api"
 	"gopkg.in/yaml.v2"
 )
 
@@ -82,6 +84,15 @@ func (d *KindDecoder) Next() (any, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling %s: %w", obj.Kind, err)
 	}
+	if lb, ok := res.(*api.LoadBalancer); ok {
+		// accept loadbalancer port protocols by name as well as by number
+		lbJSON := &conversion.LoadBalancerJSON{}
+		if err := json.Unmarshal(jsonObj, lbJSON); err != nil {
+			return nil, fmt.Errorf("error unmarshaling %s: %w", obj.Kind, err)
+		}
+		*lb = *lbJSON.LoadBalancer()
+		return res, nil
+	}
 	err = json.Unmarshal(jsonObj, res)
 	if err != nil {
 		return nil, fmt.Errorf("error unmarshaling %s: %w", obj.Kind, err)
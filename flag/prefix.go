@@ -4,6 +4,7 @@
 package flag
 
 import (
+	"fmt"
 	"net/netip"
 	"strings"
 
@@ -21,8 +22,18 @@ func (v *prefixValue) String() string {
 	return netip.Prefix(*v).String()
 }
 
-func (v *prefixValue) Set(s string) error {
+// ParsePrefix parses a prefix like prefix flags do, so that prefixes given as arguments
+// are reported with the same errors.
+func ParsePrefix(s string) (netip.Prefix, error) {
 	prefix, err := netip.ParsePrefix(strings.TrimSpace(s))
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid string %q being converted to IP prefix: %w", s, err)
+	}
+	return prefix, nil
+}
+
+func (v *prefixValue) Set(s string) error {
+	prefix, err := ParsePrefix(s)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package flag_test
+
+import (
+	"net/netip"
+
+	. "github.com/ironcore-dev/dpservice-cli/flag"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Prefix", func() {
+	var fs *pflag.FlagSet
+
+	BeforeEach(func() {
+		fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+	})
+
+	Context("PrefixVar", func() {
+		It("should parse a valid prefix", func() {
+			var prefix netip.Prefix
+			PrefixVar(fs, &prefix, "prefix", netip.Prefix{}, "")
+
+			Expect(fs.Parse([]string{"--prefix= 10.0.0.0/24"})).To(Succeed())
+			Expect(prefix).To(Equal(netip.MustParsePrefix("10.0.0.0/24")))
+		})
+
+		DescribeTable("should reject invalid CIDRs",
+			func(value string) {
+				var prefix netip.Prefix
+				PrefixVar(fs, &prefix, "prefix", netip.Prefix{}, "")
+
+				err := fs.Parse([]string{"--prefix=" + value})
+				Expect(err).To(MatchError(ContainSubstring("being converted to IP prefix")))
+			},
+			Entry("missing length", "10.0.0.0"),
+			Entry("length out of range", "10.0.0.0/33"),
+			Entry("malformed address", "10.0.0/24"),
+			Entry("not an address", "foo/24"),
+			Entry("empty", ""),
+		)
+	})
+
+	Context("ParsePrefix", func() {
+		It("should report invalid prefixes like PrefixVar", func() {
+			Expect(ParsePrefix(" fd00::/64")).To(Equal(netip.MustParsePrefix("fd00::/64")))
+
+			_, err := ParsePrefix("10.0.0.0/40")
+			Expect(err).To(MatchError(ContainSubstring(`invalid string "10.0.0.0/40" being converted to IP prefix`)))
+		})
+	})
+})
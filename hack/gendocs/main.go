@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Command gendocs generates the markdown pages of docs/commands from the cobra commands.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ironcore-dev/dpservice-cli/cmd"
+	"github.com/spf13/cobra/doc"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s <output directory>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	root := cmd.Command()
+	root.InitDefaultCompletionCmd()
+	if err := doc.GenMarkdownTree(root, os.Args[1]); err != nil {
+		fmt.Fprintf(os.Stderr, "error generating docs: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ErrWriteTimeout is returned by a TimeoutWriter whose destination stalled.
+var ErrWriteTimeout = errors.New("write timed out")
+
+// TimeoutWriter fails writes to dst that do not complete within a timeout. A stalled
+// write keeps blocking in the background, so all later writes fail immediately.
+type TimeoutWriter struct {
+	dst     io.Writer
+	timeout time.Duration
+	stalled bool
+}
+
+func NewTimeoutWriter(dst io.Writer, timeout time.Duration) *TimeoutWriter {
+	return &TimeoutWriter{
+		dst:     dst,
+		timeout: timeout,
+	}
+}
+
+func (w *TimeoutWriter) Write(p []byte) (int, error) {
+	if w.stalled {
+		return 0, fmt.Errorf("%w: the output stalled before", ErrWriteTimeout)
+	}
+
+	type result struct {
+		n   int
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		n, err := w.dst.Write(p)
+		done <- result{n, err}
+	}()
+
+	timer := time.NewTimer(w.timeout)
+	defer timer.Stop()
+	select {
+	case res := <-done:
+		return res.n, res.err
+	case <-timer.C:
+		w.stalled = true
+		return 0, fmt.Errorf("%w: the output did not accept data for %s", ErrWriteTimeout, w.timeout)
+	}
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package io_test
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	. "github.com/ironcore-dev/dpservice-cli/io"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Writer", func() {
+	Context("TimeoutWriter", func() {
+		It("should pass writes through that complete in time", func() {
+			var buf bytes.Buffer
+			w := NewTimeoutWriter(&buf, time.Second)
+			Expect(w.Write([]byte("foo"))).To(Equal(3))
+			Expect(buf.String()).To(Equal("foo"))
+		})
+
+		It("should fail writes to a stalled output and all writes after", func() {
+			rd, wr := io.Pipe()
+			DeferCleanup(rd.Close)
+			w := NewTimeoutWriter(wr, 10*time.Millisecond)
+
+			_, err := w.Write([]byte("foo"))
+			Expect(err).To(MatchError(ErrWriteTimeout))
+
+			go func() { _, _ = io.Copy(io.Discard, rd) }()
+			_, err = w.Write([]byte("bar"))
+			Expect(err).To(MatchError(ErrWriteTimeout))
+		})
+	})
+})
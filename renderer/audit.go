@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+const AuditedInterfaceListKind = "AuditedInterfaceList"
+
+// AuditedInterface is an interface annotated with the warnings found by an audit.
+type AuditedInterface struct {
+	api.Interface `json:",inline"`
+	Warnings      []string `json:"warnings"`
+}
+
+type AuditedInterfaceList struct {
+	api.TypeMeta `json:",inline"`
+	Items        []AuditedInterface `json:"items"`
+	Status       api.Status         `json:"status"`
+}
+
+func (l *AuditedInterfaceList) GetItems() []api.Object {
+	res := make([]api.Object, len(l.Items))
+	for i := range l.Items {
+		res[i] = &l.Items[i]
+	}
+	return res
+}
+
+func (l *AuditedInterfaceList) GetStatus() api.Status {
+	return l.Status
+}
+
+func (t defaultTableConverter) auditedInterfaceTable(ifaces []AuditedInterface) (*TableData, error) {
+	plain := make([]api.Interface, len(ifaces))
+	for i, iface := range ifaces {
+		plain[i] = iface.Interface
+	}
+
+	data, err := t.interfaceTable(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Headers = append(data.Headers, "Warnings")
+	for i, iface := range ifaces {
+		data.Columns[i] = append(data.Columns[i], strings.Join(iface.Warnings, ", "))
+	}
+	return data, nil
+}
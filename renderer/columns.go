@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// columnsConverter restricts the tables of another converter to the columns with the given header names.
+type columnsConverter struct {
+	converter TableConverter
+	names     []string
+}
+
+func NewColumnsConverter(converter TableConverter, names []string) TableConverter {
+	return columnsConverter{converter, names}
+}
+
+func (c columnsConverter) ConvertToTable(v any) (*TableData, error) {
+	data, err := c.converter.ConvertToTable(v)
+	if err != nil {
+		return nil, err
+	}
+	return SelectColumns(data, c.names)
+}
+
+// SelectColumns returns the columns of data with the given header names, in the order of
+// names. Header names are matched case-insensitively.
+func SelectColumns(data *TableData, names []string) (*TableData, error) {
+	indices := make([]int, len(names))
+	for i, name := range names {
+		index, err := columnIndex(data, name)
+		if err != nil {
+			return nil, err
+		}
+		indices[i] = index
+	}
+
+	res := &TableData{
+		Headers: make([]any, len(indices)),
+		Columns: make([][]any, len(data.Columns)),
+	}
+	for i, j := range indices {
+		res.Headers[i] = data.Headers[j]
+	}
+	for r, row := range data.Columns {
+		res.Columns[r] = make([]any, len(indices))
+		for i, j := range indices {
+			if j < len(row) {
+				res.Columns[r][i] = row[j]
+			}
+		}
+	}
+	return res, nil
+}
+
+// columnIndex returns the index of the column with the header name, matched case-insensitively.
+func columnIndex(data *TableData, name string) (int, error) {
+	available := make([]string, len(data.Headers))
+	for i, header := range data.Headers {
+		available[i] = fmt.Sprint(header)
+		if strings.EqualFold(available[i], name) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("unknown column %q, available columns: %s", name, strings.Join(available, ", "))
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SelectColumns", func() {
+	data := &renderer.TableData{
+		Headers: []any{"ID", "VNI", "Device", "UnderlayRoute"},
+		Columns: [][]any{{"vm1", uint32(100), "net_tap1", "fc00::1"}, {"vm2", uint32(200), "net_tap2", "fc00::2"}},
+	}
+
+	It("should select the columns in the given order ignoring case", func() {
+		selected, err := renderer.SelectColumns(data, []string{"underlayroute", "ID"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(selected).To(Equal(&renderer.TableData{
+			Headers: []any{"UnderlayRoute", "ID"},
+			Columns: [][]any{{"fc00::1", "vm1"}, {"fc00::2", "vm2"}},
+		}))
+	})
+
+	It("should list the available columns for unknown names", func() {
+		_, err := renderer.SelectColumns(data, []string{"ID", "UnderlayIP"})
+		Expect(err).To(MatchError(`unknown column "UnderlayIP", available columns: ID, VNI, Device, UnderlayRoute`))
+	})
+
+	It("should select columns of the converted tables", func() {
+		converted, err := renderer.NewColumnsConverter(numbersConverter{}, []string{"Rate", "VNI"}).ConvertToTable(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(converted.Headers).To(Equal([]any{"Rate", "VNI"}))
+		Expect(converted.Columns).To(Equal([][]any{{float64(1e21), uint32(4294967295)}}))
+	})
+})
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"bytes"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type quotingConverter struct{}
+
+func (quotingConverter) ConvertToTable(any) (*renderer.TableData, error) {
+	return &renderer.TableData{
+		Headers: []any{"ID", "Note"},
+		Columns: [][]any{{"a,b", "line1\nline2"}, {`say "hi"`, nil}},
+	}, nil
+}
+
+var _ = Describe("CSV", func() {
+	It("should quote cells containing commas, newlines and quotes", func() {
+		var buf bytes.Buffer
+		Expect(renderer.NewCSV(&buf, quotingConverter{}).Render(nil)).To(Succeed())
+		Expect(buf.String()).To(Equal("ID,Note\n\"a,b\",\"line1\nline2\"\n\"say \"\"hi\"\"\",\n"))
+	})
+
+	It("should render numbers and addresses like the table renderer", func() {
+		var buf bytes.Buffer
+		Expect(renderer.NewCSV(&buf, numbersConverter{}).Render(nil)).To(Succeed())
+		Expect(buf.String()).To(Equal("VNI,Bytes,Rate\n4294967295,18446744073709551615,1000000000000000000000\n"))
+
+		prefix := netip.MustParsePrefix("10.0.0.0/24")
+		nextHop := netip.MustParseAddr("fc00::1")
+		buf.Reset()
+		Expect(renderer.NewCSV(&buf, renderer.DefaultTableConverter).Render(&api.RouteList{Items: []api.Route{{
+			RouteMeta: api.RouteMeta{VNI: 100},
+			Spec:      api.RouteSpec{Prefix: &prefix, NextHop: &api.RouteNextHop{VNI: 200, IP: &nextHop}},
+		}}})).To(Succeed())
+		Expect(buf.String()).To(HaveSuffix("\n10.0.0.0/24,100,200,fc00::1\n"))
+	})
+})
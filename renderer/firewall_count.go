@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+const InterfaceFirewallRuleCountListKind = "InterfaceFirewallRuleCountList"
+
+// InterfaceFirewallRuleCount is an interface annotated with the number of its firewall rules.
+type InterfaceFirewallRuleCount struct {
+	api.Interface `json:",inline"`
+	FirewallRules int `json:"firewallRules"`
+}
+
+type InterfaceFirewallRuleCountList struct {
+	api.TypeMeta `json:",inline"`
+	Items        []InterfaceFirewallRuleCount `json:"items"`
+	Status       api.Status                   `json:"status"`
+}
+
+func (l *InterfaceFirewallRuleCountList) GetItems() []api.Object {
+	res := make([]api.Object, len(l.Items))
+	for i := range l.Items {
+		res[i] = &l.Items[i]
+	}
+	return res
+}
+
+func (l *InterfaceFirewallRuleCountList) GetStatus() api.Status {
+	return l.Status
+}
+
+func (t defaultTableConverter) interfaceFirewallRuleCountTable(ifaces []InterfaceFirewallRuleCount) (*TableData, error) {
+	plain := make([]api.Interface, len(ifaces))
+	for i, iface := range ifaces {
+		plain[i] = iface.Interface
+	}
+
+	data, err := t.interfaceTable(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Headers = append(data.Headers, "FWRules")
+	for i, iface := range ifaces {
+		data.Columns[i] = append(data.Columns[i], iface.FirewallRules)
+	}
+	return data, nil
+}
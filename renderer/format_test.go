@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"bytes"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type numbersConverter struct{}
+
+func (numbersConverter) ConvertToTable(any) (*renderer.TableData, error) {
+	return &renderer.TableData{
+		Headers: []any{"VNI", "Bytes", "Rate"},
+		Columns: [][]any{{uint32(4294967295), uint64(18446744073709551615), float64(1e21)}},
+	}, nil
+}
+
+var _ = Describe("Number formatting", func() {
+	It("should render numbers as plain digits in tables", func() {
+		var buf bytes.Buffer
+		Expect(renderer.NewTable(&buf, numbersConverter{}).Render(nil)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("4294967295"))
+		Expect(buf.String()).To(ContainSubstring("18446744073709551615"))
+		Expect(buf.String()).To(ContainSubstring("1000000000000000000000"))
+		Expect(buf.String()).NotTo(ContainSubstring("e+"))
+	})
+
+	It("should render numbers as plain digits in lines", func() {
+		var buf bytes.Buffer
+		Expect(renderer.NewLine(&buf, numbersConverter{}).Render(nil)).To(Succeed())
+		Expect(buf.String()).To(Equal("vni=4294967295 bytes=18446744073709551615 rate=1000000000000000000000\n"))
+	})
+})
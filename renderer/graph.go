@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+// Topology is the dataplane state shown by a graph.
+type Topology struct {
+	Interfaces    []api.Interface
+	Routes        []api.Route
+	LoadBalancers []TopologyLoadBalancer
+}
+
+type TopologyLoadBalancer struct {
+	LoadBalancer api.LoadBalancer
+	Targets      []api.LoadBalancerTarget
+}
+
+// WriteDOT writes topology as a Graphviz digraph. Every VNI is a cluster containing its
+// interfaces and loadbalancers, routes are edges from the VNI to their next hop. Next hops
+// that are not the underlay route of a known interface or loadbalancer get a node of their own.
+func WriteDOT(w io.Writer, topology Topology) error {
+	g := &dotWriter{w: bufio.NewWriter(w)}
+
+	// nodes by underlay address, to connect routes and loadbalancer targets to them
+	byUnderlay := make(map[netip.Addr]string)
+	members := make(map[uint32][]string)
+	for _, iface := range topology.Interfaces {
+		id := "interface:" + iface.ID
+		members[iface.Spec.VNI] = append(members[iface.Spec.VNI], id)
+		if iface.Spec.UnderlayRoute != nil {
+			byUnderlay[*iface.Spec.UnderlayRoute] = id
+		}
+	}
+	for _, lb := range topology.LoadBalancers {
+		id := "loadbalancer:" + lb.LoadBalancer.ID
+		members[lb.LoadBalancer.Spec.VNI] = append(members[lb.LoadBalancer.Spec.VNI], id)
+		if lb.LoadBalancer.Spec.UnderlayRoute != nil {
+			byUnderlay[*lb.LoadBalancer.Spec.UnderlayRoute] = id
+		}
+	}
+	for _, route := range topology.Routes {
+		if _, ok := members[route.VNI]; !ok {
+			members[route.VNI] = nil
+		}
+	}
+
+	g.line("digraph dpservice {")
+	g.line("  rankdir=LR;")
+	g.line("  node [fontname=monospace];")
+
+	vnis := make([]uint32, 0, len(members))
+	for vni := range members {
+		vnis = append(vnis, vni)
+	}
+	sort.Slice(vnis, func(i, j int) bool { return vnis[i] < vnis[j] })
+
+	for _, vni := range vnis {
+		g.line("  subgraph %s {", quoteDOT(fmt.Sprintf("cluster_vni_%d", vni)))
+		g.line("    label=%s;", quoteDOT(fmt.Sprintf("VNI %d", vni)))
+		g.line("    %s [shape=box, style=rounded, label=%s];", quoteDOT(vniNode(vni)), quoteDOT(fmt.Sprintf("VNI %d", vni)))
+		for _, iface := range topology.Interfaces {
+			if iface.Spec.VNI != vni {
+				continue
+			}
+			label := []string{iface.ID}
+			for _, ip := range []*netip.Addr{iface.Spec.IPv4, iface.Spec.IPv6} {
+				if ip != nil && ip.IsValid() && !ip.IsUnspecified() {
+					label = append(label, ip.String())
+				}
+			}
+			if iface.Spec.UnderlayRoute != nil {
+				label = append(label, "underlay "+iface.Spec.UnderlayRoute.String())
+			}
+			g.line("    %s [shape=box, label=%s];", quoteDOT("interface:"+iface.ID), quoteDOT(strings.Join(label, "\n")))
+		}
+		for _, lb := range topology.LoadBalancers {
+			if lb.LoadBalancer.Spec.VNI != vni {
+				continue
+			}
+			label := []string{lb.LoadBalancer.ID}
+			if lb.LoadBalancer.Spec.LbVipIP != nil {
+				label = append(label, "vip "+lb.LoadBalancer.Spec.LbVipIP.String())
+			}
+			if lb.LoadBalancer.Spec.UnderlayRoute != nil {
+				label = append(label, "underlay "+lb.LoadBalancer.Spec.UnderlayRoute.String())
+			}
+			g.line("    %s [shape=diamond, label=%s];", quoteDOT("loadbalancer:"+lb.LoadBalancer.ID), quoteDOT(strings.Join(label, "\n")))
+		}
+		g.line("  }")
+	}
+
+	external := make(map[string]bool)
+	hopNode := func(vni uint32, ip *netip.Addr) string {
+		if ip != nil {
+			if id, ok := byUnderlay[*ip]; ok {
+				return id
+			}
+		}
+		addr := "<none>"
+		if ip != nil {
+			addr = ip.String()
+		}
+		id := fmt.Sprintf("hop:%d:%s", vni, addr)
+		if !external[id] {
+			external[id] = true
+			g.line("  %s [shape=ellipse, style=dashed, label=%s];", quoteDOT(id), quoteDOT(fmt.Sprintf("%s\nVNI %d", addr, vni)))
+		}
+		return id
+	}
+
+	for _, route := range topology.Routes {
+		if route.Spec.Prefix == nil || route.Spec.NextHop == nil {
+			continue
+		}
+		to := hopNode(route.Spec.NextHop.VNI, route.Spec.NextHop.IP)
+		g.line("  %s -> %s [label=%s];", quoteDOT(vniNode(route.VNI)), quoteDOT(to), quoteDOT(route.Spec.Prefix.String()))
+	}
+	for _, lb := range topology.LoadBalancers {
+		for _, target := range lb.Targets {
+			to := hopNode(lb.LoadBalancer.Spec.VNI, target.Spec.TargetIP)
+			g.line("  %s -> %s [style=dotted, label=\"target\"];", quoteDOT("loadbalancer:"+lb.LoadBalancer.ID), quoteDOT(to))
+		}
+	}
+
+	g.line("}")
+	if g.err != nil {
+		return g.err
+	}
+	return g.w.Flush()
+}
+
+func vniNode(vni uint32) string {
+	return fmt.Sprintf("vni:%d", vni)
+}
+
+// quoteDOT returns s as a quoted DOT ID.
+func quoteDOT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+type dotWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (d *dotWriter) line(format string, args ...any) {
+	if d.err != nil {
+		return
+	}
+	_, d.err = fmt.Fprintf(d.w, format+"\n", args...)
+}
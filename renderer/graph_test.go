@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"bytes"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WriteDOT", func() {
+	addr := func(s string) *netip.Addr {
+		a := netip.MustParseAddr(s)
+		return &a
+	}
+	prefix := netip.MustParsePrefix("10.0.2.0/24")
+
+	It("should render VNIs, interfaces, loadbalancers, routes and targets", func() {
+		topology := renderer.Topology{
+			Interfaces: []api.Interface{
+				{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 100, IPv4: addr("10.0.1.1"), UnderlayRoute: addr("fc00::1")}},
+			},
+			Routes: []api.Route{
+				{RouteMeta: api.RouteMeta{VNI: 100}, Spec: api.RouteSpec{Prefix: &prefix, NextHop: &api.RouteNextHop{VNI: 100, IP: addr("fc00::1")}}},
+				{RouteMeta: api.RouteMeta{VNI: 100}, Spec: api.RouteSpec{Prefix: &prefix, NextHop: &api.RouteNextHop{VNI: 200, IP: addr("fc00::9")}}},
+			},
+			LoadBalancers: []renderer.TopologyLoadBalancer{{
+				LoadBalancer: api.LoadBalancer{LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"}, Spec: api.LoadBalancerSpec{VNI: 100, LbVipIP: addr("20.0.0.1")}},
+				Targets:      []api.LoadBalancerTarget{{Spec: api.LoadBalancerTargetSpec{TargetIP: addr("fc00::1")}}},
+			}},
+		}
+
+		var buf bytes.Buffer
+		Expect(renderer.WriteDOT(&buf, topology)).To(Succeed())
+		Expect(buf.String()).To(HavePrefix("digraph dpservice {\n"))
+		Expect(buf.String()).To(ContainSubstring(`subgraph "cluster_vni_100" {`))
+		Expect(buf.String()).To(ContainSubstring(`"interface:vm1" [shape=box, label="vm1\n10.0.1.1\nunderlay fc00::1"];`))
+		Expect(buf.String()).To(ContainSubstring(`"loadbalancer:lb1" [shape=diamond, label="lb1\nvip 20.0.0.1"];`))
+		Expect(buf.String()).To(ContainSubstring(`"vni:100" -> "interface:vm1" [label="10.0.2.0/24"];`))
+		Expect(buf.String()).To(ContainSubstring(`"hop:200:fc00::9" [shape=ellipse, style=dashed, label="fc00::9\nVNI 200"];`))
+		Expect(buf.String()).To(ContainSubstring(`"vni:100" -> "hop:200:fc00::9" [label="10.0.2.0/24"];`))
+		Expect(buf.String()).To(ContainSubstring(`"loadbalancer:lb1" -> "interface:vm1" [style=dotted, label="target"];`))
+		Expect(buf.String()).To(HaveSuffix("}\n"))
+	})
+})
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interface table", func() {
+	iface := &api.Interface{
+		InterfaceMeta: api.InterfaceMeta{ID: "vm1"},
+		Spec: api.InterfaceSpec{
+			VNI:      100,
+			PXE:      &api.PXE{Server: "192.168.129.1", FileName: "ipxe/x86_64/ipxe.efi"},
+			Metering: &api.MeteringParams{},
+		},
+	}
+
+	It("should show the PXE settings only in wide output", func() {
+		converter := renderer.DefaultTableConverter
+		converter.SetWide(false)
+		data, err := converter.ConvertToTable(iface)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data.Headers).NotTo(ContainElement("PxeServer"))
+
+		converter.SetWide(true)
+		data, err = converter.ConvertToTable(iface)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data.Headers[len(data.Headers)-2:]).To(Equal([]any{"PxeServer", "PxeFileName"}))
+		Expect(data.Columns[0][len(data.Columns[0])-2:]).To(Equal([]any{"192.168.129.1", "ipxe/x86_64/ipxe.efi"}))
+	})
+})
@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrJSONPathNoResults is returned if no expression of a JSONPath template matched a value,
+// so that scripts can detect misses by the exit code.
+var ErrJSONPathNoResults = errors.New("jsonpath matched no values")
+
+// JSONPath renders the values selected by a kubectl-style JSONPath template, such as
+// {.items[*].spec.vni}, from the JSON representation of the object. Text outside of braces
+// is printed as is, multiple values of an expression are separated by spaces.
+//
+// Supported are field names (.name or ['name']), array indices ([0], [-1]) and wildcards
+// ([*] or .*), not filters, slices, recursive descent or range.
+type JSONPath struct {
+	w        io.Writer
+	segments []jsonPathSegment
+}
+
+// jsonPathSegment is either literal text or an expression.
+type jsonPathSegment struct {
+	text  string
+	expr  bool
+	steps []jsonPathStep
+}
+
+type jsonPathStep struct {
+	field    string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// NewJSONPath parses text, which is wrapped in braces if it contains none, like kubectl does.
+func NewJSONPath(w io.Writer, text string) (*JSONPath, error) {
+	if !strings.Contains(text, "{") {
+		text = "{" + text + "}"
+	}
+
+	var segments []jsonPathSegment
+	for pos := 0; pos < len(text); {
+		start := strings.IndexByte(text[pos:], '{')
+		if start < 0 {
+			segments = append(segments, jsonPathSegment{text: text[pos:]})
+			break
+		}
+		if start > 0 {
+			segments = append(segments, jsonPathSegment{text: text[pos : pos+start]})
+		}
+		start += pos
+		end := strings.IndexByte(text[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("error parsing jsonpath %q: unclosed { at position %d", text, start)
+		}
+		end += start
+		steps, err := parseJSONPathExpression(text[start+1 : end])
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jsonpath %q: %w", text, err)
+		}
+		segments = append(segments, jsonPathSegment{expr: true, steps: steps})
+		pos = end + 1
+	}
+	return &JSONPath{w, segments}, nil
+}
+
+func parseJSONPathExpression(expr string) ([]jsonPathStep, error) {
+	expr = strings.TrimSpace(expr)
+	expr = strings.TrimPrefix(expr, "$")
+	if expr == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+	if expr[0] != '.' && expr[0] != '[' {
+		return nil, fmt.Errorf("expression %q must start with . or [", expr)
+	}
+
+	var steps []jsonPathStep
+	for i := 0; i < len(expr); {
+		switch expr[i] {
+		case '.':
+			i++
+			if i < len(expr) && expr[i] == '.' {
+				return nil, fmt.Errorf("recursive descent (..) is not supported")
+			}
+			j := i
+			for j < len(expr) && expr[j] != '.' && expr[j] != '[' {
+				j++
+			}
+			switch name := expr[i:j]; name {
+			case "":
+				// a lone "." selects the object itself
+				if j < len(expr) && expr[j] != '[' {
+					return nil, fmt.Errorf("empty field name at position %d", i)
+				}
+			case "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			default:
+				steps = append(steps, jsonPathStep{field: name})
+			}
+			i = j
+		case '[':
+			j := strings.IndexByte(expr[i:], ']')
+			if j < 0 {
+				return nil, fmt.Errorf("unclosed [ at position %d", i)
+			}
+			step, err := parseJSONPathBracket(expr[i+1 : i+j])
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+			i += j + 1
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", expr[i], i)
+		}
+	}
+	return steps, nil
+}
+
+func parseJSONPathBracket(s string) (jsonPathStep, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "*":
+		return jsonPathStep{wildcard: true}, nil
+	case len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]:
+		return jsonPathStep{field: s[1 : len(s)-1]}, nil
+	}
+	index, err := strconv.Atoi(s)
+	if err != nil {
+		return jsonPathStep{}, fmt.Errorf("unsupported subscript [%s], only indices, quoted names and * are supported", s)
+	}
+	return jsonPathStep{index: index, isIndex: true}, nil
+}
+
+func (j *JSONPath) Render(v any) error {
+	data, err := json.Marshal(structured(v))
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// numbers are kept as written, e.g. large counters not in exponent notation
+	dec.UseNumber()
+	var obj any
+	if err := dec.Decode(&obj); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	found := false
+	for _, segment := range j.segments {
+		if !segment.expr {
+			buf.WriteString(segment.text)
+			continue
+		}
+		values := evalJSONPath(obj, segment.steps)
+		for i, value := range values {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			s, err := jsonPathValue(value)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(s)
+		}
+		found = found || len(values) > 0
+	}
+	if !found {
+		return ErrJSONPathNoResults
+	}
+	buf.WriteByte('\n')
+	_, err = j.w.Write(buf.Bytes())
+	return err
+}
+
+func evalJSONPath(obj any, steps []jsonPathStep) []any {
+	values := []any{obj}
+	for _, step := range steps {
+		var next []any
+		for _, value := range values {
+			switch value := value.(type) {
+			case map[string]any:
+				switch {
+				case step.wildcard:
+					keys := make([]string, 0, len(value))
+					for key := range value {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					for _, key := range keys {
+						next = append(next, value[key])
+					}
+				case !step.isIndex:
+					if field, ok := value[step.field]; ok {
+						next = append(next, field)
+					}
+				}
+			case []any:
+				switch {
+				case step.wildcard:
+					next = append(next, value...)
+				case step.isIndex:
+					index := step.index
+					if index < 0 {
+						index += len(value)
+					}
+					if index >= 0 && index < len(value) {
+						next = append(next, value[index])
+					}
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// jsonPathValue prints strings and numbers as is and other values as JSON.
+func jsonPathValue(value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case json.Number:
+		return value.String(), nil
+	default:
+		data, err := json.Marshal(value)
+		return string(data), err
+	}
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"bytes"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONPath", func() {
+	ipv4 := netip.MustParseAddr("10.0.0.1")
+	list := &api.InterfaceList{Items: []api.Interface{
+		{InterfaceMeta: api.InterfaceMeta{ID: "vm1"}, Spec: api.InterfaceSpec{VNI: 100, IPv4: &ipv4}},
+		{InterfaceMeta: api.InterfaceMeta{ID: "vm2"}, Spec: api.InterfaceSpec{VNI: 4294967295}},
+	}}
+
+	render := func(expr string, v any) (string, error) {
+		var buf bytes.Buffer
+		j, err := renderer.NewJSONPath(&buf, expr)
+		if err != nil {
+			return "", err
+		}
+		err = j.Render(v)
+		return buf.String(), err
+	}
+
+	It("should render the selected values separated by spaces", func() {
+		Expect(render("{.items[*].spec.vni}", list)).To(Equal("100 4294967295\n"))
+		Expect(render("{.items[-1].metadata.id}: {.items[0].spec['primary_ipv4']}", list)).To(Equal("vm2: 10.0.0.1\n"))
+		Expect(render(".items[0].metadata", list)).To(Equal(`{"id":"vm1"}` + "\n"))
+	})
+
+	It("should fail if no value matched", func() {
+		_, err := render("{.items[*].spec.missing}", list)
+		Expect(err).To(MatchError(renderer.ErrJSONPathNoResults))
+	})
+
+	It("should report invalid expressions", func() {
+		_, err := render("{.items[?(@.id)]}", list)
+		Expect(err).To(MatchError(ContainSubstring("unsupported subscript")))
+		_, err = render("{.items", list)
+		Expect(err).To(MatchError(ContainSubstring("unclosed {")))
+		_, err = render("{..id}", list)
+		Expect(err).To(MatchError(ContainSubstring("recursive descent")))
+	})
+})
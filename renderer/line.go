@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Line renders every table row as a single line of space-separated key=value pairs,
+// prefixed by the kind of the object. The keys are the lowercased table headers.
+type Line struct {
+	w              io.Writer
+	tableConverter TableConverter
+	resolver       *DNSResolver
+}
+
+func NewLine(w io.Writer, converter TableConverter) *Line {
+	return &Line{w: w, tableConverter: converter}
+}
+
+// NewResolvingLine is like NewLine, but annotates address values with their reverse-DNS names.
+func NewResolvingLine(w io.Writer, converter TableConverter, resolver *DNSResolver) *Line {
+	return &Line{w: w, tableConverter: converter, resolver: resolver}
+}
+
+func (l *Line) Render(v any) error {
+	data, err := l.tableConverter.ConvertToTable(v)
+	if err != nil {
+		return err
+	}
+	if l.resolver != nil {
+		l.resolver.annotateCells(data)
+	}
+
+	kind := ""
+	if o, ok := v.(interface{ GetKind() string }); ok {
+		kind = strings.ToLower(strings.TrimSuffix(o.GetKind(), "List"))
+	}
+
+	for _, row := range data.Columns {
+		var parts []string
+		if kind != "" {
+			parts = append(parts, kind)
+		}
+		for i, cell := range row {
+			if i >= len(data.Headers) {
+				break
+			}
+			key := strings.ToLower(fmt.Sprint(data.Headers[i]))
+			parts = append(parts, fmt.Sprintf("%s=%s", key, lineValue(cell)))
+		}
+		if _, err := fmt.Fprintln(l.w, strings.Join(parts, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lineValue formats cell, quoting it if it would not stay a single token.
+func lineValue(cell any) string {
+	if cell == nil {
+		return ""
+	}
+	s := formatCell(cell)
+	if s == "<nil>" {
+		return ""
+	}
+	if strings.ContainsFunc(s, func(r rune) bool { return unicode.IsSpace(r) || r == '"' || r == '=' }) {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"bytes"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Line", func() {
+	It("should render one line of key=value pairs per object", func() {
+		underlay := netip.MustParseAddr("fc00::1")
+		list := &api.PrefixList{
+			TypeMeta: api.TypeMeta{Kind: api.PrefixListKind},
+			Items: []api.Prefix{
+				{Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.0.0/24"), UnderlayRoute: &underlay}},
+				{Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.1.0/24")}},
+			},
+		}
+
+		var buf bytes.Buffer
+		Expect(renderer.NewLine(&buf, renderer.DefaultTableConverter).Render(list)).To(Succeed())
+		Expect(buf.String()).To(Equal("prefix prefix=10.0.0.0/24 underlayroute=fc00::1\n" +
+			"prefix prefix=10.0.1.0/24 underlayroute=\n"))
+	})
+})
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LoadBalancer table", func() {
+	It("should render ports in the syntax of --lbports", func() {
+		vip := netip.MustParseAddr("10.20.30.40")
+		data, err := renderer.DefaultTableConverter.ConvertToTable(&api.LoadBalancer{
+			LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"},
+			Spec: api.LoadBalancerSpec{
+				VNI:     100,
+				LbVipIP: &vip,
+				Lbports: []api.LBPort{{Protocol: conversion.ProtocolTCP, Port: 443}, {Protocol: conversion.ProtocolUDP, Port: 53}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data.Headers).To(Equal([]any{"ID", "VNI", "LbVipIP", "Lbports", "UnderlayRoute"}))
+		Expect(data.Columns).To(Equal([][]any{{"lb1", uint32(100), &vip, "TCP/443,UDP/53", ""}}))
+	})
+
+	It("should render the loadbalancer of targets", func() {
+		target := netip.MustParseAddr("ff80::1")
+		data, err := renderer.DefaultTableConverter.ConvertToTable(&api.LoadBalancerTargetList{Items: []api.LoadBalancerTarget{{
+			LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: "lb1"},
+			Spec:                   api.LoadBalancerTargetSpec{TargetIP: &target},
+		}}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data.Headers).To(Equal([]any{"LoadbalancerID", "IpVersion", "TargetIP"}))
+		Expect(data.Columns).To(Equal([][]any{{"lb1", "IPV6", &target}}))
+	})
+})
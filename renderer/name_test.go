@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"bytes"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Name", func() {
+	addr := func(s string) *netip.Addr {
+		a := netip.MustParseAddr(s)
+		return &a
+	}
+	prefix := func(s string) *netip.Prefix {
+		p := netip.MustParsePrefix(s)
+		return &p
+	}
+
+	DescribeTable("should name objects of a kind distinguishably",
+		func(a, b api.Object, expectedA string) {
+			var buf bytes.Buffer
+			Expect(renderer.NewName(&buf, "").Render(a)).To(Succeed())
+			Expect(buf.String()).To(Equal(expectedA + "\n"))
+
+			Expect(renderer.ObjectName(a)).NotTo(BeEmpty())
+			Expect(renderer.ObjectName(b)).NotTo(BeEmpty())
+			Expect(renderer.ObjectName(a)).NotTo(Equal(renderer.ObjectName(b)))
+		},
+		Entry("interface",
+			&api.Interface{TypeMeta: api.TypeMeta{Kind: api.InterfaceKind}, InterfaceMeta: api.InterfaceMeta{ID: "vm1"}},
+			&api.Interface{TypeMeta: api.TypeMeta{Kind: api.InterfaceKind}, InterfaceMeta: api.InterfaceMeta{ID: "vm2"}},
+			"interface/vm1"),
+		Entry("prefix",
+			&api.Prefix{TypeMeta: api.TypeMeta{Kind: api.PrefixKind}, Spec: api.PrefixSpec{Prefix: *prefix("10.0.1.0/24")}},
+			&api.Prefix{TypeMeta: api.TypeMeta{Kind: api.PrefixKind}, Spec: api.PrefixSpec{Prefix: *prefix("10.0.2.0/24")}},
+			"prefix/10.0.1.0/24"),
+		Entry("route",
+			&api.Route{TypeMeta: api.TypeMeta{Kind: api.RouteKind}, Spec: api.RouteSpec{Prefix: prefix("10.0.1.0/24"), NextHop: &api.RouteNextHop{VNI: 100, IP: addr("fc00::1")}}},
+			&api.Route{TypeMeta: api.TypeMeta{Kind: api.RouteKind}, Spec: api.RouteSpec{Prefix: prefix("10.0.1.0/24"), NextHop: &api.RouteNextHop{VNI: 100, IP: addr("fc00::2")}}},
+			"route/10.0.1.0/24-100:fc00::1"),
+		Entry("route without next hop",
+			&api.Route{TypeMeta: api.TypeMeta{Kind: api.RouteKind}, Spec: api.RouteSpec{Prefix: prefix("10.0.1.0/24")}},
+			&api.Route{TypeMeta: api.TypeMeta{Kind: api.RouteKind}, Spec: api.RouteSpec{Prefix: prefix("10.0.2.0/24")}},
+			"route/10.0.1.0/24"),
+		Entry("virtual ip",
+			&api.VirtualIP{TypeMeta: api.TypeMeta{Kind: api.VirtualIPKind}, VirtualIPMeta: api.VirtualIPMeta{InterfaceID: "vm1"}, Spec: api.VirtualIPSpec{IP: addr("20.0.0.1")}},
+			&api.VirtualIP{TypeMeta: api.TypeMeta{Kind: api.VirtualIPKind}, VirtualIPMeta: api.VirtualIPMeta{InterfaceID: "vm2"}, Spec: api.VirtualIPSpec{IP: addr("20.0.0.2")}},
+			"virtualip/20.0.0.1"),
+		Entry("loadbalancer",
+			&api.LoadBalancer{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerKind}, LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb1"}},
+			&api.LoadBalancer{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerKind}, LoadBalancerMeta: api.LoadBalancerMeta{ID: "lb2"}},
+			"loadbalancer/lb1"),
+		Entry("loadbalancer target",
+			&api.LoadBalancerTarget{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerTargetKind}, LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: "lb1"}, Spec: api.LoadBalancerTargetSpec{TargetIP: addr("fc00::1")}},
+			&api.LoadBalancerTarget{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerTargetKind}, LoadBalancerTargetMeta: api.LoadBalancerTargetMeta{LoadbalancerID: "lb1"}, Spec: api.LoadBalancerTargetSpec{TargetIP: addr("fc00::2")}},
+			"loadbalancertarget/lb1/fc00::1"),
+		Entry("loadbalancer prefix",
+			&api.LoadBalancerPrefix{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerPrefixKind}, Spec: api.LoadBalancerPrefixSpec{Prefix: *prefix("10.0.1.0/24")}},
+			&api.LoadBalancerPrefix{TypeMeta: api.TypeMeta{Kind: api.LoadBalancerPrefixKind}, Spec: api.LoadBalancerPrefixSpec{Prefix: *prefix("10.0.2.0/24")}},
+			"loadbalancerprefix/10.0.1.0/24"),
+		Entry("nat",
+			&api.Nat{TypeMeta: api.TypeMeta{Kind: api.NatKind}, Spec: api.NatSpec{NatIP: addr("20.0.0.1"), MinPort: 1000, MaxPort: 2000}},
+			&api.Nat{TypeMeta: api.TypeMeta{Kind: api.NatKind}, Spec: api.NatSpec{NatIP: addr("20.0.0.1"), MinPort: 2000, MaxPort: 3000}},
+			"nat/20.0.0.1:1000-2000"),
+		Entry("neighbor nat",
+			&api.NeighborNat{TypeMeta: api.TypeMeta{Kind: api.NeighborNatKind}, NeighborNatMeta: api.NeighborNatMeta{NatIP: addr("20.0.0.1")}, Spec: api.NeighborNatSpec{MinPort: 1000, MaxPort: 2000}},
+			&api.NeighborNat{TypeMeta: api.TypeMeta{Kind: api.NeighborNatKind}, NeighborNatMeta: api.NeighborNatMeta{NatIP: addr("20.0.0.1")}, Spec: api.NeighborNatSpec{MinPort: 2000, MaxPort: 3000}},
+			"neighbornat/20.0.0.1:1000-2000"),
+		Entry("firewall rule",
+			&api.FirewallRule{TypeMeta: api.TypeMeta{Kind: api.FirewallRuleKind}, FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: "vm1"}, Spec: api.FirewallRuleSpec{RuleID: "rule1"}},
+			&api.FirewallRule{TypeMeta: api.TypeMeta{Kind: api.FirewallRuleKind}, FirewallRuleMeta: api.FirewallRuleMeta{InterfaceID: "vm1"}, Spec: api.FirewallRuleSpec{RuleID: "rule2"}},
+			"firewallrule/vm1/rule1"),
+	)
+})
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"bytes"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NAT table", func() {
+	It("should render a nat and a list of nats", func() {
+		natIP := netip.MustParseAddr("10.20.30.40")
+		underlayRoute := netip.MustParseAddr("fc00::1")
+
+		data, err := renderer.DefaultTableConverter.ConvertToTable(&api.Nat{
+			NatMeta: api.NatMeta{InterfaceID: "vm1"},
+			Spec:    api.NatSpec{NatIP: &natIP, MinPort: 30000, MaxPort: 30100, UnderlayRoute: &underlayRoute},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data.Headers).To(Equal([]any{"InterfaceID", "IP", "MinPort", "MaxPort", "UnderlayRoute"}))
+		Expect(data.Columns).To(Equal([][]any{{"vm1", &natIP, uint32(30000), uint32(30100), &underlayRoute}}))
+
+		data, err = renderer.DefaultTableConverter.ConvertToTable(&api.NatList{Items: []api.Nat{
+			{Spec: api.NatSpec{NatIP: &natIP, MinPort: 30000, MaxPort: 30100, Vni: 100}},
+		}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data.Headers).To(Equal([]any{"VNI", "IP", "MinPort", "MaxPort", "UnderlayRoute", "NatType"}))
+		Expect(data.Columns).To(Equal([][]any{{uint32(100), &natIP, uint32(30000), uint32(30100), "", "Local"}}))
+	})
+
+	It("should render missing addresses as empty cells", func() {
+		var buf bytes.Buffer
+		Expect(renderer.NewTable(&buf, renderer.DefaultTableConverter).Render(&api.Nat{NatMeta: api.NatMeta{InterfaceID: "vm1"}})).To(Succeed())
+		Expect(buf.String()).NotTo(ContainSubstring("<nil>"))
+	})
+})
@@ -5,54 +5,107 @@ package renderer
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/netip"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/ghodss/yaml"
+	"github.com/ironcore-dev/dpservice-cli/dpdk/conversion"
 	"github.com/ironcore-dev/dpservice-go/api"
 	dpdkproto "github.com/ironcore-dev/dpservice-go/proto"
 	"github.com/jedib0t/go-pretty/v6/table"
+	yaml3 "gopkg.in/yaml.v3"
 )
 
 type Renderer interface {
 	Render(v any) error
 }
 
+// DefaultIndent is the indentation used by the JSON (pretty) and YAML renderers.
+const DefaultIndent = "  "
+
 type JSON struct {
 	w      io.Writer
 	pretty bool
+	indent string
 }
 
 func NewJSON(w io.Writer, pretty bool) *JSON {
-	return &JSON{w, pretty}
+	return NewIndentedJSON(w, pretty, DefaultIndent)
+}
+
+// NewIndentedJSON returns a JSON renderer that uses indent in pretty mode.
+func NewIndentedJSON(w io.Writer, pretty bool, indent string) *JSON {
+	return &JSON{w, pretty, indent}
 }
 
 func (j *JSON) Render(v any) error {
 	enc := json.NewEncoder(j.w)
 	if j.pretty {
-		enc.SetIndent("", "  ")
+		enc.SetIndent("", j.indent)
+	}
+	return enc.Encode(structured(v))
+}
+
+// structured returns the representation of v in json and yaml output, which names
+// loadbalancer port protocols in addition to their number and renders the items of
+// empty lists as an empty array instead of null.
+func structured(v any) any {
+	if lb, ok := v.(*api.LoadBalancer); ok {
+		return conversion.NewLoadBalancerJSON(lb)
 	}
-	return enc.Encode(v)
+	if list, ok := v.(api.List); ok && len(list.GetItems()) == 0 {
+		return withEmptyItems(list)
+	}
+	return v
+}
+
+// withEmptyItems returns a copy of list whose nil Items are an empty slice.
+func withEmptyItems(list api.List) any {
+	rv := reflect.ValueOf(list)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return list
+	}
+	c := reflect.New(rv.Elem().Type())
+	c.Elem().Set(rv.Elem())
+	items := c.Elem().FieldByName("Items")
+	if !items.IsValid() || items.Kind() != reflect.Slice || !items.IsNil() {
+		return list
+	}
+	items.Set(reflect.MakeSlice(items.Type(), 0, 0))
+	return c.Interface()
 }
 
 type YAML struct {
-	w io.Writer
+	w      io.Writer
+	indent int
 }
 
 func NewYAML(w io.Writer) *YAML {
-	return &YAML{w}
+	return &YAML{w, len(DefaultIndent)}
+}
+
+// NewIndentedYAML returns a YAML renderer that indents by the given number of spaces.
+func NewIndentedYAML(w io.Writer, indent int) *YAML {
+	return &YAML{w, indent}
 }
 
 func (y *YAML) Render(v any) error {
-	jsonData, err := json.Marshal(v)
+	jsonData, err := json.Marshal(structured(v))
 	if err != nil {
 		return err
 	}
 
+	if y.indent != len(DefaultIndent) {
+		return y.renderIndented(jsonData)
+	}
+
 	data, err := yaml.JSONToYAML(jsonData)
 	if err != nil {
 		return err
@@ -62,9 +115,48 @@ func (y *YAML) Render(v any) error {
 	return err
 }
 
+// renderIndented re-encodes the JSON document with a custom indentation, which the
+// default JSON to YAML conversion does not support.
+func (y *YAML) renderIndented(jsonData []byte) error {
+	var node yaml3.Node
+	if err := yaml3.Unmarshal(jsonData, &node); err != nil {
+		return err
+	}
+	clearStyle(&node)
+
+	enc := yaml3.NewEncoder(y.w)
+	enc.SetIndent(y.indent)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// clearStyle resets the flow and quoting styles taken over from JSON to block style
+// and sorts mapping keys, as the default conversion does.
+func clearStyle(node *yaml3.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+
+	if node.Kind != yaml3.MappingNode {
+		return
+	}
+	pairs := make([][2]*yaml3.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml3.Node{node.Content[i], node.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i][0].Value < pairs[j][0].Value })
+	for i, pair := range pairs {
+		node.Content[2*i], node.Content[2*i+1] = pair[0], pair[1]
+	}
+}
+
 type Name struct {
 	w         io.Writer
 	operation string
+	resolver  *DNSResolver
 }
 
 func NewName(w io.Writer, operation string) *Name {
@@ -74,6 +166,15 @@ func NewName(w io.Writer, operation string) *Name {
 	}
 }
 
+// NewResolvingName is like NewName, but annotates addresses in names and the operation with their reverse-DNS names.
+func NewResolvingName(w io.Writer, operation string, resolver *DNSResolver) *Name {
+	return &Name{
+		w:         w,
+		operation: resolver.AnnotateText(operation),
+		resolver:  resolver,
+	}
+}
+
 func (n *Name) Render(v any) error {
 	objs, err := getObjs(v)
 	if err != nil {
@@ -92,11 +193,16 @@ func (n *Name) Render(v any) error {
 }
 
 func (n *Name) renderObject(obj api.Object) error {
+	name := ObjectName(obj)
+	if n.resolver != nil {
+		name = n.resolver.AnnotateText(name)
+	}
+
 	var parts []string
 	if kind := obj.GetKind(); kind != "" {
-		parts = append(parts, fmt.Sprintf("%s/%s", strings.ToLower(kind), obj.GetName()))
+		parts = append(parts, fmt.Sprintf("%s/%s", strings.ToLower(kind), name))
 	} else {
-		parts = append(parts, obj.GetName())
+		parts = append(parts, name)
 	}
 
 	if n.operation != "" {
@@ -107,6 +213,49 @@ func (n *Name) renderObject(obj api.Object) error {
 	return err
 }
 
+// ObjectName returns an identifier for obj that tells it apart from other objects of the
+// same kind. The GetName of some kinds is not usable for that, e.g. all targets of a
+// loadbalancer share the same name, so those kinds are named here.
+func ObjectName(obj api.Object) string {
+	switch obj := obj.(type) {
+	case *api.Route:
+		name := addrOrPrefixName(obj.Spec.Prefix)
+		if obj.Spec.NextHop != nil {
+			name = fmt.Sprintf("%s-%d", name, obj.Spec.NextHop.VNI)
+			if obj.Spec.NextHop.IP != nil {
+				name = fmt.Sprintf("%s:%s", name, obj.Spec.NextHop.IP)
+			}
+		}
+		return name
+	case *api.VirtualIP:
+		if obj.Spec.IP != nil {
+			return obj.Spec.IP.String()
+		}
+		return obj.InterfaceID
+	case *api.LoadBalancerTarget:
+		return fmt.Sprintf("%s/%s", obj.LoadbalancerID, addrOrPrefixName(obj.Spec.TargetIP))
+	case *api.Nat:
+		if obj.Spec.NatIP == nil {
+			return obj.InterfaceID
+		}
+		return fmt.Sprintf("%s:%d-%d", obj.Spec.NatIP, obj.Spec.MinPort, obj.Spec.MaxPort)
+	case *api.NeighborNat:
+		return fmt.Sprintf("%s:%d-%d", addrOrPrefixName(obj.NatIP), obj.Spec.MinPort, obj.Spec.MaxPort)
+	default:
+		return obj.GetName()
+	}
+}
+
+func addrOrPrefixName[T interface {
+	*netip.Addr | *netip.Prefix
+	String() string
+}](v T) string {
+	if v == nil {
+		return "<none>"
+	}
+	return v.String()
+}
+
 func (n *Name) renderList(list any) error {
 	var parts []string
 
@@ -136,10 +285,22 @@ func getObjs(v any) ([]api.Object, error) {
 type Table struct {
 	w              io.Writer
 	tableConverter TableConverter
+	resolver       *DNSResolver
+	noHeaders      bool
 }
 
 func NewTable(w io.Writer, converter TableConverter) *Table {
-	return &Table{w, converter}
+	return &Table{w: w, tableConverter: converter}
+}
+
+// NewResolvingTable is like NewTable, but annotates address cells with their reverse-DNS names.
+func NewResolvingTable(w io.Writer, converter TableConverter, resolver *DNSResolver) *Table {
+	return &Table{w: w, tableConverter: converter, resolver: resolver}
+}
+
+// SetNoHeaders makes the table render only its rows, without the header row.
+func (t *Table) SetNoHeaders(noHeaders bool) {
+	t.noHeaders = noHeaders
 }
 
 type TableData struct {
@@ -153,12 +314,18 @@ type TableConverter interface {
 
 type defaultTableConverter struct {
 	Wide bool
+	// PreferIPv6 shows IPv6 columns before IPv4 columns.
+	PreferIPv6 bool
 }
 
 func (t *defaultTableConverter) SetWide(wide bool) {
 	t.Wide = wide
 }
 
+func (t *defaultTableConverter) SetPreferIPv6(preferIPv6 bool) {
+	t.PreferIPv6 = preferIPv6
+}
+
 var DefaultTableConverter = defaultTableConverter{}
 
 func (t defaultTableConverter) ConvertToTable(v any) (*TableData, error) {
@@ -173,6 +340,12 @@ func (t defaultTableConverter) ConvertToTable(v any) (*TableData, error) {
 		return t.interfaceTable([]api.Interface{*obj})
 	case *api.InterfaceList:
 		return t.interfaceTable(obj.Items)
+	case *AuditedInterfaceList:
+		return t.auditedInterfaceTable(obj.Items)
+	case *InterfaceFirewallRuleCountList:
+		return t.interfaceFirewallRuleCountTable(obj.Items)
+	case *DanglingRouteList:
+		return t.danglingRouteTable(obj.Items)
 	case *api.Prefix:
 		return t.prefixTable([]api.Prefix{*obj})
 	case *api.PrefixList:
@@ -199,6 +372,8 @@ func (t defaultTableConverter) ConvertToTable(v any) (*TableData, error) {
 		return t.vniTable(*obj)
 	case *api.Version:
 		return t.versionTable(*obj)
+	case *Versions:
+		return t.versionsTable(*obj)
 	case *api.CaptureStart:
 		return t.captureStartTable(*obj)
 	case *api.CaptureStop:
@@ -214,13 +389,7 @@ func (t defaultTableConverter) loadBalancerTable(lb api.LoadBalancer) (*TableDat
 	headers := []any{"ID", "VNI", "LbVipIP", "Lbports", "UnderlayRoute"}
 
 	columns := make([][]any, 1)
-
-	var ports = make([]string, 0, len(lb.Spec.Lbports))
-	for _, port := range lb.Spec.Lbports {
-		p := dpdkproto.Protocol_name[int32(port.Protocol)] + "/" + strconv.Itoa(int(port.Port))
-		ports = append(ports, p)
-	}
-	columns[0] = []any{lb.ID, lb.Spec.VNI, lb.Spec.LbVipIP, ports, lb.Spec.UnderlayRoute}
+	columns[0] = []any{lb.ID, lb.Spec.VNI, addrCell(lb.Spec.LbVipIP), conversion.FormatLBPorts(lb.Spec.Lbports), addrCell(lb.Spec.UnderlayRoute)}
 
 	return &TableData{
 		Headers: headers,
@@ -229,12 +398,13 @@ func (t defaultTableConverter) loadBalancerTable(lb api.LoadBalancer) (*TableDat
 }
 
 func (t defaultTableConverter) loadBalancerTargetTable(lbtargets []api.LoadBalancerTarget) (*TableData, error) {
-	headers := []any{"IpVersion", "Address"}
+	headers := []any{"LoadbalancerID", "IpVersion", "TargetIP"}
 
 	columns := make([][]any, len(lbtargets))
 	for i, lbtarget := range lbtargets {
 		columns[i] = []any{
-			api.NetIPAddrToProtoIPVersion(lbtarget.Spec.TargetIP),
+			lbtarget.LoadbalancerID,
+			conversion.IPVersions.String(api.NetIPAddrToProtoIPVersion(lbtarget.Spec.TargetIP)),
 			lbtarget.Spec.TargetIP,
 		}
 	}
@@ -247,6 +417,9 @@ func (t defaultTableConverter) loadBalancerTargetTable(lbtargets []api.LoadBalan
 
 func (t defaultTableConverter) interfaceTable(ifaces []api.Interface) (*TableData, error) {
 	headers := []any{"ID", "VNI", "Device", "IPv4", "IPv6", "UnderlayRoute", "TotalMeterRate", "PublicMeterRate"}
+	if t.PreferIPv6 {
+		headers[3], headers[4] = headers[4], headers[3]
+	}
 	vfNeeded := isColumnNeeded(ifaces, "Spec.VirtualFunction")
 	if vfNeeded {
 		headers = append(headers, "VirtualFunction")
@@ -259,10 +432,16 @@ func (t defaultTableConverter) interfaceTable(ifaces []api.Interface) (*TableDat
 	if t.Wide && vipNeeded {
 		headers = append(headers, "VirtualIP")
 	}
+	if t.Wide {
+		headers = append(headers, "PxeServer", "PxeFileName")
+	}
 
 	columns := make([][]any, len(ifaces))
 	for i, iface := range ifaces {
 		columns[i] = []any{iface.ID, iface.Spec.VNI, iface.Spec.Device, iface.Spec.IPv4, iface.Spec.IPv6, iface.Spec.UnderlayRoute, iface.Spec.Metering.TotalRate, iface.Spec.Metering.PublicRate}
+		if t.PreferIPv6 {
+			columns[i][3], columns[i][4] = columns[i][4], columns[i][3]
+		}
 		if iface.Spec.VirtualFunction != nil {
 			columns[i] = append(columns[i], iface.Spec.VirtualFunction.Name)
 		} else if vfNeeded {
@@ -278,6 +457,13 @@ func (t defaultTableConverter) interfaceTable(ifaces []api.Interface) (*TableDat
 		} else if vipNeeded {
 			columns[i] = append(columns[i], "")
 		}
+		if t.Wide {
+			pxe := iface.Spec.PXE
+			if pxe == nil {
+				pxe = &api.PXE{}
+			}
+			columns[i] = append(columns[i], pxe.Server, pxe.FileName)
+		}
 	}
 
 	return &TableData{
@@ -328,6 +514,14 @@ func (t defaultTableConverter) virtualIPTable(virtualIPs []api.VirtualIP) (*Tabl
 	}, nil
 }
 
+// addrCell returns addr as a cell that stays empty instead of showing <nil> for a missing address.
+func addrCell(addr *netip.Addr) any {
+	if addr == nil {
+		return ""
+	}
+	return addr
+}
+
 func (t defaultTableConverter) natTable(nats []api.Nat) (*TableData, error) {
 	var headers []any
 	// if command was get nat or there are no nats
@@ -342,10 +536,10 @@ func (t defaultTableConverter) natTable(nats []api.Nat) (*TableData, error) {
 	for i, nat := range nats {
 		// if command was get nat or there are no nats
 		if len(nats) > 0 && nats[0].InterfaceID != "" {
-			columns[i] = []any{nat.NatMeta.InterfaceID, nat.Spec.NatIP, nat.Spec.MinPort, nat.Spec.MaxPort, nat.Spec.UnderlayRoute}
+			columns[i] = []any{nat.NatMeta.InterfaceID, addrCell(nat.Spec.NatIP), nat.Spec.MinPort, nat.Spec.MaxPort, addrCell(nat.Spec.UnderlayRoute)}
 			// if command was list nats
 		} else {
-			columns[i] = []any{nat.Spec.Vni, nat.Spec.NatIP, nat.Spec.MinPort, nat.Spec.MaxPort, nat.Spec.UnderlayRoute}
+			columns[i] = []any{nat.Spec.Vni, addrCell(nat.Spec.NatIP), nat.Spec.MinPort, nat.Spec.MaxPort, addrCell(nat.Spec.UnderlayRoute)}
 			if len(nats) > 0 && nats[i].Spec.UnderlayRoute == nil {
 				columns[i] = append(columns[i], "Local")
 			} else {
@@ -448,7 +642,7 @@ func (t defaultTableConverter) captureStartTable(captureStart api.CaptureStart)
 
 	for _, iface := range captureStart.Spec.Interfaces {
 
-		if iface.InterfaceType == "pf" {
+		if isCapturedPF(iface.InterfaceType) {
 			pfInterfaces += iface.InterfaceInfo + " "
 		} else {
 			vfInterfaces += iface.InterfaceInfo + " "
@@ -486,7 +680,7 @@ func (t defaultTableConverter) captureStatusTable(captureStatus api.CaptureStatu
 
 	for _, iface := range captureStatus.Spec.Interfaces {
 
-		if iface.InterfaceType == "pf" {
+		if isCapturedPF(iface.InterfaceType) {
 			pfInterfaces += iface.InterfaceInfo + " "
 		} else {
 			vfInterfaces += iface.InterfaceInfo + " "
@@ -507,6 +701,12 @@ func (t defaultTableConverter) captureStatusTable(captureStatus api.CaptureStatu
 	}, nil
 }
 
+// isCapturedPF reports whether the interface type of a captured interface is a PF.
+func isCapturedPF(interfaceType string) bool {
+	captureType, err := conversion.CaptureInterfaceTypes.Parse(interfaceType)
+	return err == nil && captureType == dpdkproto.CaptureInterfaceType_SINGLE_PF
+}
+
 var (
 	lightBoxStyle = table.BoxStyle{
 		BottomLeft:       "",
@@ -536,29 +736,127 @@ func (t *Table) Render(v any) error {
 	if err != nil {
 		return err
 	}
+	if t.resolver != nil {
+		t.resolver.annotateCells(data)
+	}
 
 	tw := table.NewWriter()
 	tw.SetStyle(tableStyle)
-	tw.SetOutputMirror(t.w)
 
-	tw.AppendHeader(data.Headers)
+	configs := make([]table.ColumnConfig, len(data.Headers))
+	for i := range configs {
+		configs[i] = table.ColumnConfig{Number: i + 1, Transformer: formatCell}
+	}
+	tw.SetColumnConfigs(configs)
+
+	if !t.noHeaders {
+		tw.AppendHeader(data.Headers)
+	}
 	for _, col := range data.Columns {
 		tw.AppendRow(col)
 	}
 
-	tw.Render()
+	// written here instead of through an output mirror, which ignores write errors
+	if out := tw.Render(); out != "" {
+		if _, err := fmt.Fprintln(t.w, out); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// CSV renders the table of an object as comma-separated values, with a header row.
+type CSV struct {
+	w              io.Writer
+	tableConverter TableConverter
+}
+
+func NewCSV(w io.Writer, converter TableConverter) *CSV {
+	return &CSV{w: w, tableConverter: converter}
+}
+
+func (c *CSV) Render(v any) error {
+	data, err := c.tableConverter.ConvertToTable(v)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(c.w)
+	record := make([]string, len(data.Headers))
+	for i, header := range data.Headers {
+		record[i] = fmt.Sprint(header)
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for _, row := range data.Columns {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			record[i] = csvValue(cell)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvValue formats cell like the table renderer, leaving missing values empty.
+func csvValue(cell any) string {
+	if cell == nil {
+		return ""
+	}
+	if s := formatCell(cell); s != "<nil>" {
+		return s
+	}
+	return ""
+}
+
+// formatCell formats numbers as plain digits, without grouping or exponent, so that
+// numeric columns stay parseable.
+func formatCell(cell any) string {
+	switch v := cell.(type) {
+	case int:
+		return strconv.FormatInt(int64(v), 10)
+	case int8:
+		return strconv.FormatInt(int64(v), 10)
+	case int16:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(cell)
+	}
+}
+
 type NewFunc func(w io.Writer) Renderer
 
 type Registry struct {
 	newFuncByName map[string]NewFunc
+	nameByAlias   map[string]string
 }
 
 func NewRegistry() *Registry {
 	return &Registry{
 		newFuncByName: make(map[string]NewFunc),
+		nameByAlias:   make(map[string]string),
 	}
 }
 
@@ -571,15 +869,61 @@ func (r *Registry) Register(name string, newFunc NewFunc) error {
 	return nil
 }
 
+// RegisterAlias makes the renderer registered as name also available as alias.
+func (r *Registry) RegisterAlias(alias, name string) error {
+	if _, ok := r.newFuncByName[name]; !ok {
+		return fmt.Errorf("cannot alias unknown renderer %q", name)
+	}
+	if _, ok := r.newFuncByName[alias]; ok {
+		return fmt.Errorf("alias %q collides with a registered renderer", alias)
+	}
+	if existing, ok := r.nameByAlias[alias]; ok {
+		return fmt.Errorf("alias %q is already registered for renderer %q", alias, existing)
+	}
+
+	r.nameByAlias[alias] = name
+	return nil
+}
+
 func (r *Registry) New(name string, w io.Writer) (Renderer, error) {
+	if canonical, ok := r.nameByAlias[name]; ok {
+		name = canonical
+	}
+
 	newFunc, ok := r.newFuncByName[name]
 	if !ok {
-		return nil, fmt.Errorf("unknown renderer %q", name)
+		return nil, fmt.Errorf("unknown renderer %q, available renderers: %s", name, r.describe())
 	}
 
 	return newFunc(w), nil
 }
 
+// describe lists the registered renderers with their aliases, e.g. "json (j), yaml (y, yml)".
+func (r *Registry) describe() string {
+	aliasesByName := make(map[string][]string)
+	for alias, name := range r.nameByAlias {
+		aliasesByName[name] = append(aliasesByName[name], alias)
+	}
+
+	names := make([]string, 0, len(r.newFuncByName))
+	for name := range r.newFuncByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		aliases := aliasesByName[name]
+		if len(aliases) == 0 {
+			parts[i] = name
+			continue
+		}
+		sort.Strings(aliases)
+		parts[i] = fmt.Sprintf("%s (%s)", name, strings.Join(aliases, ", "))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // iterate over objects to check if it has any non nil value in given field
 func isColumnNeeded(objs interface{}, field string) bool {
 	fields := strings.Split(field, ".")
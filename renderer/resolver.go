@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-cli/util"
+)
+
+// DefaultLookupTimeout is the time a single reverse-DNS lookup may take before the address is shown without a name.
+const DefaultLookupTimeout = 500 * time.Millisecond
+
+// maxConcurrentLookups bounds the number of reverse-DNS lookups running at once.
+const maxConcurrentLookups = 16
+
+// DNSResolver annotates addresses with their reverse-DNS names. Up to maxConcurrentLookups
+// lookups run concurrently and the results, including failed lookups, are cached for the lifetime of the resolver.
+type DNSResolver struct {
+	timeout time.Duration
+	lookup  func(ctx context.Context, addr string) ([]string, error)
+
+	mu    sync.Mutex
+	names map[netip.Addr]string
+}
+
+func NewDNSResolver(timeout time.Duration) *DNSResolver {
+	return NewDNSResolverWithLookup(timeout, net.DefaultResolver.LookupAddr)
+}
+
+func NewDNSResolverWithLookup(timeout time.Duration, lookup func(ctx context.Context, addr string) ([]string, error)) *DNSResolver {
+	return &DNSResolver{
+		timeout: timeout,
+		lookup:  lookup,
+		names:   make(map[netip.Addr]string),
+	}
+}
+
+// Resolve looks up all addresses that are not cached yet and waits until every lookup finished or timed out.
+func (r *DNSResolver) Resolve(addrs []netip.Addr) {
+	r.mu.Lock()
+	seen := make(map[netip.Addr]struct{})
+	var pending []netip.Addr
+	for _, addr := range addrs {
+		if _, ok := seen[addr]; ok {
+			continue
+		}
+		seen[addr] = struct{}{}
+		if _, ok := r.names[addr]; !ok && addr.IsValid() {
+			pending = append(pending, addr)
+		}
+	}
+	r.mu.Unlock()
+
+	_ = util.ForEachBounded(len(pending), maxConcurrentLookups, func(i int) error {
+		ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+		defer cancel()
+
+		var name string
+		if names, err := r.lookup(ctx, pending[i].String()); err == nil && len(names) > 0 {
+			name = strings.TrimSuffix(names[0], ".")
+		}
+		r.mu.Lock()
+		r.names[pending[i]] = name
+		r.mu.Unlock()
+		return nil
+	})
+}
+
+// Annotate returns addr followed by its reverse-DNS name in parentheses, if Resolve found one.
+func (r *DNSResolver) Annotate(addr netip.Addr) string {
+	r.mu.Lock()
+	name := r.names[addr]
+	r.mu.Unlock()
+	if name == "" {
+		return addr.String()
+	}
+	return fmt.Sprintf("%s (%s)", addr, name)
+}
+
+// AnnotateText annotates all whitespace separated addresses in s, e.g. in an operation message.
+func (r *DNSResolver) AnnotateText(s string) string {
+	fields := strings.Fields(s)
+	var addrs []netip.Addr
+	for _, field := range fields {
+		if addr, err := netip.ParseAddr(strings.TrimRight(field, ",")); err == nil {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return s
+	}
+	r.Resolve(addrs)
+
+	for i, field := range fields {
+		trimmed := strings.TrimRight(field, ",")
+		if addr, err := netip.ParseAddr(trimmed); err == nil {
+			fields[i] = r.Annotate(addr) + field[len(trimmed):]
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// annotateCells replaces all address cells of data with their annotated form.
+func (r *DNSResolver) annotateCells(data *TableData) {
+	cellAddr := func(cell any) (netip.Addr, bool) {
+		switch v := cell.(type) {
+		case netip.Addr:
+			return v, v.IsValid()
+		case *netip.Addr:
+			if v != nil && v.IsValid() {
+				return *v, true
+			}
+		}
+		return netip.Addr{}, false
+	}
+
+	var addrs []netip.Addr
+	for _, row := range data.Columns {
+		for _, cell := range row {
+			if addr, ok := cellAddr(cell); ok {
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+	r.Resolve(addrs)
+
+	for _, row := range data.Columns {
+		for i, cell := range row {
+			if addr, ok := cellAddr(cell); ok {
+				row[i] = r.Annotate(addr)
+			}
+		}
+	}
+}
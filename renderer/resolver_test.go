@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/netip"
+	"sync/atomic"
+	"time"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DNSResolver", func() {
+	var (
+		lookups  atomic.Int32
+		resolver *renderer.DNSResolver
+	)
+
+	BeforeEach(func() {
+		lookups.Store(0)
+		resolver = renderer.NewDNSResolverWithLookup(50*time.Millisecond, func(ctx context.Context, addr string) ([]string, error) {
+			lookups.Add(1)
+			switch addr {
+			case "fc00::1":
+				return []string{"node1.example.com."}, nil
+			case "fc00::2":
+				<-ctx.Done()
+				return nil, ctx.Err()
+			default:
+				return nil, errors.New("not found")
+			}
+		})
+	})
+
+	It("should annotate resolvable addresses and cache lookups", func() {
+		addrs := []netip.Addr{netip.MustParseAddr("fc00::1"), netip.MustParseAddr("fc00::2"), netip.MustParseAddr("fc00::3")}
+		resolver.Resolve(addrs)
+		resolver.Resolve(addrs)
+
+		Expect(lookups.Load()).To(Equal(int32(3)))
+		Expect(resolver.Annotate(addrs[0])).To(Equal("fc00::1 (node1.example.com)"))
+		Expect(resolver.Annotate(addrs[1])).To(Equal("fc00::2"))
+		Expect(resolver.Annotate(addrs[2])).To(Equal("fc00::3"))
+	})
+
+	It("should bound the number of concurrent lookups", func() {
+		var running, maxRunning atomic.Int32
+		resolver := renderer.NewDNSResolverWithLookup(time.Second, func(ctx context.Context, addr string) ([]string, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return nil, errors.New("not found")
+		})
+
+		addrs := make([]netip.Addr, 100)
+		for i := range addrs {
+			addrs[i] = netip.AddrFrom4([4]byte{10, 0, 0, byte(i)})
+		}
+		resolver.Resolve(addrs)
+		Expect(maxRunning.Load()).To(BeNumerically("<=", 16))
+		Expect(resolver.Annotate(addrs[99])).To(Equal("10.0.0.99"))
+	})
+
+	It("should annotate addresses in text", func() {
+		Expect(resolver.AnnotateText("created, underlay route: fc00::1, targets: 2")).
+			To(Equal("created, underlay route: fc00::1 (node1.example.com), targets: 2"))
+	})
+
+	It("should annotate address cells in tables", func() {
+		var buf bytes.Buffer
+		underlay := netip.MustParseAddr("fc00::1")
+		prefix := &api.Prefix{
+			TypeMeta: api.TypeMeta{Kind: api.PrefixKind},
+			Spec:     api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.0.0/24"), UnderlayRoute: &underlay},
+		}
+
+		Expect(renderer.NewResolvingTable(&buf, renderer.DefaultTableConverter, resolver).Render(prefix)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("10.0.0.0/24  fc00::1 (node1.example.com)"))
+	})
+})
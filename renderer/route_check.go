@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+const DanglingRouteListKind = "DanglingRouteList"
+
+// DanglingRoute is a route whose next hop does not resolve to a known interface.
+type DanglingRoute struct {
+	api.Route `json:",inline"`
+	Problem   string `json:"problem"`
+}
+
+type DanglingRouteList struct {
+	api.TypeMeta `json:",inline"`
+	Items        []DanglingRoute `json:"items"`
+	Status       api.Status      `json:"status"`
+}
+
+func (l *DanglingRouteList) GetItems() []api.Object {
+	res := make([]api.Object, len(l.Items))
+	for i := range l.Items {
+		res[i] = &l.Items[i]
+	}
+	return res
+}
+
+func (l *DanglingRouteList) GetStatus() api.Status {
+	return l.Status
+}
+
+func (t defaultTableConverter) danglingRouteTable(routes []DanglingRoute) (*TableData, error) {
+	plain := make([]api.Route, len(routes))
+	for i, route := range routes {
+		plain[i] = route.Route
+	}
+
+	data, err := t.routeTable(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Headers = append(data.Headers, "Problem")
+	for i, route := range routes {
+		data.Columns[i] = append(data.Columns[i], route.Problem)
+	}
+	return data, nil
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SortOrder returns the indices of the rows of data, stably sorted by the column with the
+// header name. Addresses and prefixes are sorted in address order with IPv4 before IPv6,
+// or IPv6 first if preferIPv6 is set, numbers numerically and other values as strings.
+// Empty cells come last.
+func SortOrder(data *TableData, name string, preferIPv6 bool) ([]int, error) {
+	index, err := columnIndex(data, name)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]sortKey, len(data.Columns))
+	order := make([]int, len(data.Columns))
+	for i, row := range data.Columns {
+		order[i] = i
+		if index < len(row) {
+			keys[i] = newSortKey(row[index])
+		} else {
+			keys[i] = newSortKey(nil)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return keys[order[i]].compare(keys[order[j]], preferIPv6) < 0
+	})
+	return order, nil
+}
+
+type sortKind int
+
+const (
+	sortKindAddr sortKind = iota
+	sortKindNumber
+	sortKindString
+	sortKindEmpty
+)
+
+type sortKey struct {
+	kind sortKind
+	addr netip.Addr
+	bits int
+	num  float64
+	str  string
+}
+
+func newSortKey(cell any) sortKey {
+	s := strings.TrimSpace(fmt.Sprint(cell))
+	if cell == nil || s == "" || s == "<nil>" || s == "invalid IP" || s == "invalid Prefix" {
+		return sortKey{kind: sortKindEmpty}
+	}
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return sortKey{kind: sortKindAddr, addr: addr, bits: addr.BitLen()}
+	}
+	if prefix, err := netip.ParsePrefix(s); err == nil {
+		return sortKey{kind: sortKindAddr, addr: prefix.Addr(), bits: prefix.Bits()}
+	}
+	if num, err := strconv.ParseFloat(s, 64); err == nil {
+		return sortKey{kind: sortKindNumber, num: num}
+	}
+	return sortKey{kind: sortKindString, str: s}
+}
+
+func (k sortKey) compare(o sortKey, preferIPv6 bool) int {
+	if k.kind != o.kind {
+		return int(k.kind) - int(o.kind)
+	}
+	switch k.kind {
+	case sortKindAddr:
+		if k.addr.Is6() != o.addr.Is6() {
+			if k.addr.Is6() == preferIPv6 {
+				return -1
+			}
+			return 1
+		}
+		if c := k.addr.Compare(o.addr); c != 0 {
+			return c
+		}
+		return k.bits - o.bits
+	case sortKindNumber:
+		switch {
+		case k.num < o.num:
+			return -1
+		case k.num > o.num:
+			return 1
+		}
+		return 0
+	default:
+		return strings.Compare(k.str, o.str)
+	}
+}
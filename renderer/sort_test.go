@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SortOrder", func() {
+	addr := func(s string) *netip.Addr {
+		addr := netip.MustParseAddr(s)
+		return &addr
+	}
+
+	It("should sort addresses in address order", func() {
+		data := &renderer.TableData{
+			Headers: []any{"ID", "IP"},
+			Columns: [][]any{{"a", addr("10.0.0.10")}, {"b", addr("fc00::1")}, {"c", ""}, {"d", addr("10.0.0.2")}, {"e", addr("9.0.0.1")}},
+		}
+		Expect(renderer.SortOrder(data, "ip", false)).To(Equal([]int{4, 3, 0, 1, 2}))
+	})
+
+	It("should sort IPv6 addresses first if preferred", func() {
+		data := &renderer.TableData{
+			Headers: []any{"ID", "IP"},
+			Columns: [][]any{{"a", addr("10.0.0.10")}, {"b", addr("fc00::2")}, {"c", ""}, {"d", addr("fc00::1")}, {"e", addr("9.0.0.1")}},
+		}
+		Expect(renderer.SortOrder(data, "ip", true)).To(Equal([]int{3, 1, 4, 0, 2}))
+	})
+
+	It("should sort prefixes by address and length", func() {
+		data := &renderer.TableData{
+			Headers: []any{"Prefix"},
+			Columns: [][]any{{netip.MustParsePrefix("10.0.0.0/16")}, {netip.MustParsePrefix("10.0.0.0/8")}, {netip.MustParsePrefix("2.0.0.0/8")}},
+		}
+		Expect(renderer.SortOrder(data, "Prefix", false)).To(Equal([]int{2, 1, 0}))
+	})
+
+	It("should sort numbers numerically and keep the order of equal rows", func() {
+		data := &renderer.TableData{
+			Headers: []any{"ID", "VNI"},
+			Columns: [][]any{{"a", uint32(100)}, {"b", uint32(20)}, {"c", uint32(100)}},
+		}
+		Expect(renderer.SortOrder(data, "VNI", false)).To(Equal([]int{1, 0, 2}))
+	})
+
+	It("should fail for an unknown column", func() {
+		data := &renderer.TableData{Headers: []any{"ID", "VNI"}}
+		_, err := renderer.SortOrder(data, "device", false)
+		Expect(err).To(MatchError(`unknown column "device", available columns: ID, VNI`))
+	})
+})
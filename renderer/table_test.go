@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"bytes"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Table", func() {
+	prefix := func(s, underlayRoute string) api.Prefix {
+		route := netip.MustParseAddr(underlayRoute)
+		return api.Prefix{Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix(s), UnderlayRoute: &route}}
+	}
+	list := &api.PrefixList{Items: []api.Prefix{prefix("10.0.0.0/24", "fc00::1"), prefix("10.0.1.0/24", "fc00::2")}}
+
+	It("should render only the rows with no headers", func() {
+		var buf bytes.Buffer
+		table := renderer.NewTable(&buf, renderer.DefaultTableConverter)
+		table.SetNoHeaders(true)
+		Expect(table.Render(list)).To(Succeed())
+		Expect(buf.String()).To(Equal(" 10.0.0.0/24  fc00::1 \n 10.0.1.0/24  fc00::2 \n"))
+	})
+})
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// Modes for keys that are missing in the rendered object, see the missingkey option of text/template.
+const (
+	MissingKeyError   = "error"
+	MissingKeyZero    = "zero"
+	MissingKeyDefault = "default"
+)
+
+// MissingKeyModes are the supported values of the missing key mode of GoTemplate.
+var MissingKeyModes = []string{MissingKeyError, MissingKeyZero, MissingKeyDefault}
+
+// emptyIfNilFunc is appended to the pipelines of all actions with MissingKeyZero. The zero value
+// of the entries of generic maps is nil, which text/template would print as "<no value>".
+const emptyIfNilFunc = "emptyIfNil"
+
+func emptyIfNil(v any) any {
+	if v == nil {
+		return ""
+	}
+	return v
+}
+
+// GoTemplate renders objects with a go template. Like with kubectl, the template is applied
+// to the JSON representation of the object, so fields are referenced by their JSON names.
+type GoTemplate struct {
+	w    io.Writer
+	tmpl *template.Template
+}
+
+func NewGoTemplate(w io.Writer, text, missingKey string) (*GoTemplate, error) {
+	switch missingKey {
+	case MissingKeyError, MissingKeyZero, MissingKeyDefault:
+	default:
+		return nil, fmt.Errorf("invalid missing key mode %q, must be one of %s", missingKey, strings.Join(MissingKeyModes, ", "))
+	}
+
+	tmpl, err := template.New("output").
+		Option("missingkey=" + missingKey).
+		Funcs(template.FuncMap{emptyIfNilFunc: emptyIfNil}).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template: %w", err)
+	}
+	if missingKey == MissingKeyZero {
+		for _, t := range tmpl.Templates() {
+			appendToActions(t.Tree, t.Tree.Root, emptyIfNilFunc)
+		}
+	}
+	return &GoTemplate{w, tmpl}, nil
+}
+
+// appendToActions appends a call of the function fn to the pipeline of every action below node
+// that prints its value.
+func appendToActions(tree *parse.Tree, node parse.Node, fn string) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+		for _, n := range node.Nodes {
+			appendToActions(tree, n, fn)
+		}
+	case *parse.ActionNode:
+		if len(node.Pipe.Decl) > 0 {
+			return
+		}
+		node.Pipe.Cmds = append(node.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      node.Pos,
+			Args:     []parse.Node{parse.NewIdentifier(fn).SetTree(tree).SetPos(node.Pos)},
+		})
+	case *parse.IfNode:
+		appendToActions(tree, node.List, fn)
+		appendToActions(tree, node.ElseList, fn)
+	case *parse.RangeNode:
+		appendToActions(tree, node.List, fn)
+		appendToActions(tree, node.ElseList, fn)
+	case *parse.WithNode:
+		appendToActions(tree, node.List, fn)
+		appendToActions(tree, node.ElseList, fn)
+	}
+}
+
+func (g *GoTemplate) Render(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var obj any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := g.tmpl.Execute(&buf, obj); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+	_, err = buf.WriteTo(g.w)
+	return err
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer_test
+
+import (
+	"bytes"
+	"net/netip"
+
+	"github.com/ironcore-dev/dpservice-cli/renderer"
+	"github.com/ironcore-dev/dpservice-go/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GoTemplate", func() {
+	underlay := netip.MustParseAddr("fc00::1")
+	list := &api.PrefixList{
+		TypeMeta: api.TypeMeta{Kind: api.PrefixListKind},
+		Items: []api.Prefix{
+			{Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.1.0/24"), UnderlayRoute: &underlay}},
+			{Spec: api.PrefixSpec{Prefix: netip.MustParsePrefix("10.0.2.0/24")}},
+		},
+	}
+	const text = `{{range .items}}{{.spec.prefix}} {{.spec.underlay_route}};{{end}}`
+
+	DescribeTable("missing keys",
+		func(missingKey, expected string) {
+			var buf bytes.Buffer
+			tmpl, err := renderer.NewGoTemplate(&buf, text, missingKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = tmpl.Render(list)
+			if expected == "" {
+				Expect(err).To(MatchError(ContainSubstring("map has no entry for key")))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal(expected))
+		},
+		Entry("zero renders missing keys empty", renderer.MissingKeyZero, "10.0.1.0/24 fc00::1;10.0.2.0/24 ;"),
+		Entry("default renders missing keys as no value", renderer.MissingKeyDefault, "10.0.1.0/24 fc00::1;10.0.2.0/24 <no value>;"),
+		Entry("error fails on missing keys", renderer.MissingKeyError, ""),
+	)
+
+	It("should render only missing keys empty with zero", func() {
+		iface := &api.Interface{
+			TypeMeta: api.TypeMeta{Kind: api.InterfaceKind},
+			Spec:     api.InterfaceSpec{Device: "<no value>"},
+		}
+		var buf bytes.Buffer
+		tmpl, err := renderer.NewGoTemplate(&buf, `{{.spec.device}};{{with .spec}}{{.pxe}}{{end}};{{$v := .spec.vni}}{{$v}}`, renderer.MissingKeyZero)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tmpl.Render(iface)).To(Succeed())
+		Expect(buf.String()).To(Equal("<no value>;;0"))
+	})
+
+	It("should reject unknown missing key modes", func() {
+		_, err := renderer.NewGoTemplate(&bytes.Buffer{}, text, "invalid")
+		Expect(err).To(MatchError(ContainSubstring("invalid missing key mode")))
+	})
+})
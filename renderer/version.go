@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: 2022 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package renderer
+
+import (
+	"github.com/ironcore-dev/dpservice-go/api"
+)
+
+const VersionsKind = "Versions"
+
+// Versions are the versions of dpservice-cli and, if it was reachable, of dpservice.
+type Versions struct {
+	api.TypeMeta   `json:",inline"`
+	ClientVersion  string `json:"clientVersion"`
+	ServerVersion  string `json:"serverVersion,omitempty"`
+	ServerProtocol string `json:"serverProtocol,omitempty"`
+}
+
+func (v *Versions) GetName() string {
+	return v.ClientVersion
+}
+
+func (v *Versions) GetStatus() api.Status {
+	return api.Status{}
+}
+
+func (t defaultTableConverter) versionsTable(versions Versions) (*TableData, error) {
+	return &TableData{
+		Headers: []any{"ClientVersion", "ServerVersion", "ServerProto"},
+		Columns: [][]any{{versions.ClientVersion, versions.ServerVersion, versions.ServerProtocol}},
+	}, nil
+}
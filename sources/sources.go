@@ -4,11 +4,13 @@
 package sources
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"net/url"
 	"os"
 	"path/filepath"
+	"unicode"
 
 	"github.com/ironcore-dev/dpservice-cli/dpdk/runtime"
 )
@@ -35,6 +37,10 @@ func (r *Iterator) Next() (Source, error) {
 }
 
 func NewSource(source string) (Source, error) {
+	if source == StdinSource {
+		return &StdinIterator{}, nil
+	}
+
 	u, err := url.Parse(source)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing source: %w", err)
@@ -102,6 +108,53 @@ func (f *FileSource) Ext() string {
 	return filepath.Ext(f.path)
 }
 
+// StdinSource is the source name that reads the objects from stdin.
+const StdinSource = "-"
+
+// StdinIterator reads stdin once. As stdin has no extension, the format is detected
+// from its content: JSON if it starts with { or [, YAML otherwise.
+type StdinIterator struct {
+	read bool
+}
+
+func (s *StdinIterator) Next() (ReadCloserExt, error) {
+	if s.read {
+		return nil, io.EOF
+	}
+	s.read = true
+
+	rd := bufio.NewReader(os.Stdin)
+	ext := ".yaml"
+	for {
+		b, err := rd.Peek(1)
+		if err != nil {
+			break
+		}
+		if unicode.IsSpace(rune(b[0])) {
+			_, _ = rd.ReadByte()
+			continue
+		}
+		if b[0] == '{' || b[0] == '[' {
+			ext = ".json"
+		}
+		break
+	}
+	return &readerSource{Reader: rd, ext: ext}, nil
+}
+
+type readerSource struct {
+	io.Reader
+	ext string
+}
+
+func (r *readerSource) Close() error {
+	return nil
+}
+
+func (r *readerSource) Ext() string {
+	return r.ext
+}
+
 type DirSource struct {
 	path    string
 	entries []os.DirEntry
@@ -147,28 +200,48 @@ func IterateObjects(iterator *Iterator, scheme *runtime.Scheme, f func(obj any)
 				break
 			}
 
-			newDecoder, err := runtime.NewExtDecoderFactory(rce.Ext())
+			err = iterateSourceObjects(rce, scheme, f)
+			_ = rce.Close()
 			if err != nil {
 				return err
 			}
+		}
+	}
+	return nil
+}
 
-			decoder := runtime.NewKindDecoder(runtime.DefaultScheme, runtime.NewPeekDecoder(rce, newDecoder))
-			for {
-				obj, err := decoder.Next()
-				if err != nil {
-					if err != io.EOF {
-						return err
-					}
-					break
-				}
+func iterateSourceObjects(rce ReadCloserExt, scheme *runtime.Scheme, f func(obj any) error) error {
+	newDecoder, err := runtime.NewExtDecoderFactory(rce.Ext())
+	if err != nil {
+		return err
+	}
 
-				if err := f(obj); err != nil {
-					return err
-				}
+	decoder := runtime.NewKindDecoder(scheme, runtime.NewPeekDecoder(rce, newDecoder))
+	for {
+		obj, err := decoder.Next()
+		if err != nil {
+			if err != io.EOF {
+				return err
 			}
+			return nil
+		}
+
+		if err := f(obj); err != nil {
+			return err
 		}
 	}
-	return nil
+}
+
+// DecodeObjects decodes all objects of rce in the format given by its extension.
+func DecodeObjects(rce ReadCloserExt, scheme *runtime.Scheme) ([]any, error) {
+	var objs []any
+	if err := iterateSourceObjects(rce, scheme, func(obj any) error {
+		objs = append(objs, obj)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return objs, nil
 }
 
 func CollectObjects(iterator *Iterator, scheme *runtime.Scheme) ([]any, error) {
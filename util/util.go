@@ -3,6 +3,11 @@
 
 package util
 
+import (
+	"errors"
+	"sync"
+)
+
 var (
 	BuildVersion string
 )
@@ -12,3 +17,23 @@ func Must(err error) {
 		panic(err)
 	}
 }
+
+// ForEachBounded calls f for 0 <= i < n with at most limit calls running concurrently.
+func ForEachBounded(n, limit int, f func(i int) error) error {
+	var (
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, limit)
+		errs = make([]error, n)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = f(i)
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}